@@ -26,6 +26,7 @@ import (
 	log "github.com/golang/glog"
 	// log "k8s.io/klog"
 
+	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
 	"github.com/altinity/clickhouse-operator/pkg/chop"
 	"github.com/altinity/clickhouse-operator/pkg/version"
 )
@@ -37,6 +38,7 @@ const (
 
 	metricsPath = "/metrics"
 	chiListPath = "/chi"
+	chkListPath = "/chk"
 )
 
 // CLI parameter variables
@@ -57,6 +59,9 @@ var (
 	metricsEP string
 
 	chiListEP string
+
+	// sharded defines whether exporter runs as a replica of the sharded metrics exporter Deployment
+	sharded bool
 )
 
 func init() {
@@ -66,6 +71,7 @@ func init() {
 	flag.StringVar(&masterURL, "master", "", "The address of custom Kubernetes API server. Makes sense if runs outside of the cluster and not being specified in kube config file only.")
 	flag.StringVar(&metricsEP, "metrics-endpoint", defaultMetricsEndpoint, "The Prometheus exporter endpoint.")
 	flag.StringVar(&chiListEP, "chi-list-endpoint", defaultChiListEndPoint, "The CHI list endpoint.")
+	flag.BoolVar(&sharded, "sharded", false, "Run as a replica of the sharded metrics exporter Deployment. Requires sharding to be enabled in clickhouse-operator config.")
 	flag.Parse()
 }
 
@@ -90,7 +96,7 @@ func Run() {
 	log.Infof("Starting metrics exporter. Version:%s GitSHA:%s BuiltAt:%s\n", version.Version, version.GitSHA, version.BuiltAt)
 
 	// Initialize k8s API clients
-	kubeClient, _, chopClient := chop.GetClientset(kubeConfigFile, masterURL)
+	kubeClient, _, chopClient, _ := chop.GetClientset(kubeConfigFile, masterURL)
 
 	// Create operator instance
 	chop.New(kubeClient, chopClient, chopConfigFile)
@@ -103,9 +109,28 @@ func Run() {
 
 		chiListEP,
 		chiListPath,
+		chkListPath,
 	)
 
-	exporter.DiscoveryWatchedCHIs(kubeClient, chopClient)
+	if chop.Config().ClickHouse.Metrics.Scrape.Background.IsTrue() {
+		exporter.StartBackgroundScrape(ctx, chop.Config().ClickHouse.Metrics.Scrape.Interval)
+	}
 
-	<-ctx.Done()
+	switch {
+	case sharded:
+		// Watched CHIs are discovered and shared among replicas of the Deployment
+		namespace, _ := chop.Get().ConfigManager.GetRuntimeParam(deployment.OPERATOR_POD_NAMESPACE)
+		identity, _ := chop.Get().ConfigManager.GetRuntimeParam(deployment.OPERATOR_POD_NAME)
+		if identity == "" {
+			identity, _ = os.Hostname()
+		}
+		exporter.RunSharded(ctx, kubeClient, chopClient, namespace, identity)
+	case chop.Config().ClickHouse.Metrics.Sharding.IsEnabled():
+		// CHIs are watched by the sharded metrics exporter Deployment, keepers are watched only
+		log.Info("Metrics exporter sharding is enabled, CHIs are watched by the sharded metrics exporter replicas")
+		<-ctx.Done()
+	default:
+		exporter.DiscoveryWatchedCHIs(kubeClient, chopClient)
+		<-ctx.Done()
+	}
 }
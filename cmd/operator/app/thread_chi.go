@@ -56,7 +56,7 @@ func initClickHouse(ctx context.Context) {
 	}
 
 	// Initialize k8s API clients
-	kubeClient, extClient, chopClient := chop.GetClientset(kubeConfigFile, masterURL)
+	kubeClient, extClient, chopClient, dynamicClient := chop.GetClientset(kubeConfigFile, masterURL)
 
 	// Create operator instance
 	chop.New(kubeClient, chopClient, chopConfigFile)
@@ -80,6 +80,7 @@ func initClickHouse(ctx context.Context) {
 		chopClient,
 		extClient,
 		kubeClient,
+		dynamicClient,
 		chopInformerFactory,
 		kubeInformerFactory,
 	)
@@ -54,21 +54,26 @@ func initKeeper(ctx context.Context) error {
 		return err
 	}
 
+	keeperController := &controller.Controller{
+		Client: manager.GetClient(),
+		Scheme: manager.GetScheme(),
+	}
 	err = ctrlRuntime.
 		NewControllerManagedBy(manager).
 		For(&api.ClickHouseKeeperInstallation{}).
 		Owns(&apps.StatefulSet{}).
-		Complete(
-			&controller.Controller{
-				Client: manager.GetClient(),
-				Scheme: manager.GetScheme(),
-			},
-		)
+		Complete(keeperController)
 	if err != nil {
 		logger.Error(err, "init keeper - unable to ctrlRuntime.NewControllerManagedBy")
 		return err
 	}
 
+	// Periodic tasks run alongside the controller
+	if err = manager.Add(keeperController); err != nil {
+		logger.Error(err, "init keeper - unable to manager.Add")
+		return err
+	}
+
 	// Initialization successful
 	return nil
 }
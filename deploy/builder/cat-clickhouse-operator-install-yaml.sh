@@ -152,8 +152,24 @@ if [[ "${MANIFEST_PRINT_CRD}" == "yes" ]]; then
     ensure_file "${TEMPLATES_DIR}" "${SECTION_FILE_NAME}" "${REPO_PATH_TEMPLATES_PATH}"
     render_separator
     cat "${TEMPLATES_DIR}/${SECTION_FILE_NAME}" | \
+        KIND="ClickHouseKeeperInstallation"       \
+        SINGULAR="clickhousekeeperinstallation"   \
+        PLURAL="clickhousekeeperinstallations"    \
+        SHORT="chk"                               \
         OPERATOR_VERSION="${OPERATOR_VERSION}"    \
         envsubst
+
+    # Render CHKT
+    SECTION_FILE_NAME="clickhouse-operator-install-yaml-template-01-section-crd-03-chk.yaml"
+    ensure_file "${TEMPLATES_DIR}" "${SECTION_FILE_NAME}" "${REPO_PATH_TEMPLATES_PATH}"
+    render_separator
+    cat "${TEMPLATES_DIR}/${SECTION_FILE_NAME}" | \
+        KIND="ClickHouseKeeperInstallationTemplate"     \
+        SINGULAR="clickhousekeeperinstallationtemplate" \
+        PLURAL="clickhousekeeperinstallationtemplates"  \
+        SHORT="chkt"                                    \
+        OPERATOR_VERSION="${OPERATOR_VERSION}"          \
+        envsubst
 fi
 
 # Render RBAC section for ClusterRole
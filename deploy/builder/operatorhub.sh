@@ -88,6 +88,7 @@ CHI="clickhouseinstallations.clickhouse.altinity.com"
 CHIT="clickhouseinstallationtemplates.clickhouse.altinity.com"
 CONF="clickhouseoperatorconfigurations.clickhouse.altinity.com"
 CHK="clickhousekeeperinstallations.clickhouse-keeper.altinity.com"
+CHKT="clickhousekeeperinstallationtemplates.clickhouse-keeper.altinity.com"
 
 # Build partial .yaml manifest(s)
 MANIFEST_PRINT_CRD="yes" \
@@ -121,6 +122,14 @@ MANIFEST_PRINT_DEPLOYMENT="no" \
 MANIFEST_PRINT_SERVICE_METRICS="no" \
 "${CUR_DIR}/cat-clickhouse-operator-install-yaml.sh" | yq "select(.metadata.name == \"${CHK}\")" > "${MANIFESTS_DIR}/${CHK}.crd.yaml"
 
+# Build partial .yaml manifest(s)
+MANIFEST_PRINT_CRD="yes" \
+MANIFEST_PRINT_RBAC_CLUSTERED="no" \
+MANIFEST_PRINT_RBAC_NAMESPACED="no" \
+MANIFEST_PRINT_DEPLOYMENT="no" \
+MANIFEST_PRINT_SERVICE_METRICS="no" \
+"${CUR_DIR}/cat-clickhouse-operator-install-yaml.sh" | yq "select(.metadata.name == \"${CHKT}\")" > "${MANIFESTS_DIR}/${CHKT}.crd.yaml"
+
 # TODO
 # Package file not used any more?
 #cat <<EOF > "${OPERATORHUB_DIR}/clickhouse.package.yaml"
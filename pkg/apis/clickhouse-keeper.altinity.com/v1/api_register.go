@@ -41,5 +41,7 @@ func init() {
 	SchemeBuilder.Register(
 		&ClickHouseKeeperInstallation{},
 		&ClickHouseKeeperInstallationList{},
+		&ClickHouseKeeperInstallationTemplate{},
+		&ClickHouseKeeperInstallationTemplateList{},
 	)
 }
@@ -20,5 +20,6 @@ package v1
 
 // Possible kinds of CRDs
 const (
-	ClickHouseKeeperInstallationCRDResourceKind = "ClickHouseKeeperInstallation"
+	ClickHouseKeeperInstallationCRDResourceKind         = "ClickHouseKeeperInstallation"
+	ClickHouseKeeperInstallationTemplateCRDResourceKind = "ClickHouseKeeperInstallationTemplate"
 )
@@ -0,0 +1,190 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	core "k8s.io/api/core/v1"
+
+	apiChi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// ChkBackup defines periodic backup of keeper snapshots and logs into S3-compatible object storage.
+// Backup is taken from the current raft leader, as it has the most recent state.
+type ChkBackup struct {
+	// Schedule specifies cron expression of moments to take backup. Evaluated in UTC
+	Schedule *types.String `json:"schedule,omitempty"    yaml:"schedule,omitempty"`
+	// Retention specifies how many most recent backups are kept in the storage. All backups are kept if not specified
+	Retention *types.Int32 `json:"retention,omitempty"   yaml:"retention,omitempty"`
+	// Image specifies image of backup container. Image is expected to provide `sh`, `tar` and MinIO client `mc`
+	Image *types.String `json:"image,omitempty"       yaml:"image,omitempty"`
+	// S3 specifies object storage backups are stored in
+	S3 *ChkBackupS3 `json:"s3,omitempty"          yaml:"s3,omitempty"`
+	// RestoreFrom specifies name of the backup new keeper hosts are bootstrapped from.
+	// Applied to hosts being created with no snapshots only, thus running hosts are never overwritten.
+	RestoreFrom *types.String `json:"restoreFrom,omitempty" yaml:"restoreFrom,omitempty"`
+}
+
+// ChkBackupS3 defines S3-compatible object storage
+type ChkBackupS3 struct {
+	// Endpoint specifies URL of the storage, e.g. http://minio.minio.svc:9000
+	Endpoint *types.String `json:"endpoint,omitempty"        yaml:"endpoint,omitempty"`
+	// Bucket specifies bucket backups are stored in
+	Bucket *types.String `json:"bucket,omitempty"          yaml:"bucket,omitempty"`
+	// Path specifies path prefix within the bucket
+	Path *types.String `json:"path,omitempty"            yaml:"path,omitempty"`
+	// AccessKeyID specifies secret key to get access key id from
+	AccessKeyID *core.SecretKeySelector `json:"accessKeyID,omitempty"     yaml:"accessKeyID,omitempty"`
+	// SecretAccessKey specifies secret key to get secret access key from
+	SecretAccessKey *core.SecretKeySelector `json:"secretAccessKey,omitempty" yaml:"secretAccessKey,omitempty"`
+}
+
+// NewChkBackup creates new backup
+func NewChkBackup() *ChkBackup {
+	return new(ChkBackup)
+}
+
+// HasSchedule checks whether backup is scheduled
+func (b *ChkBackup) HasSchedule() bool {
+	if b == nil {
+		return false
+	}
+	return b.Schedule.HasValue() && b.HasS3()
+}
+
+// GetSchedule gets schedule cron expression
+func (b *ChkBackup) GetSchedule() string {
+	if b == nil {
+		return ""
+	}
+	return b.Schedule.Value()
+}
+
+// GetRetention gets number of backups to keep
+func (b *ChkBackup) GetRetention() int {
+	if b == nil {
+		return 0
+	}
+	return b.Retention.IntValue()
+}
+
+// GetImage gets backup container image
+func (b *ChkBackup) GetImage() string {
+	if b == nil {
+		return ""
+	}
+	return b.Image.Value()
+}
+
+// HasS3 checks whether object storage is specified
+func (b *ChkBackup) HasS3() bool {
+	if b == nil {
+		return false
+	}
+	return b.S3 != nil
+}
+
+// GetS3 gets object storage
+func (b *ChkBackup) GetS3() *ChkBackupS3 {
+	if b == nil {
+		return nil
+	}
+	return b.S3
+}
+
+// HasRestoreFrom checks whether hosts are to be bootstrapped from a backup
+func (b *ChkBackup) HasRestoreFrom() bool {
+	if b == nil {
+		return false
+	}
+	return b.RestoreFrom.HasValue() && b.HasS3()
+}
+
+// GetRestoreFrom gets name of the backup to bootstrap hosts from
+func (b *ChkBackup) GetRestoreFrom() string {
+	if b == nil {
+		return ""
+	}
+	return b.RestoreFrom.Value()
+}
+
+// MergeFrom merges from specified backup
+func (b *ChkBackup) MergeFrom(from *ChkBackup, _type apiChi.MergeType) *ChkBackup {
+	if from == nil {
+		return b
+	}
+
+	if b == nil {
+		b = NewChkBackup()
+	}
+
+	switch _type {
+	case apiChi.MergeTypeFillEmptyValues:
+		b.Schedule = b.Schedule.MergeFrom(from.Schedule)
+		b.Retention = b.Retention.MergeFrom(from.Retention)
+		b.Image = b.Image.MergeFrom(from.Image)
+		b.RestoreFrom = b.RestoreFrom.MergeFrom(from.RestoreFrom)
+		if b.S3 == nil {
+			b.S3 = from.S3
+		}
+	case apiChi.MergeTypeOverrideByNonEmptyValues:
+		if from.Schedule.HasValue() {
+			// Override by non-empty values only
+			b.Schedule = from.Schedule
+		}
+		if from.Retention.HasValue() {
+			// Override by non-empty values only
+			b.Retention = from.Retention
+		}
+		if from.Image.HasValue() {
+			// Override by non-empty values only
+			b.Image = from.Image
+		}
+		if from.RestoreFrom.HasValue() {
+			// Override by non-empty values only
+			b.RestoreFrom = from.RestoreFrom
+		}
+		if from.S3 != nil {
+			// Override by non-empty values only
+			b.S3 = from.S3
+		}
+	}
+
+	return b
+}
+
+// GetEndpoint gets storage endpoint
+func (s *ChkBackupS3) GetEndpoint() string {
+	if s == nil {
+		return ""
+	}
+	return s.Endpoint.Value()
+}
+
+// GetBucket gets bucket
+func (s *ChkBackupS3) GetBucket() string {
+	if s == nil {
+		return ""
+	}
+	return s.Bucket.Value()
+}
+
+// GetPath gets path prefix within the bucket
+func (s *ChkBackupS3) GetPath() string {
+	if s == nil {
+		return ""
+	}
+	return s.Path.Value()
+}
@@ -165,7 +165,7 @@ func (cr *ClickHouseKeeperInstallation) SetTarget(a *ClickHouseKeeperInstallatio
 }
 
 func (cr *ClickHouseKeeperInstallation) GetUsedTemplates() []*apiChi.TemplateRef {
-	return nil
+	return cr.GetSpecT().UseTemplates
 }
 
 // FillStatus fills .Status
@@ -392,22 +392,37 @@ func (cr *ClickHouseKeeperInstallation) FoundIn(haystack []*ClickHouseKeeperInst
 
 // IsAuto checks whether templating policy is auto
 func (cr *ClickHouseKeeperInstallation) IsAuto() bool {
-	return false
+	if cr == nil {
+		return false
+	}
+	if (cr.Namespace == "") && (cr.Name == "") {
+		return false
+	}
+	return cr.GetSpecT().GetTemplating().GetPolicy() == apiChi.TemplatingPolicyAuto
 }
 
-// IsStopped checks whether CHI is stopped
+// IsStopped checks whether CHK is stopped
 func (cr *ClickHouseKeeperInstallation) IsStopped() bool {
-	return false
+	if cr == nil {
+		return false
+	}
+	return cr.GetSpecT().GetStop().Value()
 }
 
-// IsRollingUpdate checks whether CHI should perform rolling update
+// IsRollingUpdate checks whether CHK should perform rolling update
 func (cr *ClickHouseKeeperInstallation) IsRollingUpdate() bool {
-	return false
+	if cr == nil {
+		return false
+	}
+	return cr.GetSpecT().GetRestart().Value() == apiChi.RestartRollingUpdate
 }
 
-// IsTroubleshoot checks whether CHI is in troubleshoot mode
+// IsTroubleshoot checks whether CHK is in troubleshoot mode
 func (cr *ClickHouseKeeperInstallation) IsTroubleshoot() bool {
-	return false
+	if cr == nil {
+		return false
+	}
+	return cr.GetSpecT().GetTroubleshoot().Value()
 }
 
 // GetReconciling gets reconciling spec
@@ -485,6 +500,27 @@ func (cr *ClickHouseKeeperInstallation) FirstHost() *apiChi.Host {
 	return result
 }
 
+// GetZookeeperNodes gets current nodes of the CHK as they are to be used by zookeeper clients.
+// Client port is taken from the normalized CHK, in case it is available
+func (cr *ClickHouseKeeperInstallation) GetZookeeperNodes() apiChi.ZookeeperNodes {
+	port := apiChi.KpDefaultZKPortNumber
+	cr.EnsureStatus().GetNormalizedCRCompleted().WalkHosts(func(host *apiChi.Host) error {
+		if host.ZKPort.HasValue() {
+			port = host.ZKPort.Value()
+		}
+		return nil
+	})
+
+	var nodes apiChi.ZookeeperNodes
+	for _, fqdn := range cr.EnsureStatus().GetFQDNs() {
+		nodes = append(nodes, apiChi.ZookeeperNode{
+			Host: fqdn,
+			Port: types.NewInt32(port),
+		})
+	}
+	return nodes
+}
+
 func (cr *ClickHouseKeeperInstallation) GetName() string {
 	if cr == nil {
 		return ""
@@ -122,6 +122,10 @@ func (cluster *Cluster) GetPDBMaxUnavailable() *types.Int32 {
 	return types.NewInt32(1)
 }
 
+func (cluster *Cluster) GetDiscovery() *apiChi.ClusterDiscovery {
+	return nil
+}
+
 // FillShardReplicaSpecified fills whether shard or replicas are explicitly specified
 func (cluster *Cluster) FillShardReplicaSpecified() {
 	if len(cluster.Layout.Shards) > 0 {
@@ -0,0 +1,46 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+// KeeperStatus defines health of ClickHouse Keeper cluster, as reported by the keepers
+type KeeperStatus struct {
+	// QuorumHealthy specifies whether majority of the members is alive and has a leader
+	QuorumHealthy bool `json:"quorumHealthy,omitempty" yaml:"quorumHealthy,omitempty"`
+	// Leader specifies name of the host which is the leader
+	Leader string `json:"leader,omitempty"        yaml:"leader,omitempty"`
+	// Updated specifies time of the last update of the status, in RFC3339 format
+	Updated string `json:"updated,omitempty"       yaml:"updated,omitempty"`
+	// Hosts specifies status of each keeper
+	Hosts []*KeeperHostStatus `json:"hosts,omitempty"         yaml:"hosts,omitempty"`
+}
+
+// KeeperHostStatus defines status of one keeper
+type KeeperHostStatus struct {
+	Name string `json:"name,omitempty"                yaml:"name,omitempty"`
+	// OK specifies whether keeper responded to 'ruok'
+	OK                  bool   `json:"ok,omitempty"                  yaml:"ok,omitempty"`
+	Role                string `json:"role,omitempty"                yaml:"role,omitempty"`
+	Zxid                string `json:"zxid,omitempty"                yaml:"zxid,omitempty"`
+	OutstandingRequests int64  `json:"outstandingRequests,omitempty" yaml:"outstandingRequests,omitempty"`
+	ZnodeCount          int64  `json:"znodeCount,omitempty"          yaml:"znodeCount,omitempty"`
+}
+
+// IsQuorumHealthy checks whether quorum is healthy
+func (s *KeeperStatus) IsQuorumHealthy() bool {
+	if s == nil {
+		return false
+	}
+	return s.QuorumHealthy
+}
@@ -21,12 +21,18 @@ import (
 
 // ChkSpec defines spec section of ClickHouseKeeper resource
 type ChkSpec struct {
-	TaskID                 *types.String       `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
-	NamespaceDomainPattern *types.String       `json:"namespaceDomainPattern,omitempty" yaml:"namespaceDomainPattern,omitempty"`
-	Reconciling            *apiChi.Reconciling `json:"reconciling,omitempty"            yaml:"reconciling,omitempty"`
-	Defaults               *apiChi.Defaults    `json:"defaults,omitempty"               yaml:"defaults,omitempty"`
-	Configuration          *Configuration      `json:"configuration,omitempty"          yaml:"configuration,omitempty"`
-	Templates              *apiChi.Templates   `json:"templates,omitempty"              yaml:"templates,omitempty"`
+	TaskID                 *types.String         `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
+	Stop                   *types.StringBool     `json:"stop,omitempty"                   yaml:"stop,omitempty"`
+	Restart                *types.String         `json:"restart,omitempty"                yaml:"restart,omitempty"`
+	Troubleshoot           *types.StringBool     `json:"troubleshoot,omitempty"           yaml:"troubleshoot,omitempty"`
+	NamespaceDomainPattern *types.String         `json:"namespaceDomainPattern,omitempty" yaml:"namespaceDomainPattern,omitempty"`
+	Templating             *apiChi.ChiTemplating `json:"templating,omitempty"             yaml:"templating,omitempty"`
+	Reconciling            *apiChi.Reconciling   `json:"reconciling,omitempty"            yaml:"reconciling,omitempty"`
+	Backup                 *ChkBackup            `json:"backup,omitempty"                 yaml:"backup,omitempty"`
+	Defaults               *apiChi.Defaults      `json:"defaults,omitempty"               yaml:"defaults,omitempty"`
+	Configuration          *Configuration        `json:"configuration,omitempty"          yaml:"configuration,omitempty"`
+	Templates              *apiChi.Templates     `json:"templates,omitempty"              yaml:"templates,omitempty"`
+	UseTemplates           []*apiChi.TemplateRef `json:"useTemplates,omitempty"           yaml:"useTemplates,omitempty"`
 }
 
 // HasTaskID checks whether task id is specified
@@ -39,10 +45,30 @@ func (spec *ChkSpec) GetTaskID() string {
 	return spec.TaskID.Value()
 }
 
+func (spec *ChkSpec) GetStop() *types.StringBool {
+	return spec.Stop
+}
+
+func (spec *ChkSpec) GetRestart() *types.String {
+	return spec.Restart
+}
+
+func (spec *ChkSpec) GetTroubleshoot() *types.StringBool {
+	return spec.Troubleshoot
+}
+
 func (spec *ChkSpec) GetNamespaceDomainPattern() *types.String {
 	return spec.NamespaceDomainPattern
 }
 
+func (spec *ChkSpec) GetTemplating() *apiChi.ChiTemplating {
+	return spec.Templating
+}
+
+func (spec *ChkSpec) GetBackup() *ChkBackup {
+	return spec.Backup
+}
+
 func (spec *ChkSpec) GetDefaults() *apiChi.Defaults {
 	return spec.Defaults
 }
@@ -66,6 +92,15 @@ func (spec *ChkSpec) MergeFrom(from *ChkSpec, _type apiChi.MergeType) {
 		if !spec.HasTaskID() {
 			spec.TaskID = spec.TaskID.MergeFrom(from.TaskID)
 		}
+		if !spec.Stop.HasValue() {
+			spec.Stop = spec.Stop.MergeFrom(from.Stop)
+		}
+		if !spec.Restart.HasValue() {
+			spec.Restart = spec.Restart.MergeFrom(from.Restart)
+		}
+		if !spec.Troubleshoot.HasValue() {
+			spec.Troubleshoot = spec.Troubleshoot.MergeFrom(from.Troubleshoot)
+		}
 		if !spec.NamespaceDomainPattern.HasValue() {
 			spec.NamespaceDomainPattern = spec.NamespaceDomainPattern.MergeFrom(from.NamespaceDomainPattern)
 		}
@@ -73,13 +108,29 @@ func (spec *ChkSpec) MergeFrom(from *ChkSpec, _type apiChi.MergeType) {
 		if from.HasTaskID() {
 			spec.TaskID = spec.TaskID.MergeFrom(from.TaskID)
 		}
+		if from.Stop.HasValue() {
+			// Override by non-empty values only
+			spec.Stop = from.Stop
+		}
+		if from.Restart.HasValue() {
+			// Override by non-empty values only
+			spec.Restart = spec.Restart.MergeFrom(from.Restart)
+		}
+		if from.Troubleshoot.HasValue() {
+			// Override by non-empty values only
+			spec.Troubleshoot = from.Troubleshoot
+		}
 		if from.NamespaceDomainPattern.HasValue() {
 			spec.NamespaceDomainPattern = spec.NamespaceDomainPattern.MergeFrom(from.NamespaceDomainPattern)
 		}
 	}
 
+	spec.Templating = spec.Templating.MergeFrom(from.Templating, _type)
 	spec.Reconciling = spec.Reconciling.MergeFrom(from.Reconciling, _type)
+	spec.Backup = spec.Backup.MergeFrom(from.Backup, _type)
 	spec.Defaults = spec.Defaults.MergeFrom(from.Defaults, _type)
 	spec.Configuration = spec.Configuration.MergeFrom(from.Configuration, _type)
 	spec.Templates = spec.Templates.MergeFrom(from.Templates, _type)
+	// TODO may be it would be wiser to make more intelligent merge
+	spec.UseTemplates = append(spec.UseTemplates, from.UseTemplates...)
 }
@@ -76,6 +76,7 @@ type Status struct {
 	NormalizedCRCompleted  *ClickHouseKeeperInstallation `json:"normalizedCompleted,omitempty"    yaml:"normalizedCompleted,omitempty"`
 	HostsWithTablesCreated []string                      `json:"hostsWithTablesCreated,omitempty" yaml:"hostsWithTablesCreated,omitempty"`
 	UsedTemplates          []*apiChi.TemplateRef         `json:"usedTemplates,omitempty"          yaml:"usedTemplates,omitempty"`
+	Keeper                 *KeeperStatus                 `json:"keeper,omitempty"                 yaml:"keeper,omitempty"`
 
 	mu sync.RWMutex `json:"-" yaml:"-"`
 }
@@ -187,6 +188,13 @@ func (s *Status) SetAction(action string) {
 	})
 }
 
+// SetKeeper sets keeper status
+func (s *Status) SetKeeper(keeper *KeeperStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.Keeper = keeper
+	})
+}
+
 // HasNormalizedCRCompleted is a checker
 func (s *Status) HasNormalizedCRCompleted() bool {
 	return s.GetNormalizedCRCompleted() != nil
@@ -391,6 +399,10 @@ func (s *Status) CopyFrom(f *Status, opts types.CopyStatusOptions) {
 				s.NormalizedCR = from.NormalizedCR
 			}
 
+			if opts.Keeper {
+				s.Keeper = from.Keeper
+			}
+
 			if opts.WholeStatus {
 				s.CHOpVersion = from.CHOpVersion
 				s.CHOpCommit = from.CHOpCommit
@@ -420,6 +432,7 @@ func (s *Status) CopyFrom(f *Status, opts types.CopyStatusOptions) {
 				s.Endpoint = from.Endpoint
 				s.NormalizedCR = from.NormalizedCR
 				s.NormalizedCRCompleted = from.NormalizedCRCompleted
+				s.Keeper = from.Keeper
 			}
 		})
 	})
@@ -649,6 +662,15 @@ func (s *Status) GetHostsWithTablesCreated() []string {
 	})
 }
 
+// GetKeeper gets keeper status
+func (s *Status) GetKeeper() *KeeperStatus {
+	var keeper *KeeperStatus
+	doWithReadLock(s, func(s *Status) {
+		keeper = s.Keeper
+	})
+	return keeper
+}
+
 // Begin helpers
 
 func doWithWriteLock(s *Status, f func(s *Status)) {
@@ -18,6 +18,7 @@ import (
 	"sync"
 
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	apiChi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 )
@@ -60,6 +61,38 @@ func (runtime *ClickHouseKeeperInstallationRuntime) UnlockCommonConfig() {
 	runtime.commonConfigMutex.Unlock()
 }
 
+// +k8s:deepcopy-gen=false
+
+// ClickHouseKeeperInstallationTemplate defines ClickHouseKeeperInstallation template
+type ClickHouseKeeperInstallationTemplate ClickHouseKeeperInstallation
+
+// DeepCopyInto copies the receiver into out. Mutexes are not copied, out keeps its own ones.
+func (in *ClickHouseKeeperInstallationTemplate) DeepCopyInto(out *ClickHouseKeeperInstallationTemplate) {
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status.DeepCopy()
+	out.runtime = in.runtime.DeepCopy()
+}
+
+// DeepCopy copies the receiver, creating a new ClickHouseKeeperInstallationTemplate
+func (in *ClickHouseKeeperInstallationTemplate) DeepCopy() *ClickHouseKeeperInstallationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClickHouseKeeperInstallationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject copies the receiver, creating a new runtime.Object
+func (in *ClickHouseKeeperInstallationTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ClickHouseKeeperList defines a list of ClickHouseKeeper resources
@@ -68,3 +101,12 @@ type ClickHouseKeeperInstallationList struct {
 	meta.ListMeta `json:"metadata" yaml:"metadata"`
 	Items         []ClickHouseKeeperInstallation `json:"items" yaml:"items"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClickHouseKeeperInstallationTemplateList defines CHK template list
+type ClickHouseKeeperInstallationTemplateList struct {
+	meta.TypeMeta `json:",inline"  yaml:",inline"`
+	meta.ListMeta `json:"metadata" yaml:"metadata"`
+	Items         []ClickHouseKeeperInstallationTemplate `json:"items" yaml:"items"`
+}
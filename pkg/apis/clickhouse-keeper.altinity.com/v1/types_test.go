@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiChi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+func TestClickHouseKeeperInstallationTemplateDeepCopy(t *testing.T) {
+	template := &ClickHouseKeeperInstallationTemplate{
+		TypeMeta: meta.TypeMeta{Kind: "ClickHouseKeeperInstallationTemplate"},
+		ObjectMeta: meta.ObjectMeta{
+			Name:   "template",
+			Labels: map[string]string{"team": "analytics"},
+		},
+		Spec: ChkSpec{
+			TaskID: types.NewString("task"),
+			Templating: &apiChi.ChiTemplating{
+				Policy: "auto",
+			},
+			UseTemplates: []*apiChi.TemplateRef{{Name: "base"}},
+		},
+		Status:  &Status{CHOpVersion: "1.0"},
+		runtime: newClickHouseKeeperInstallationRuntime(),
+	}
+
+	// Mutexes of the source are not copied, thus a locked template can be copied
+	template.statusCreatorMutex.Lock()
+	defer template.statusCreatorMutex.Unlock()
+	template.runtimeCreatorMutex.Lock()
+	defer template.runtimeCreatorMutex.Unlock()
+
+	copied := template.DeepCopy()
+	require.Equal(t, template.TypeMeta, copied.TypeMeta)
+	require.Equal(t, template.ObjectMeta, copied.ObjectMeta)
+	require.Equal(t, template.Spec, copied.Spec)
+	require.Equal(t, template.Status, copied.Status)
+	require.NotNil(t, copied.runtime)
+	require.True(t, copied.statusCreatorMutex.TryLock())
+	require.True(t, copied.runtimeCreatorMutex.TryLock())
+
+	// Modification of the copy does not affect the source
+	copied.Labels["team"] = "ops"
+	*copied.Spec.TaskID = "other"
+	copied.Spec.Templating.Policy = "manual"
+	copied.Spec.UseTemplates[0].Name = "other"
+	copied.Status.CHOpVersion = "2.0"
+	require.NotSame(t, template.runtime, copied.runtime)
+
+	require.Equal(t, "analytics", template.Labels["team"])
+	require.Equal(t, "task", template.Spec.GetTaskID())
+	require.Equal(t, "auto", template.Spec.Templating.Policy)
+	require.Equal(t, "base", template.Spec.UseTemplates[0].Name)
+	require.Equal(t, "1.0", template.Status.CHOpVersion)
+
+	// Template is used as a runtime object
+	object, ok := template.DeepCopyObject().(*ClickHouseKeeperInstallationTemplate)
+	require.True(t, ok)
+	require.Equal(t, template.Spec, object.Spec)
+	require.Nil(t, (*ClickHouseKeeperInstallationTemplate)(nil).DeepCopy())
+}
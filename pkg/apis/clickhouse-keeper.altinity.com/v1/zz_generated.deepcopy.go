@@ -24,9 +24,92 @@ package v1
 import (
 	clickhousealtinitycomv1 "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	types "github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChkBackup) DeepCopyInto(out *ChkBackup) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ChkBackupS3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChkBackup.
+func (in *ChkBackup) DeepCopy() *ChkBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ChkBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChkBackupS3) DeepCopyInto(out *ChkBackupS3) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.AccessKeyID != nil {
+		in, out := &in.AccessKeyID, &out.AccessKeyID
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretAccessKey != nil {
+		in, out := &in.SecretAccessKey, &out.SecretAccessKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChkBackupS3.
+func (in *ChkBackupS3) DeepCopy() *ChkBackupS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(ChkBackupS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChkClusterAddress) DeepCopyInto(out *ChkClusterAddress) {
 	*out = *in
@@ -288,16 +371,41 @@ func (in *ChkSpec) DeepCopyInto(out *ChkSpec) {
 		*out = new(types.String)
 		**out = **in
 	}
+	if in.Stop != nil {
+		in, out := &in.Stop, &out.Stop
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.Restart != nil {
+		in, out := &in.Restart, &out.Restart
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Troubleshoot != nil {
+		in, out := &in.Troubleshoot, &out.Troubleshoot
+		*out = new(types.StringBool)
+		**out = **in
+	}
 	if in.NamespaceDomainPattern != nil {
 		in, out := &in.NamespaceDomainPattern, &out.NamespaceDomainPattern
 		*out = new(types.String)
 		**out = **in
 	}
+	if in.Templating != nil {
+		in, out := &in.Templating, &out.Templating
+		*out = new(clickhousealtinitycomv1.ChiTemplating)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Reconciling != nil {
 		in, out := &in.Reconciling, &out.Reconciling
 		*out = new(clickhousealtinitycomv1.Reconciling)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ChkBackup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Defaults != nil {
 		in, out := &in.Defaults, &out.Defaults
 		*out = new(clickhousealtinitycomv1.Defaults)
@@ -313,6 +421,17 @@ func (in *ChkSpec) DeepCopyInto(out *ChkSpec) {
 		*out = new(clickhousealtinitycomv1.Templates)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UseTemplates != nil {
+		in, out := &in.UseTemplates, &out.UseTemplates
+		*out = make([]*clickhousealtinitycomv1.TemplateRef, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(clickhousealtinitycomv1.TemplateRef)
+				**out = **in
+			}
+		}
+	}
 	return
 }
 
@@ -420,6 +539,39 @@ func (in *ClickHouseKeeperInstallationRuntime) DeepCopy() *ClickHouseKeeperInsta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClickHouseKeeperInstallationTemplateList) DeepCopyInto(out *ClickHouseKeeperInstallationTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClickHouseKeeperInstallationTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClickHouseKeeperInstallationTemplateList.
+func (in *ClickHouseKeeperInstallationTemplateList) DeepCopy() *ClickHouseKeeperInstallationTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClickHouseKeeperInstallationTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClickHouseKeeperInstallationTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -525,6 +677,49 @@ func (in *FillStatusParams) DeepCopy() *FillStatusParams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeeperHostStatus) DeepCopyInto(out *KeeperHostStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeeperHostStatus.
+func (in *KeeperHostStatus) DeepCopy() *KeeperHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeeperHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeeperStatus) DeepCopyInto(out *KeeperStatus) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]*KeeperHostStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(KeeperHostStatus)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeeperStatus.
+func (in *KeeperStatus) DeepCopy() *KeeperStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeeperStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -589,6 +784,11 @@ func (in *Status) DeepCopyInto(out *Status) {
 			}
 		}
 	}
+	if in.Keeper != nil {
+		in, out := &in.Keeper, &out.Keeper
+		*out = new(KeeperStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	out.mu = in.mu
 	return
 }
@@ -18,3 +18,8 @@ const (
 	// APIGroupName is the group name of the ClickHouse Operator API.
 	APIGroupName = "clickhouse.altinity.com"
 )
+
+// AnnotationScheduleKeepRunningUntil specifies annotation, which keeps scheduled CHI running till specified time
+// regardless of the schedule. Time is expected in RFC3339 format.
+// Declared at the API group level, since it has to be known to packages, which API version depends on.
+const AnnotationScheduleKeepRunningUntil = APIGroupName + "/" + "schedule-keep-running-until"
@@ -107,6 +107,7 @@ type ICluster interface {
 	GetSecure() *types.StringBool
 	GetSecret() *ClusterSecret
 	GetPDBMaxUnavailable() *types.Int32
+	GetDiscovery() *ClusterDiscovery
 
 	WalkShards(f func(index int, shard IShard) error) []error
 	WalkHosts(func(host *Host) error) []error
@@ -173,7 +173,7 @@ func (cr *ClickHouseInstallation) FillStatus(endpoint string, pods, fqdns []stri
 		CHOpIP:              ip,
 		ClustersCount:       cr.ClustersCount(),
 		ShardsCount:         cr.ShardsCount(),
-		HostsCount:          cr.HostsCount(),
+		HostsCount:          cr.HostsManagedCount(),
 		TaskID:              cr.GetSpecT().GetTaskID(),
 		HostsUpdatedCount:   0,
 		HostsAddedCount:     0,
@@ -292,6 +292,18 @@ func (cr *ClickHouseInstallation) HostsCount() int {
 	return count
 }
 
+// HostsManagedCount counts hosts managed by the operator, thus external hosts are not counted
+func (cr *ClickHouseInstallation) HostsManagedCount() int {
+	count := 0
+	cr.WalkHosts(func(host *Host) error {
+		if !host.IsExternal() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
 // HostsCountAttributes counts hosts by attributes
 func (cr *ClickHouseInstallation) HostsCountAttributes(a *HostReconcileAttributes) int {
 	count := 0
@@ -358,6 +370,40 @@ func (cr *ClickHouseInstallation) GetRootServiceTemplate() (*ServiceTemplate, bo
 	return cr.GetServiceTemplate(name)
 }
 
+// GetIngressTemplate gets IngressTemplate by name
+func (cr *ClickHouseInstallation) GetIngressTemplate(name string) (*IngressTemplate, bool) {
+	if !cr.GetSpecT().GetTemplates().GetIngressTemplatesIndex().Has(name) {
+		return nil, false
+	}
+	return cr.GetSpecT().GetTemplates().GetIngressTemplatesIndex().Get(name), true
+}
+
+// GetRootIngressTemplate gets IngressTemplate of a CHI
+func (cr *ClickHouseInstallation) GetRootIngressTemplate() (*IngressTemplate, bool) {
+	if !cr.GetSpec().GetDefaults().Templates.HasIngressTemplate() {
+		return nil, false
+	}
+	name := cr.GetSpec().GetDefaults().Templates.GetIngressTemplate()
+	return cr.GetIngressTemplate(name)
+}
+
+// GetRouteTemplate gets RouteTemplate by name
+func (cr *ClickHouseInstallation) GetRouteTemplate(name string) (*RouteTemplate, bool) {
+	if !cr.GetSpecT().GetTemplates().GetRouteTemplatesIndex().Has(name) {
+		return nil, false
+	}
+	return cr.GetSpecT().GetTemplates().GetRouteTemplatesIndex().Get(name), true
+}
+
+// GetRootRouteTemplate gets RouteTemplate of a CHI
+func (cr *ClickHouseInstallation) GetRootRouteTemplate() (*RouteTemplate, bool) {
+	if !cr.GetSpec().GetDefaults().Templates.HasRouteTemplate() {
+		return nil, false
+	}
+	name := cr.GetSpec().GetDefaults().Templates.GetRouteTemplate()
+	return cr.GetRouteTemplate(name)
+}
+
 // MatchNamespace matches namespace
 func (cr *ClickHouseInstallation) MatchNamespace(namespace string) bool {
 	if cr == nil {
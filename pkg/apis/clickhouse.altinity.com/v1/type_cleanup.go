@@ -62,7 +62,10 @@ func (t *Cleanup) DefaultUnknownObjects() *ObjectsCleanup {
 		SetStatefulSet(ObjectsCleanupDelete).
 		SetPVC(ObjectsCleanupDelete).
 		SetConfigMap(ObjectsCleanupDelete).
-		SetService(ObjectsCleanupDelete)
+		SetService(ObjectsCleanupDelete).
+		SetIngress(ObjectsCleanupDelete).
+		SetRoute(ObjectsCleanupDelete).
+		SetNetworkPolicy(ObjectsCleanupDelete)
 }
 
 // GetReconcileFailedObjects gets failed objects cleanup
@@ -79,7 +82,10 @@ func (t *Cleanup) DefaultReconcileFailedObjects() *ObjectsCleanup {
 		SetStatefulSet(ObjectsCleanupRetain).
 		SetPVC(ObjectsCleanupRetain).
 		SetConfigMap(ObjectsCleanupRetain).
-		SetService(ObjectsCleanupRetain)
+		SetService(ObjectsCleanupRetain).
+		SetIngress(ObjectsCleanupRetain).
+		SetRoute(ObjectsCleanupRetain).
+		SetNetworkPolicy(ObjectsCleanupRetain)
 }
 
 // SetDefaults set defaults for cleanup
@@ -101,11 +107,14 @@ const (
 
 // ObjectsCleanup specifies object cleanup struct
 type ObjectsCleanup struct {
-	StatefulSet string `json:"statefulSet,omitempty" yaml:"statefulSet,omitempty"`
-	PVC         string `json:"pvc,omitempty"         yaml:"pvc,omitempty"`
-	ConfigMap   string `json:"configMap,omitempty"   yaml:"configMap,omitempty"`
-	Service     string `json:"service,omitempty"     yaml:"service,omitempty"`
-	Secret      string `json:"secret,omitempty"      yaml:"secret,omitempty"`
+	StatefulSet   string `json:"statefulSet,omitempty" yaml:"statefulSet,omitempty"`
+	PVC           string `json:"pvc,omitempty"         yaml:"pvc,omitempty"`
+	ConfigMap     string `json:"configMap,omitempty"   yaml:"configMap,omitempty"`
+	Service       string `json:"service,omitempty"     yaml:"service,omitempty"`
+	Secret        string `json:"secret,omitempty"      yaml:"secret,omitempty"`
+	Ingress       string `json:"ingress,omitempty"       yaml:"ingress,omitempty"`
+	Route         string `json:"route,omitempty"         yaml:"route,omitempty"`
+	NetworkPolicy string `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
 }
 
 // NewObjectsCleanup creates new object cleanup
@@ -140,6 +149,15 @@ func (c *ObjectsCleanup) MergeFrom(from *ObjectsCleanup, _type MergeType) *Objec
 		if c.Secret == "" {
 			c.Secret = from.Secret
 		}
+		if c.Ingress == "" {
+			c.Ingress = from.Ingress
+		}
+		if c.Route == "" {
+			c.Route = from.Route
+		}
+		if c.NetworkPolicy == "" {
+			c.NetworkPolicy = from.NetworkPolicy
+		}
 	case MergeTypeOverrideByNonEmptyValues:
 		if from.StatefulSet != "" {
 			// Override by non-empty values only
@@ -161,6 +179,18 @@ func (c *ObjectsCleanup) MergeFrom(from *ObjectsCleanup, _type MergeType) *Objec
 			// Override by non-empty values only
 			c.Secret = from.Secret
 		}
+		if from.Ingress != "" {
+			// Override by non-empty values only
+			c.Ingress = from.Ingress
+		}
+		if from.Route != "" {
+			// Override by non-empty values only
+			c.Route = from.Route
+		}
+		if from.NetworkPolicy != "" {
+			// Override by non-empty values only
+			c.NetworkPolicy = from.NetworkPolicy
+		}
 	}
 
 	return c
@@ -250,3 +280,54 @@ func (c *ObjectsCleanup) SetSecret(v string) *ObjectsCleanup {
 	c.Secret = v
 	return c
 }
+
+// GetIngress gets ingress
+func (c *ObjectsCleanup) GetIngress() string {
+	if c == nil {
+		return ""
+	}
+	return c.Ingress
+}
+
+// SetIngress sets ingress
+func (c *ObjectsCleanup) SetIngress(v string) *ObjectsCleanup {
+	if c == nil {
+		return nil
+	}
+	c.Ingress = v
+	return c
+}
+
+// GetRoute gets route
+func (c *ObjectsCleanup) GetRoute() string {
+	if c == nil {
+		return ""
+	}
+	return c.Route
+}
+
+// SetRoute sets route
+func (c *ObjectsCleanup) SetRoute(v string) *ObjectsCleanup {
+	if c == nil {
+		return nil
+	}
+	c.Route = v
+	return c
+}
+
+// GetNetworkPolicy gets network policy
+func (c *ObjectsCleanup) GetNetworkPolicy() string {
+	if c == nil {
+		return ""
+	}
+	return c.NetworkPolicy
+}
+
+// SetNetworkPolicy sets network policy
+func (c *ObjectsCleanup) SetNetworkPolicy(v string) *ObjectsCleanup {
+	if c == nil {
+		return nil
+	}
+	c.NetworkPolicy = v
+	return c
+}
@@ -20,17 +20,19 @@ import (
 
 // Cluster defines item of a clusters section of .configuration
 type Cluster struct {
-	Name              string            `json:"name,omitempty"              yaml:"name,omitempty"`
-	Zookeeper         *ZookeeperConfig  `json:"zookeeper,omitempty"         yaml:"zookeeper,omitempty"`
-	Settings          *Settings         `json:"settings,omitempty"          yaml:"settings,omitempty"`
-	Files             *Settings         `json:"files,omitempty"             yaml:"files,omitempty"`
-	Templates         *TemplatesList    `json:"templates,omitempty"         yaml:"templates,omitempty"`
-	SchemaPolicy      *SchemaPolicy     `json:"schemaPolicy,omitempty"      yaml:"schemaPolicy,omitempty"`
-	Insecure          *types.StringBool `json:"insecure,omitempty"          yaml:"insecure,omitempty"`
-	Secure            *types.StringBool `json:"secure,omitempty"            yaml:"secure,omitempty"`
-	Secret            *ClusterSecret    `json:"secret,omitempty"            yaml:"secret,omitempty"`
-	PDBMaxUnavailable *types.Int32      `json:"pdbMaxUnavailable,omitempty" yaml:"pdbMaxUnavailable,omitempty"`
-	Layout            *ChiClusterLayout `json:"layout,omitempty"            yaml:"layout,omitempty"`
+	Name              string             `json:"name,omitempty"              yaml:"name,omitempty"`
+	Zookeeper         *ZookeeperConfig   `json:"zookeeper,omitempty"         yaml:"zookeeper,omitempty"`
+	Settings          *Settings          `json:"settings,omitempty"          yaml:"settings,omitempty"`
+	Files             *Settings          `json:"files,omitempty"             yaml:"files,omitempty"`
+	Templates         *TemplatesList     `json:"templates,omitempty"         yaml:"templates,omitempty"`
+	SchemaPolicy      *SchemaPolicy      `json:"schemaPolicy,omitempty"      yaml:"schemaPolicy,omitempty"`
+	Insecure          *types.StringBool  `json:"insecure,omitempty"          yaml:"insecure,omitempty"`
+	Secure            *types.StringBool  `json:"secure,omitempty"            yaml:"secure,omitempty"`
+	Secret            *ClusterSecret     `json:"secret,omitempty"            yaml:"secret,omitempty"`
+	PDBMaxUnavailable *types.Int32       `json:"pdbMaxUnavailable,omitempty" yaml:"pdbMaxUnavailable,omitempty"`
+	Layout            *ChiClusterLayout  `json:"layout,omitempty"            yaml:"layout,omitempty"`
+	Federation        *ClusterFederation `json:"federation,omitempty"        yaml:"federation,omitempty"`
+	Discovery         *ClusterDiscovery  `json:"discovery,omitempty"         yaml:"discovery,omitempty"`
 
 	Runtime ChiClusterRuntime `json:"-" yaml:"-"`
 }
@@ -38,6 +40,8 @@ type Cluster struct {
 type ChiClusterRuntime struct {
 	Address ChiClusterAddress       `json:"-" yaml:"-"`
 	CHI     *ClickHouseInstallation `json:"-" yaml:"-" testdiff:"ignore"`
+	// LayoutReplicasCount is replicas count specified by the layout of the scaled cluster, before scaling is applied
+	LayoutReplicasCount int `json:"-" yaml:"-"`
 }
 
 func (r *ChiClusterRuntime) GetAddress() IClusterAddress {
@@ -132,6 +136,22 @@ func (cluster *Cluster) GetPDBMaxUnavailable() *types.Int32 {
 	return cluster.PDBMaxUnavailable
 }
 
+// GetDiscovery is a getter
+func (cluster *Cluster) GetDiscovery() *ClusterDiscovery {
+	if cluster == nil {
+		return nil
+	}
+	return cluster.Discovery
+}
+
+// GetFederation is a getter
+func (cluster *Cluster) GetFederation() *ClusterFederation {
+	if cluster == nil {
+		return nil
+	}
+	return cluster.Federation
+}
+
 // FillShardReplicaSpecified fills whether shard or replicas are explicitly specified
 func (cluster *Cluster) FillShardReplicaSpecified() {
 	if len(cluster.Layout.Shards) > 0 {
@@ -163,7 +183,7 @@ func (cluster *Cluster) IsShardSpecified() bool {
 
 // InheritZookeeperFrom inherits zookeeper config from CHI
 func (cluster *Cluster) InheritZookeeperFrom(chi *ClickHouseInstallation) {
-	if !cluster.Zookeeper.IsEmpty() {
+	if !cluster.Zookeeper.IsEmpty() || cluster.Zookeeper.HasKeeperRef() {
 		// Has zk config explicitly specified alread
 		return
 	}
@@ -219,6 +239,24 @@ func (cluster *Cluster) GetServiceTemplate() (*ServiceTemplate, bool) {
 	return cluster.Runtime.CHI.GetServiceTemplate(name)
 }
 
+// GetIngressTemplate returns ingress template, if exists
+func (cluster *Cluster) GetIngressTemplate() (*IngressTemplate, bool) {
+	if !cluster.Templates.HasClusterIngressTemplate() {
+		return nil, false
+	}
+	name := cluster.Templates.GetClusterIngressTemplate()
+	return cluster.Runtime.CHI.GetIngressTemplate(name)
+}
+
+// GetRouteTemplate returns route template, if exists
+func (cluster *Cluster) GetRouteTemplate() (*RouteTemplate, bool) {
+	if !cluster.Templates.HasClusterRouteTemplate() {
+		return nil, false
+	}
+	name := cluster.Templates.GetClusterRouteTemplate()
+	return cluster.Runtime.CHI.GetRouteTemplate(name)
+}
+
 // GetCHI gets parent CHI
 func (cluster *Cluster) GetCHI() *ClickHouseInstallation {
 	return cluster.Runtime.CHI
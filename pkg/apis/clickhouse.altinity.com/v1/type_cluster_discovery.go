@@ -0,0 +1,50 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// ClusterDiscovery defines cluster discovery mode of the cluster.
+// In discovery mode hosts register themselves in ZooKeeper/Keeper and the cluster is defined by discovery path,
+// instead of the static list of hosts in remote_servers. Requires allow_experimental_cluster_discovery support
+type ClusterDiscovery struct {
+	// Enabled specifies whether cluster is defined via cluster discovery
+	Enabled *types.StringBool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Path specifies ZooKeeper/Keeper path hosts are registered at
+	Path *types.String `json:"path,omitempty"    yaml:"path,omitempty"`
+}
+
+// NewClusterDiscovery creates new cluster discovery
+func NewClusterDiscovery() *ClusterDiscovery {
+	return new(ClusterDiscovery)
+}
+
+// IsEnabled checks whether cluster is defined via cluster discovery
+func (d *ClusterDiscovery) IsEnabled() bool {
+	if d == nil {
+		return false
+	}
+	return d.Enabled.Value()
+}
+
+// GetPath gets ZooKeeper/Keeper path hosts are registered at
+func (d *ClusterDiscovery) GetPath() *types.String {
+	if d == nil {
+		return nil
+	}
+	return d.Path
+}
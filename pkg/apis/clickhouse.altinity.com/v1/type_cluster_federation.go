@@ -0,0 +1,121 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// ClusterFederation defines federation of the cluster - logical cluster, which spans several CHIs,
+// each CHI running in its own Kubernetes cluster and owning its own range of replicas.
+// Each CHI of the federation declares the same cluster, its own replica offset and the remote parts,
+// so that all CHIs generate the same remote_servers and consistent macros and replicate through the same Keeper paths.
+type ClusterFederation struct {
+	// Installation specifies value of the <installation> macro, shared by all parts of the federation.
+	// CHI name is used by default
+	Installation *types.String `json:"installation,omitempty"  yaml:"installation,omitempty"`
+	// ReplicaOffset specifies index of the first replica owned by this CHI within the federated cluster
+	ReplicaOffset *types.Int32 `json:"replicaOffset,omitempty" yaml:"replicaOffset,omitempty"`
+	// Parts specifies remote parts of the federation
+	Parts []ClusterFederationPart `json:"parts,omitempty"         yaml:"parts,omitempty"`
+}
+
+// ClusterFederationPart defines remote part of the federation - range of replicas owned by another CHI
+type ClusterFederationPart struct {
+	// Name specifies name of the part
+	Name string `json:"name,omitempty"          yaml:"name,omitempty"`
+	// ReplicaOffset specifies index of the first replica owned by the part within the federated cluster
+	ReplicaOffset *types.Int32 `json:"replicaOffset,omitempty" yaml:"replicaOffset,omitempty"`
+	// ReplicasCount specifies number of replicas owned by the part
+	ReplicasCount *types.Int32 `json:"replicasCount,omitempty" yaml:"replicasCount,omitempty"`
+	// Hostname specifies pattern of the hostnames of the part's hosts. Macros are expanded in the scope of the host,
+	// ex.: chi-dr-{cluster}-{shard}-{replicaIndex}.dr.example.com
+	// Hostname is mandatory, since hosts of the remote part are not resolvable by the names of this CHI
+	Hostname   *types.String `json:"hostname,omitempty"      yaml:"hostname,omitempty"`
+	HostSecure `json:",inline" yaml:",inline"`
+	HostPorts  `json:",inline" yaml:",inline"`
+}
+
+// NewClusterFederation creates new cluster federation
+func NewClusterFederation() *ClusterFederation {
+	return new(ClusterFederation)
+}
+
+// GetInstallation gets value of the <installation> macro
+func (f *ClusterFederation) GetInstallation() *types.String {
+	if f == nil {
+		return nil
+	}
+	return f.Installation
+}
+
+// GetReplicaOffset gets index of the first replica owned by this CHI
+func (f *ClusterFederation) GetReplicaOffset() int {
+	if f == nil {
+		return 0
+	}
+	return f.ReplicaOffset.IntValue()
+}
+
+// GetParts gets remote parts of the federation
+func (f *ClusterFederation) GetParts() []ClusterFederationPart {
+	if f == nil {
+		return nil
+	}
+	return f.Parts
+}
+
+// GetReplicasCount gets number of replicas in the federated cluster, having specified number of local replicas
+func (f *ClusterFederation) GetReplicasCount(localReplicasCount int) int {
+	count := f.GetReplicaOffset() + localReplicasCount
+	for i := range f.GetParts() {
+		if end := f.Parts[i].GetReplicaEnd(); end > count {
+			count = end
+		}
+	}
+	return count
+}
+
+// GetPart gets remote part, which owns replica with specified index within the federated cluster
+func (f *ClusterFederation) GetPart(replicaIndex int) *ClusterFederationPart {
+	for i := range f.GetParts() {
+		part := &f.Parts[i]
+		if (part.GetReplicaOffset() <= replicaIndex) && (replicaIndex < part.GetReplicaEnd()) {
+			return part
+		}
+	}
+	return nil
+}
+
+// GetReplicaOffset gets index of the first replica owned by the part
+func (p *ClusterFederationPart) GetReplicaOffset() int {
+	if p == nil {
+		return 0
+	}
+	return p.ReplicaOffset.IntValue()
+}
+
+// GetReplicasCount gets number of replicas owned by the part
+func (p *ClusterFederationPart) GetReplicasCount() int {
+	if p == nil {
+		return 0
+	}
+	return p.ReplicasCount.IntValue()
+}
+
+// GetReplicaEnd gets index of the replica following the last replica owned by the part
+func (p *ClusterFederationPart) GetReplicaEnd() int {
+	return p.GetReplicaOffset() + p.GetReplicasCount()
+}
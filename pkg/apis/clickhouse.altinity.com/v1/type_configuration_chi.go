@@ -50,6 +50,7 @@ type Configuration struct {
 	Quotas    *Settings        `json:"quotas,omitempty"    yaml:"quotas,omitempty"`
 	Settings  *Settings        `json:"settings,omitempty"  yaml:"settings,omitempty"`
 	Files     *Settings        `json:"files,omitempty"     yaml:"files,omitempty"`
+	TLS       *TLS             `json:"tls,omitempty"       yaml:"tls,omitempty"`
 	// TODO refactor into map[string]ChiCluster
 	Clusters []*Cluster `json:"clusters,omitempty"  yaml:"clusters,omitempty"`
 }
@@ -75,6 +76,13 @@ func (c *Configuration) GetFiles() *Settings {
 	return c.Files
 }
 
+func (c *Configuration) GetTLS() *TLS {
+	if c == nil {
+		return nil
+	}
+	return c.TLS
+}
+
 // MergeFrom merges from specified source
 func (c *Configuration) MergeFrom(from *Configuration, _type MergeType) *Configuration {
 	if from == nil {
@@ -91,6 +99,7 @@ func (c *Configuration) MergeFrom(from *Configuration, _type MergeType) *Configu
 	c.Quotas = c.Quotas.MergeFrom(from.Quotas)
 	c.Settings = c.Settings.MergeFrom(from.Settings)
 	c.Files = c.Files.MergeFrom(from.Files)
+	c.TLS = c.TLS.MergeFrom(from.TLS, _type)
 
 	// TODO merge clusters
 	// Copy Clusters for now
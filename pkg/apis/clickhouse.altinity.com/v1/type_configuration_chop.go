@@ -39,6 +39,9 @@ const (
 	defaultStatefulSetUpdateTimeout      = 300
 	defaultStatefulSetUpdatePollInterval = 15
 
+	// Default max absolute delay of replicated tables of the host to be considered caught up with other replicas, in seconds
+	defaultReconcileHostWaitReplicasDelay = 10
+
 	// Default values for ClickHouse user configuration
 	// 1. user/profile
 	// 2. user/quota
@@ -75,6 +78,8 @@ const (
 	defaultTimeoutQuery = 5
 	// defaultTimeoutCollect specifies default timeout to collect metrics from the ClickHouse instance. In seconds
 	defaultTimeoutCollect = 8
+	// defaultScrapeInterval specifies default interval of background scraping of the ClickHouse instance. In seconds
+	defaultScrapeInterval = 30
 
 	// defaultReconcileCHIsThreadsNumber specifies default number of controller threads running concurrently.
 	// Used in case no other specified in config
@@ -277,6 +282,19 @@ type OperatorConfigClickHouse struct {
 		Timeouts struct {
 			Collect time.Duration `json:"collect" yaml:"collect"`
 		} `json:"timeouts" yaml:"timeouts"`
+		// Custom specifies user-defined metrics fetched by the metrics exporter with SQL queries
+		Custom []OperatorConfigCustomMetric `json:"custom" yaml:"custom"`
+		// QueryLog specifies query latency metrics built from system.query_log
+		QueryLog OperatorConfigQueryLogMetrics `json:"queryLog" yaml:"queryLog"`
+		// Scrape specifies how hosts are scraped
+		Scrape struct {
+			// Background specifies whether hosts are scraped in background, with results cached and served on collect
+			Background *types.StringBool `json:"background" yaml:"background"`
+			// Interval specifies interval of background scraping of each host. In seconds
+			Interval time.Duration `json:"interval" yaml:"interval"`
+		} `json:"scrape" yaml:"scrape"`
+		// Sharding specifies sharding of the watched CHIs among replicas of the metrics exporter Deployment
+		Sharding OperatorConfigMetricsSharding `json:"sharding" yaml:"sharding"`
 	} `json:"metrics" yaml:"metrics"`
 }
 
@@ -373,6 +391,17 @@ type OperatorConfigReconcileHostWait struct {
 	Exclude *types.StringBool `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 	Queries *types.StringBool `json:"queries,omitempty" yaml:"queries,omitempty"`
 	Include *types.StringBool `json:"include,omitempty" yaml:"include,omitempty"`
+	// Replicas specifies how far the host may lag behind other replicas to be considered caught up with them
+	Replicas OperatorConfigReconcileHostWaitReplicas `json:"replicas" yaml:"replicas"`
+}
+
+// OperatorConfigReconcileHostWaitReplicas defines reconcile host wait replicas config
+type OperatorConfigReconcileHostWaitReplicas struct {
+	// Delay specifies max absolute delay of replicated tables of the host, in seconds, as reported by `system.replicas`
+	Delay *types.Int32 `json:"delay,omitempty"     yaml:"delay,omitempty"`
+	// QueueSize specifies max replication queue size of replicated tables of the host, as reported by `system.replicas`.
+	// Not checked in case not specified
+	QueueSize *types.Int32 `json:"queueSize,omitempty" yaml:"queueSize,omitempty"`
 }
 
 // OperatorConfigAnnotation specifies annotation section
@@ -848,6 +877,30 @@ func (c *OperatorConfig) normalizeSectionClickHouseMetrics() {
 	}
 	// Adjust seconds to time.Duration
 	c.ClickHouse.Metrics.Timeouts.Collect = c.ClickHouse.Metrics.Timeouts.Collect * time.Second
+
+	// Custom metrics without name or SQL can not be fetched
+	var custom []OperatorConfigCustomMetric
+	for i := range c.ClickHouse.Metrics.Custom {
+		metric := &c.ClickHouse.Metrics.Custom[i]
+		if !metric.IsValid() {
+			log.V(1).Infof("Custom metric %q has no name or SQL specified, skip it", metric.Name)
+			continue
+		}
+		metric.normalize()
+		custom = append(custom, *metric)
+	}
+	c.ClickHouse.Metrics.Custom = custom
+
+	c.ClickHouse.Metrics.QueryLog.normalize()
+
+	c.ClickHouse.Metrics.Scrape.Background = c.ClickHouse.Metrics.Scrape.Background.Normalize(false)
+	if c.ClickHouse.Metrics.Scrape.Interval == 0 {
+		c.ClickHouse.Metrics.Scrape.Interval = defaultScrapeInterval
+	}
+	// Adjust seconds to time.Duration
+	c.ClickHouse.Metrics.Scrape.Interval = c.ClickHouse.Metrics.Scrape.Interval * time.Second
+
+	c.ClickHouse.Metrics.Sharding.normalize()
 }
 
 func (c *OperatorConfig) normalizeSectionLogger() {
@@ -859,6 +912,17 @@ func (c *OperatorConfig) normalizeSectionLogger() {
 	// Log_backtrace_at string `json:"log_backtrace_at" yaml:"log_backtrace_at"`
 }
 
+func (c *OperatorConfig) normalizeSectionReconcileHost() {
+	replicas := &c.Reconcile.Host.Wait.Replicas
+	if !replicas.Delay.HasValue() || (replicas.Delay.Value() < 0) {
+		replicas.Delay = types.NewInt32(defaultReconcileHostWaitReplicasDelay)
+	}
+	if replicas.QueueSize.Value() < 0 {
+		// Negative queue size means queue size is not checked
+		replicas.QueueSize = nil
+	}
+}
+
 func (c *OperatorConfig) normalizeSectionReconcileRuntime() {
 	if c.Reconcile.Runtime.ThreadsNumber == 0 {
 		c.Reconcile.Runtime.ThreadsNumber = defaultReconcileCHIsThreadsNumber
@@ -911,6 +975,7 @@ func (c *OperatorConfig) normalize() {
 	c.normalizeSectionTemplate()
 	c.normalizeSectionReconcileStatefulSet()
 	c.normalizeSectionReconcileRuntime()
+	c.normalizeSectionReconcileHost()
 	c.normalizeSectionLogger()
 	c.normalizeSectionLabel()
 	c.normalizeSectionStatefulSet()
@@ -0,0 +1,103 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"time"
+
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// Possible custom metric types
+const (
+	CustomMetricTypeGauge   = "gauge"
+	CustomMetricTypeCounter = "counter"
+)
+
+// CustomMetricDefaultValueColumn specifies column with metric value, in case value column is not specified
+const CustomMetricDefaultValueColumn = "value"
+
+// OperatorConfigCustomMetric specifies user-defined metric, which is fetched by the metrics exporter
+// with SQL query from each watched host
+type OperatorConfigCustomMetric struct {
+	// Name specifies name of the metric. Metric is exposed with the same prefix as all metrics of the exporter
+	Name string `json:"name"         yaml:"name"`
+	// Help specifies description of the metric
+	Help string `json:"help"         yaml:"help"`
+	// Type specifies type of the metric - gauge or counter. Gauge by default
+	Type string `json:"type"         yaml:"type"`
+	// SQL specifies query the metric is fetched with. Each row of the result is a separate time series
+	SQL string `json:"sql"          yaml:"sql"`
+	// ValueColumn specifies column with the value of the metric. `value` by default
+	ValueColumn string `json:"valueColumn"  yaml:"valueColumn"`
+	// LabelColumns specifies columns used as labels of the metric
+	LabelColumns []string `json:"labelColumns" yaml:"labelColumns"`
+	// Timeout specifies timeout of the query. In seconds. Collect timeout is used by default
+	Timeout time.Duration `json:"timeout"      yaml:"timeout"`
+	// Selector specifies CHIs the metric is fetched from. All watched CHIs by default
+	Selector OperatorConfigCustomMetricSelector `json:"selector"     yaml:"selector"`
+}
+
+// OperatorConfigCustomMetricSelector specifies CHIs custom metric is fetched from
+type OperatorConfigCustomMetricSelector struct {
+	// Namespaces specifies namespaces of the CHIs. All namespaces in case empty
+	Namespaces []string `json:"namespaces" yaml:"namespaces"`
+	// Names specifies names of the CHIs. All names in case empty
+	Names []string `json:"names"      yaml:"names"`
+	// Labels specifies labels of the CHIs. All CHIs in case empty
+	Labels TargetSelector `json:"labels"     yaml:"labels"`
+}
+
+// IsCounter checks whether metric is a counter
+func (m *OperatorConfigCustomMetric) IsCounter() bool {
+	if m == nil {
+		return false
+	}
+	return m.Type == CustomMetricTypeCounter
+}
+
+// IsValid checks whether metric has all mandatory fields specified
+func (m *OperatorConfigCustomMetric) IsValid() bool {
+	if m == nil {
+		return false
+	}
+	return (m.Name != "") && (m.SQL != "")
+}
+
+// normalize fills default values and adjusts timeout to time.Duration
+func (m *OperatorConfigCustomMetric) normalize() {
+	if m.Type != CustomMetricTypeCounter {
+		m.Type = CustomMetricTypeGauge
+	}
+	if m.ValueColumn == "" {
+		m.ValueColumn = CustomMetricDefaultValueColumn
+	}
+	// Adjust seconds to time.Duration
+	m.Timeout = m.Timeout * time.Second
+}
+
+// Matches checks whether selector matches CHI with specified namespace, name and labels
+func (s *OperatorConfigCustomMetricSelector) Matches(namespace, name string, labels map[string]string) bool {
+	if s == nil {
+		return true
+	}
+	if (len(s.Namespaces) > 0) && !util.InArray(namespace, s.Namespaces) {
+		return false
+	}
+	if (len(s.Names) > 0) && !util.InArray(name, s.Names) {
+		return false
+	}
+	return s.Labels.Matches(labels)
+}
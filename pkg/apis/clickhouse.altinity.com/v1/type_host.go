@@ -29,6 +29,8 @@ type Host struct {
 	HostSecure   `json:",inline" yaml:",inline"`
 	HostPorts    `json:",inline" yaml:",inline"`
 	HostSettings `json:",inline" yaml:",inline"`
+	HostStorage  `json:",inline" yaml:",inline"`
+	HostExternal `json:",inline" yaml:",inline"`
 	Templates    *TemplatesList `json:"templates,omitempty"           yaml:"templates,omitempty"`
 
 	Runtime HostRuntime `json:"-" yaml:"-"`
@@ -57,6 +59,47 @@ type HostSettings struct {
 	Files    *Settings `json:"files,omitempty"               yaml:"files,omitempty"`
 }
 
+// HostStorage defines how host's data storage behaves
+type HostStorage struct {
+	StorageMode *types.String `json:"storageMode,omitempty"         yaml:"storageMode,omitempty"`
+}
+
+// Possible values of host storage mode
+const (
+	// HostStorageModePersistent specifies storage that survives pod re-creation, such as network-attached PVs
+	HostStorageModePersistent = "Persistent"
+	// HostStorageModeEphemeral specifies storage that is empty each time a pod is (re)created,
+	// such as `emptyDir` or local NVMe PVs, which do not follow the pod
+	HostStorageModeEphemeral = "Ephemeral"
+)
+
+// HostExternal defines host, which is not managed by the operator, such as bare-metal ClickHouse server.
+// External host is listed in remote_servers, however no StatefulSet, Service or PVC is created for it.
+type HostExternal struct {
+	// External specifies host is not managed by the operator
+	External *types.StringBool `json:"external,omitempty"     yaml:"external,omitempty"`
+	// Hostname specifies hostname the external host is reachable by
+	Hostname *types.String `json:"hostname,omitempty"     yaml:"hostname,omitempty"`
+	// SchemaSource specifies whether schema of the external host is migrated to the hosts managed by the operator
+	SchemaSource *types.StringBool `json:"schemaSource,omitempty" yaml:"schemaSource,omitempty"`
+}
+
+// IsExternal checks whether host is not managed by the operator
+func (h *HostExternal) IsExternal() bool {
+	if h == nil {
+		return false
+	}
+	return h.External.Value()
+}
+
+// IsSchemaSource checks whether schema of the external host is migrated to the hosts managed by the operator
+func (h *HostExternal) IsSchemaSource() bool {
+	if !h.IsExternal() {
+		return false
+	}
+	return h.SchemaSource.Value()
+}
+
 type HostRuntime struct {
 	// Internal data
 	Address             HostAddress                `json:"-" yaml:"-"`
@@ -155,6 +198,10 @@ func (host *Host) MergeFrom(from *Host) {
 
 	host.Insecure = host.Insecure.MergeFrom(from.Insecure)
 	host.Secure = host.Secure.MergeFrom(from.Secure)
+	host.StorageMode = host.StorageMode.MergeFrom(from.StorageMode)
+	host.External = host.External.MergeFrom(from.External)
+	host.Hostname = host.Hostname.MergeFrom(from.Hostname)
+	host.SchemaSource = host.SchemaSource.MergeFrom(from.SchemaSource)
 
 	if !host.TCPPort.HasValue() {
 		host.TCPPort.MergeFrom(from.TCPPort)
@@ -244,6 +291,17 @@ func (host *Host) GetName() string {
 	return host.Name
 }
 
+// GetExternalHostname gets hostname of the external host. Host name is used in case no hostname specified
+func (host *Host) GetExternalHostname() string {
+	if host == nil {
+		return ""
+	}
+	if host.Hostname.HasValue() {
+		return host.Hostname.Value()
+	}
+	return host.Name
+}
+
 // GetCR gets CHI
 func (host *Host) GetCR() ICustomResource {
 	return host.GetRuntime().GetCR()
@@ -417,6 +475,15 @@ func (host *Host) IsInsecure() bool {
 	return true
 }
 
+// IsStorageEphemeral checks whether the host has ephemeral storage, which is empty on each new pod
+func (host *Host) IsStorageEphemeral() bool {
+	if host == nil {
+		return false
+	}
+
+	return host.StorageMode.Value() == HostStorageModeEphemeral
+}
+
 // IsFirst checks whether the host is the first host of the whole CHI
 func (host *Host) IsFirst() bool {
 	if host == nil {
@@ -0,0 +1,172 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// Possible keeper migration actions
+const (
+	// KeeperMigrationActionMigrate migrates hosts to the target keeper, zookeeper config of the CHI is kept as is
+	KeeperMigrationActionMigrate = "Migrate"
+	// KeeperMigrationActionFinalize replaces zookeeper config of the CHI with the reference to the target keeper
+	KeeperMigrationActionFinalize = "Finalize"
+	// KeeperMigrationActionRollback switches all hosts back to the zookeeper config of the CHI
+	KeeperMigrationActionRollback = "Rollback"
+)
+
+// Possible keeper migration phases
+const (
+	KeeperMigrationPhaseCreatingTarget  = "CreatingTarget"
+	KeeperMigrationPhaseFreezing        = "Freezing"
+	KeeperMigrationPhaseCopyingMetadata = "CopyingMetadata"
+	KeeperMigrationPhaseSwitchingHosts  = "SwitchingHosts"
+	KeeperMigrationPhaseSwitched        = "Switched"
+	KeeperMigrationPhaseFinalized       = "Finalized"
+	KeeperMigrationPhaseRollingBack     = "RollingBack"
+	KeeperMigrationPhaseRolledBack      = "RolledBack"
+)
+
+// ChiKeeperMigration defines migration of the CHI from zookeeper to ClickHouseKeeperInstallation.
+// Migration creates target CHK, makes metadata tree read-only, copies it, switches all hosts to the target at once and verifies them.
+// All hosts can be switched back until migration is finalized, metadata changed on the target is copied back then.
+type ChiKeeperMigration struct {
+	// Target specifies ClickHouseKeeperInstallation to migrate to. Created in case it does not exist
+	Target *ZookeeperKeeperRef `json:"target,omitempty"   yaml:"target,omitempty"`
+	// Replicas specifies replicas count of the target CHK, in case it is created by the operator. 3 by default
+	Replicas *types.Int32 `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+	// Action specifies what to do - Migrate, Finalize or Rollback. Migrate by default
+	Action *types.String `json:"action,omitempty"   yaml:"action,omitempty"`
+}
+
+// NewChiKeeperMigration creates new keeper migration
+func NewChiKeeperMigration() *ChiKeeperMigration {
+	return new(ChiKeeperMigration)
+}
+
+// HasTarget checks whether migration target is specified
+func (m *ChiKeeperMigration) HasTarget() bool {
+	if m == nil {
+		return false
+	}
+	return len(m.Target.GetName()) > 0
+}
+
+// GetTarget gets migration target
+func (m *ChiKeeperMigration) GetTarget() *ZookeeperKeeperRef {
+	if m == nil {
+		return nil
+	}
+	return m.Target
+}
+
+// GetReplicas gets replicas count of the target CHK
+func (m *ChiKeeperMigration) GetReplicas() int {
+	if m == nil || !m.Replicas.HasValue() {
+		return 3
+	}
+	return m.Replicas.IntValue()
+}
+
+// GetAction gets migration action
+func (m *ChiKeeperMigration) GetAction() string {
+	if m == nil || !m.Action.HasValue() {
+		return KeeperMigrationActionMigrate
+	}
+	return m.Action.Value()
+}
+
+// MergeFrom merges from specified keeper migration
+func (m *ChiKeeperMigration) MergeFrom(from *ChiKeeperMigration, _type MergeType) *ChiKeeperMigration {
+	if from == nil {
+		return m
+	}
+
+	if m == nil {
+		m = NewChiKeeperMigration()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		if !m.HasTarget() && from.HasTarget() {
+			target := *from.Target
+			m.Target = &target
+		}
+		m.Replicas = m.Replicas.MergeFrom(from.Replicas)
+		m.Action = m.Action.MergeFrom(from.Action)
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.HasTarget() {
+			// Override by non-empty values only
+			target := *from.Target
+			m.Target = &target
+		}
+		if from.Replicas.HasValue() {
+			// Override by non-empty values only
+			m.Replicas = from.Replicas
+		}
+		if from.Action.HasValue() {
+			// Override by non-empty values only
+			m.Action = from.Action
+		}
+	}
+
+	return m
+}
+
+// KeeperMigrationStatus defines progress of the migration from zookeeper to ClickHouseKeeperInstallation
+type KeeperMigrationStatus struct {
+	// Phase specifies current phase of the migration
+	Phase string `json:"phase,omitempty"         yaml:"phase,omitempty"`
+	// Target specifies namespace/name of the target CHK
+	Target string `json:"target,omitempty"        yaml:"target,omitempty"`
+	// Nodes specifies nodes of the target CHK switched hosts use
+	Nodes ZookeeperNodes `json:"nodes,omitempty"         yaml:"nodes,omitempty"`
+	// NodesCopied specifies how many znodes are copied to the target
+	NodesCopied int `json:"nodesCopied,omitempty"   yaml:"nodesCopied,omitempty"`
+	// FrozenACLs specifies map of read-only ACLs of the frozen metadata tree to the original ones
+	FrozenACLs map[string]string `json:"frozenACLs,omitempty"    yaml:"frozenACLs,omitempty"`
+	// HostsSwitched specifies hosts, by StatefulSet name, configured to use the target
+	HostsSwitched []string `json:"hostsSwitched,omitempty" yaml:"hostsSwitched,omitempty"`
+	// HostsVerified specifies switched hosts, by StatefulSet name, which are verified to read system.zookeeper from the target
+	HostsVerified []string `json:"hostsVerified,omitempty" yaml:"hostsVerified,omitempty"`
+	// Message specifies details of the current phase, e.g. error in case of failure
+	Message string `json:"message,omitempty"       yaml:"message,omitempty"`
+}
+
+// GetPhase gets phase
+func (s *KeeperMigrationStatus) GetPhase() string {
+	if s == nil {
+		return ""
+	}
+	return s.Phase
+}
+
+// IsHostSwitched checks whether host is configured to use the target
+func (s *KeeperMigrationStatus) IsHostSwitched(host string) bool {
+	if s == nil {
+		return false
+	}
+	return util.InArray(host, s.HostsSwitched)
+}
+
+// IsHostVerified checks whether host is verified to use the target
+func (s *KeeperMigrationStatus) IsHostVerified(host string) bool {
+	if s == nil {
+		return false
+	}
+	return util.InArray(host, s.HostsVerified)
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"time"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+const (
+	// Default group of the metrics exporter replicas
+	defaultMetricsShardingGroup = "clickhouse-metrics-exporter"
+	// Default lease duration of the metrics exporter replica, in seconds
+	defaultMetricsShardingLeaseDuration = 15
+	// Default renew period of the lease of the metrics exporter replica, in seconds
+	defaultMetricsShardingRenewPeriod = 5
+	// Default period of watched CHIs discovery by the metrics exporter replica, in seconds
+	defaultMetricsShardingDiscoveryPeriod = 30
+)
+
+// OperatorConfigMetricsSharding specifies sharding of the watched CHIs among replicas of the metrics exporter Deployment
+type OperatorConfigMetricsSharding struct {
+	// Enabled specifies whether watched CHIs are sharded among replicas of the metrics exporter Deployment.
+	// Operator does not inform metrics exporter running in the operator pod about CHIs in this case. Disabled by default
+	Enabled *types.StringBool `json:"enabled"         yaml:"enabled"`
+	// Group specifies name of the group of the replicas. Used as name prefix and label of the Leases of the replicas
+	Group string `json:"group"           yaml:"group"`
+	// LeaseDuration specifies how long the replica is considered alive after the last renew of its Lease. In seconds
+	LeaseDuration time.Duration `json:"leaseDuration"   yaml:"leaseDuration"`
+	// RenewPeriod specifies how often the replica renews its Lease and checks membership. In seconds
+	RenewPeriod time.Duration `json:"renewPeriod"     yaml:"renewPeriod"`
+	// DiscoveryPeriod specifies how often the replica discovers watched CHIs. In seconds
+	DiscoveryPeriod time.Duration `json:"discoveryPeriod" yaml:"discoveryPeriod"`
+}
+
+// IsEnabled checks whether sharding of the metrics exporter is enabled
+func (s *OperatorConfigMetricsSharding) IsEnabled() bool {
+	if s == nil {
+		return false
+	}
+	return s.Enabled.IsTrue()
+}
+
+// normalize fills default values and adjusts periods to time.Duration
+func (s *OperatorConfigMetricsSharding) normalize() {
+	s.Enabled = s.Enabled.Normalize(false)
+	if s.Group == "" {
+		s.Group = defaultMetricsShardingGroup
+	}
+	if s.LeaseDuration == 0 {
+		s.LeaseDuration = defaultMetricsShardingLeaseDuration
+	}
+	if s.RenewPeriod == 0 {
+		s.RenewPeriod = defaultMetricsShardingRenewPeriod
+	}
+	if s.DiscoveryPeriod == 0 {
+		s.DiscoveryPeriod = defaultMetricsShardingDiscoveryPeriod
+	}
+	// Adjust seconds to time.Duration
+	s.LeaseDuration = s.LeaseDuration * time.Second
+	s.RenewPeriod = s.RenewPeriod * time.Second
+	s.DiscoveryPeriod = s.DiscoveryPeriod * time.Second
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// ChiNetworkPolicy defines NetworkPolicy, which is generated by the operator for the CHI.
+// Policy allows traffic between hosts of the CHI, access of the operator and access of the clients
+// to the client ports of the hosts. Egress of the hosts is restricted only in case it is explicitly enabled.
+type ChiNetworkPolicy struct {
+	// Enabled specifies whether NetworkPolicy is generated
+	Enabled *types.StringBool `json:"enabled,omitempty"  yaml:"enabled,omitempty"`
+	// Operator specifies peer the operator (and metrics exporter) runs at.
+	// Pods labeled `app: clickhouse-operator` in the namespace of the operator by default
+	Operator *networking.NetworkPolicyPeer `json:"operator,omitempty" yaml:"operator,omitempty"`
+	// Clients specifies peers, which are allowed to access client ports of the hosts
+	Clients []networking.NetworkPolicyPeer `json:"clients,omitempty"  yaml:"clients,omitempty"`
+	// Egress specifies whether and how egress of the hosts is restricted
+	Egress *ChiNetworkPolicyEgress `json:"egress,omitempty"   yaml:"egress,omitempty"`
+}
+
+// ChiNetworkPolicyEgress defines egress of the hosts allowed by the NetworkPolicy.
+// Restricted egress allows traffic between hosts of the CHI, DNS, ports of the external hosts and ZooKeeper/Keeper nodes.
+type ChiNetworkPolicyEgress struct {
+	// Enabled specifies whether egress of the hosts is restricted. Egress is not restricted by default
+	Enabled *types.StringBool `json:"enabled,omitempty"   yaml:"enabled,omitempty"`
+	// Zookeeper specifies peers ZooKeeper/Keeper nodes run at.
+	// Derived from the zookeeper nodes of the clusters by default
+	Zookeeper []networking.NetworkPolicyPeer `json:"zookeeper,omitempty" yaml:"zookeeper,omitempty"`
+	// Rules specifies additional destinations the hosts are allowed to reach, such as object storage or dictionary sources
+	Rules []networking.NetworkPolicyEgressRule `json:"rules,omitempty"     yaml:"rules,omitempty"`
+}
+
+// NewChiNetworkPolicy creates new network policy
+func NewChiNetworkPolicy() *ChiNetworkPolicy {
+	return new(ChiNetworkPolicy)
+}
+
+// IsEnabled checks whether NetworkPolicy is generated
+func (p *ChiNetworkPolicy) IsEnabled() bool {
+	if p == nil {
+		return false
+	}
+	return p.Enabled.Value()
+}
+
+// GetOperator gets peer the operator runs at
+func (p *ChiNetworkPolicy) GetOperator() *networking.NetworkPolicyPeer {
+	if p == nil {
+		return nil
+	}
+	return p.Operator
+}
+
+// GetClients gets client peers
+func (p *ChiNetworkPolicy) GetClients() []networking.NetworkPolicyPeer {
+	if p == nil {
+		return nil
+	}
+	return p.Clients
+}
+
+// GetEgress gets egress
+func (p *ChiNetworkPolicy) GetEgress() *ChiNetworkPolicyEgress {
+	if p == nil {
+		return nil
+	}
+	return p.Egress
+}
+
+// MergeFrom merges from specified network policy
+func (p *ChiNetworkPolicy) MergeFrom(from *ChiNetworkPolicy, _type MergeType) *ChiNetworkPolicy {
+	if from == nil {
+		return p
+	}
+
+	if p == nil {
+		p = NewChiNetworkPolicy()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		p.Enabled = p.Enabled.MergeFrom(from.Enabled)
+		if p.Operator == nil {
+			p.Operator = from.Operator
+		}
+		if len(p.Clients) == 0 {
+			p.Clients = from.Clients
+		}
+		p.Egress = p.Egress.MergeFrom(from.Egress, _type)
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.Enabled.HasValue() {
+			// Override by non-empty values only
+			p.Enabled = from.Enabled
+		}
+		if from.Operator != nil {
+			// Override by non-empty values only
+			p.Operator = from.Operator
+		}
+		if len(from.Clients) > 0 {
+			// Override by non-empty values only
+			p.Clients = from.Clients
+		}
+		p.Egress = p.Egress.MergeFrom(from.Egress, _type)
+	}
+
+	return p
+}
+
+// NewChiNetworkPolicyEgress creates new network policy egress
+func NewChiNetworkPolicyEgress() *ChiNetworkPolicyEgress {
+	return new(ChiNetworkPolicyEgress)
+}
+
+// IsEnabled checks whether egress of the hosts is restricted
+func (e *ChiNetworkPolicyEgress) IsEnabled() bool {
+	if e == nil {
+		return false
+	}
+	return e.Enabled.Value()
+}
+
+// GetZookeeper gets peers ZooKeeper/Keeper nodes run at
+func (e *ChiNetworkPolicyEgress) GetZookeeper() []networking.NetworkPolicyPeer {
+	if e == nil {
+		return nil
+	}
+	return e.Zookeeper
+}
+
+// GetRules gets additional egress rules
+func (e *ChiNetworkPolicyEgress) GetRules() []networking.NetworkPolicyEgressRule {
+	if e == nil {
+		return nil
+	}
+	return e.Rules
+}
+
+// MergeFrom merges from specified network policy egress
+func (e *ChiNetworkPolicyEgress) MergeFrom(from *ChiNetworkPolicyEgress, _type MergeType) *ChiNetworkPolicyEgress {
+	if from == nil {
+		return e
+	}
+
+	if e == nil {
+		e = NewChiNetworkPolicyEgress()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		e.Enabled = e.Enabled.MergeFrom(from.Enabled)
+		if len(e.Zookeeper) == 0 {
+			e.Zookeeper = from.Zookeeper
+		}
+		if len(e.Rules) == 0 {
+			e.Rules = from.Rules
+		}
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.Enabled.HasValue() {
+			// Override by non-empty values only
+			e.Enabled = from.Enabled
+		}
+		if len(from.Zookeeper) > 0 {
+			// Override by non-empty values only
+			e.Zookeeper = from.Zookeeper
+		}
+		if len(from.Rules) > 0 {
+			// Override by non-empty values only
+			e.Rules = from.Rules
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"sort"
+	"time"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+const (
+	// Default delay of reading query_log, in seconds. query_log is flushed with 7.5 seconds interval by default
+	defaultQueryLogMetricsDelay = 15
+	// Default max period of query_log read by one scrape, in seconds
+	defaultQueryLogMetricsWindow = 300
+	// Default max number of distinct users per host
+	defaultQueryLogMetricsLimitUsers = 20
+	// Default max number of distinct exception codes per host
+	defaultQueryLogMetricsLimitExceptionCodes = 20
+)
+
+// defaultQueryLogMetricsBuckets specifies default upper bounds of the query duration histogram buckets, in seconds
+var defaultQueryLogMetricsBuckets = []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// OperatorConfigQueryLogMetrics specifies query latency metrics, built by the metrics exporter from system.query_log
+type OperatorConfigQueryLogMetrics struct {
+	// Enabled specifies whether query_log is read by the metrics exporter. Disabled by default
+	Enabled *types.StringBool `json:"enabled" yaml:"enabled"`
+	// Buckets specifies upper bounds of the query duration histogram buckets. In seconds
+	Buckets []float64 `json:"buckets" yaml:"buckets"`
+	// Delay specifies how long query_log entries are waited for to be flushed before being read. In seconds
+	Delay time.Duration `json:"delay"   yaml:"delay"`
+	// Window specifies max period of query_log read by one scrape. In seconds
+	Window time.Duration `json:"window"  yaml:"window"`
+	// Limits specifies max number of distinct label values per host. Values above the limit are reported as "other"
+	Limits OperatorConfigQueryLogMetricsLimits `json:"limits"  yaml:"limits"`
+}
+
+// OperatorConfigQueryLogMetricsLimits specifies cardinality limits of the query latency metrics
+type OperatorConfigQueryLogMetricsLimits struct {
+	Users          int `json:"users"          yaml:"users"`
+	ExceptionCodes int `json:"exceptionCodes" yaml:"exceptionCodes"`
+}
+
+// IsEnabled checks whether query latency metrics are enabled
+func (m *OperatorConfigQueryLogMetrics) IsEnabled() bool {
+	if m == nil {
+		return false
+	}
+	return m.Enabled.IsTrue()
+}
+
+// normalize fills default values and adjusts delay and window to time.Duration
+func (m *OperatorConfigQueryLogMetrics) normalize() {
+	m.Enabled = m.Enabled.Normalize(false)
+
+	if len(m.Buckets) == 0 {
+		m.Buckets = append([]float64{}, defaultQueryLogMetricsBuckets...)
+	}
+	sort.Float64s(m.Buckets)
+
+	if m.Delay == 0 {
+		m.Delay = defaultQueryLogMetricsDelay
+	}
+	if m.Window == 0 {
+		m.Window = defaultQueryLogMetricsWindow
+	}
+	// Adjust seconds to time.Duration
+	m.Delay = m.Delay * time.Second
+	m.Window = m.Window * time.Second
+
+	if m.Limits.Users == 0 {
+		m.Limits.Users = defaultQueryLogMetricsLimitUsers
+	}
+	if m.Limits.ExceptionCodes == 0 {
+		m.Limits.ExceptionCodes = defaultQueryLogMetricsLimitExceptionCodes
+	}
+}
@@ -27,6 +27,11 @@ type Reconciling struct {
 	ConfigMapPropagationTimeout int `json:"configMapPropagationTimeout,omitempty" yaml:"configMapPropagationTimeout,omitempty"`
 	// Cleanup specifies cleanup behavior
 	Cleanup *Cleanup `json:"cleanup,omitempty" yaml:"cleanup,omitempty"`
+	// Order specifies order hosts are reconciled in - shard by shard or zone by zone
+	Order string `json:"order,omitempty" yaml:"order,omitempty"`
+	// ZoneKey specifies node label hosts are grouped into zones by, in case of zone by zone order.
+	// Zone is looked up in the pod template, then in node selector, required node affinity and labels of the pod, then in labels of the node
+	ZoneKey string `json:"zoneKey,omitempty" yaml:"zoneKey,omitempty"`
 }
 
 // NewReconciling creates new reconciling
@@ -52,6 +57,12 @@ func (t *Reconciling) MergeFrom(from *Reconciling, _type MergeType) *Reconciling
 		if t.ConfigMapPropagationTimeout == 0 {
 			t.ConfigMapPropagationTimeout = from.ConfigMapPropagationTimeout
 		}
+		if t.Order == "" {
+			t.Order = from.Order
+		}
+		if t.ZoneKey == "" {
+			t.ZoneKey = from.ZoneKey
+		}
 	case MergeTypeOverrideByNonEmptyValues:
 		if from.Policy != "" {
 			// Override by non-empty values only
@@ -61,6 +72,14 @@ func (t *Reconciling) MergeFrom(from *Reconciling, _type MergeType) *Reconciling
 			// Override by non-empty values only
 			t.ConfigMapPropagationTimeout = from.ConfigMapPropagationTimeout
 		}
+		if from.Order != "" {
+			// Override by non-empty values only
+			t.Order = from.Order
+		}
+		if from.ZoneKey != "" {
+			// Override by non-empty values only
+			t.ZoneKey = from.ZoneKey
+		}
 	}
 
 	t.Cleanup = t.Cleanup.MergeFrom(from.Cleanup, _type)
@@ -140,6 +159,28 @@ func (t *Reconciling) IsReconcilingPolicyNoWait() bool {
 	return strings.ToLower(t.GetPolicy()) == ReconcilingPolicyNoWait
 }
 
+// Possible reconcile order values
+const (
+	ReconcilingOrderShard = "shard"
+	ReconcilingOrderZone  = "zone"
+)
+
+// IsReconcilingOrderZone checks whether hosts are reconciled zone by zone
+func (t *Reconciling) IsReconcilingOrderZone() bool {
+	if t == nil {
+		return false
+	}
+	return strings.ToLower(t.Order) == ReconcilingOrderZone
+}
+
+// GetZoneKey gets node label hosts are grouped into zones by
+func (t *Reconciling) GetZoneKey() string {
+	if t == nil {
+		return ""
+	}
+	return t.ZoneKey
+}
+
 // GetCleanup gets cleanup
 func (t *Reconciling) GetCleanup() *Cleanup {
 	if t == nil {
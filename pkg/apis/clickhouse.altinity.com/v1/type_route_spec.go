@@ -0,0 +1,54 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+)
+
+// RouteSpec defines free-form spec of a Gateway API route.
+// Gateway API types are not a part of k8s core API, so the spec is passed to the route object as is.
+type RouteSpec map[string]interface{}
+
+// DeepCopyInto is a deepcopy function, copying the receiver, writing into out.
+// Written manually, since generator is not able to copy interface{} values.
+func (in RouteSpec) DeepCopyInto(out *RouteSpec) {
+	if in == nil {
+		*out = nil
+		return
+	}
+	// Spec is a JSON-compatible tree, so JSON round-trip makes a deep copy
+	bytes, err := json.Marshal(in)
+	if err != nil {
+		*out = nil
+		return
+	}
+	copied := make(RouteSpec)
+	if err := json.Unmarshal(bytes, &copied); err != nil {
+		*out = nil
+		return
+	}
+	*out = copied
+}
+
+// DeepCopy is a deepcopy function, copying the receiver, creating a new RouteSpec.
+func (in RouteSpec) DeepCopy() RouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpec)
+	in.DeepCopyInto(out)
+	return *out
+}
@@ -0,0 +1,236 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// Autoscaler defaults
+const (
+	// AutoscalerMinReplicas specifies the lowest min replicas of the scaled cluster
+	AutoscalerMinReplicas = 1
+	// AutoscalerDefaultCooldown specifies default interval between two consequent scale operations, in seconds
+	AutoscalerDefaultCooldown = 300
+)
+
+// ChiScaling defines scaling of a cluster via `/scale` subresource and built-in autoscaler
+type ChiScaling struct {
+	// Cluster specifies name of the cluster to be scaled. The first cluster is scaled in case not specified
+	Cluster *types.String `json:"cluster,omitempty"    yaml:"cluster,omitempty"`
+	// Replicas specifies replicas count of the scaled cluster.
+	// The latest changed of scaling replicas and cluster's layout.replicasCount takes effect
+	Replicas   *types.Int32   `json:"replicas,omitempty"   yaml:"replicas,omitempty"`
+	Autoscaler *ChiAutoscaler `json:"autoscaler,omitempty" yaml:"autoscaler,omitempty"`
+}
+
+// NewChiScaling creates new scaling
+func NewChiScaling() *ChiScaling {
+	return new(ChiScaling)
+}
+
+// GetCluster gets name of the scaled cluster
+func (s *ChiScaling) GetCluster() string {
+	if s == nil {
+		return ""
+	}
+	return s.Cluster.Value()
+}
+
+// IsScaledCluster checks whether specified cluster is the scaled one
+func (s *ChiScaling) IsScaledCluster(cluster ICluster) bool {
+	if s == nil || cluster == nil {
+		return false
+	}
+	return s.GetCluster() == cluster.GetName()
+}
+
+// GetReplicas gets replicas count of the scaled cluster
+func (s *ChiScaling) GetReplicas() *types.Int32 {
+	if s == nil {
+		return nil
+	}
+	return s.Replicas
+}
+
+// GetAutoscaler gets autoscaler
+func (s *ChiScaling) GetAutoscaler() *ChiAutoscaler {
+	if s == nil {
+		return nil
+	}
+	return s.Autoscaler
+}
+
+// MergeFrom merges from specified scaling
+func (s *ChiScaling) MergeFrom(from *ChiScaling, _type MergeType) *ChiScaling {
+	if from == nil {
+		return s
+	}
+
+	if s == nil {
+		s = NewChiScaling()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		if !s.Cluster.HasValue() {
+			s.Cluster = s.Cluster.MergeFrom(from.Cluster)
+		}
+		if !s.Replicas.HasValue() {
+			s.Replicas = s.Replicas.MergeFrom(from.Replicas)
+		}
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.Cluster.HasValue() {
+			// Override by non-empty values only
+			s.Cluster = from.Cluster
+		}
+		if from.Replicas.HasValue() {
+			// Override by non-empty values only
+			s.Replicas = from.Replicas
+		}
+	}
+
+	s.Autoscaler = s.Autoscaler.MergeFrom(from.Autoscaler, _type)
+
+	return s
+}
+
+// ChiAutoscaler defines built-in autoscaler, which scales replicas of the cluster based on the load of the cluster
+type ChiAutoscaler struct {
+	Enabled *types.StringBool `json:"enabled,omitempty"       yaml:"enabled,omitempty"`
+	// MinReplicas specifies min replicas count of the scaled cluster.
+	// In case not specified autoscaler never scales the cluster below its current replicas count
+	MinReplicas *types.Int32 `json:"minReplicas,omitempty"   yaml:"minReplicas,omitempty"`
+	// MaxReplicas specifies max replicas count of the scaled cluster.
+	// In case not specified autoscaler never scales the cluster above its current replicas count
+	MaxReplicas *types.Int32 `json:"maxReplicas,omitempty"   yaml:"maxReplicas,omitempty"`
+	// Cooldown specifies minimal interval between two consequent scale operations, in seconds
+	Cooldown *types.Int32 `json:"cooldown,omitempty"      yaml:"cooldown,omitempty"`
+	// TargetQueries specifies desired average number of running queries per host, as reported by `system.processes`
+	TargetQueries *types.Int32 `json:"targetQueries,omitempty" yaml:"targetQueries,omitempty"`
+	// TargetCPU specifies desired average CPU utilization per host, in percent of CPU cores available to the host,
+	// as reported by `OSUserTimeNormalized` and `OSSystemTimeNormalized` of `system.asynchronous_metrics`
+	TargetCPU *types.Int32 `json:"targetCPU,omitempty"     yaml:"targetCPU,omitempty"`
+}
+
+// NewChiAutoscaler creates new autoscaler
+func NewChiAutoscaler() *ChiAutoscaler {
+	return new(ChiAutoscaler)
+}
+
+// IsEnabled checks whether autoscaler is enabled
+func (a *ChiAutoscaler) IsEnabled() bool {
+	if a == nil {
+		return false
+	}
+	return a.Enabled.Value()
+}
+
+// GetMinReplicas gets min replicas. Current replicas count is used in case min replicas is not specified
+func (a *ChiAutoscaler) GetMinReplicas(current int) int {
+	if (a == nil) || !a.MinReplicas.HasValue() {
+		return current
+	}
+	return a.MinReplicas.IntValue()
+}
+
+// GetMaxReplicas gets max replicas. Current replicas count is used in case max replicas is not specified
+func (a *ChiAutoscaler) GetMaxReplicas(current int) int {
+	if (a == nil) || !a.MaxReplicas.HasValue() {
+		return current
+	}
+	return a.MaxReplicas.IntValue()
+}
+
+// GetCooldown gets cooldown in seconds
+func (a *ChiAutoscaler) GetCooldown() int {
+	if a == nil {
+		return 0
+	}
+	return a.Cooldown.IntValue()
+}
+
+// GetTargetQueries gets target queries per host
+func (a *ChiAutoscaler) GetTargetQueries() int {
+	if a == nil {
+		return 0
+	}
+	return a.TargetQueries.IntValue()
+}
+
+// GetTargetCPU gets target CPU utilization per host, in percent
+func (a *ChiAutoscaler) GetTargetCPU() int {
+	if a == nil {
+		return 0
+	}
+	return a.TargetCPU.IntValue()
+}
+
+// MergeFrom merges from specified autoscaler
+func (a *ChiAutoscaler) MergeFrom(from *ChiAutoscaler, _type MergeType) *ChiAutoscaler {
+	if from == nil {
+		return a
+	}
+
+	if a == nil {
+		a = NewChiAutoscaler()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		a.Enabled = a.Enabled.MergeFrom(from.Enabled)
+		a.MinReplicas = a.MinReplicas.MergeFrom(from.MinReplicas)
+		a.MaxReplicas = a.MaxReplicas.MergeFrom(from.MaxReplicas)
+		a.Cooldown = a.Cooldown.MergeFrom(from.Cooldown)
+		a.TargetQueries = a.TargetQueries.MergeFrom(from.TargetQueries)
+		a.TargetCPU = a.TargetCPU.MergeFrom(from.TargetCPU)
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.Enabled.HasValue() {
+			a.Enabled = from.Enabled
+		}
+		if from.MinReplicas.HasValue() {
+			a.MinReplicas = from.MinReplicas
+		}
+		if from.MaxReplicas.HasValue() {
+			a.MaxReplicas = from.MaxReplicas
+		}
+		if from.Cooldown.HasValue() {
+			a.Cooldown = from.Cooldown
+		}
+		if from.TargetQueries.HasValue() {
+			a.TargetQueries = from.TargetQueries
+		}
+		if from.TargetCPU.HasValue() {
+			a.TargetCPU = from.TargetCPU
+		}
+	}
+
+	return a
+}
+
+// ScalingStatus defines status of the scaled cluster, as exposed via `/scale` subresource
+type ScalingStatus struct {
+	Replicas int32  `json:"replicas,omitempty"      yaml:"replicas,omitempty"`
+	Selector string `json:"selector,omitempty"      yaml:"selector,omitempty"`
+	// LastScaleTime specifies time of the last scale operation performed by the autoscaler
+	LastScaleTime string `json:"lastScaleTime,omitempty" yaml:"lastScaleTime,omitempty"`
+	// AutoscalerReplicas specifies replicas count decided by the autoscaler.
+	// Applied to the scaled cluster while autoscaler is enabled, the spec of the CR is not modified
+	AutoscalerReplicas int32 `json:"autoscalerReplicas,omitempty" yaml:"autoscalerReplicas,omitempty"`
+	// SpecReplicas specifies `.spec.scaling.replicas` as of the last reconcile
+	SpecReplicas int32 `json:"specReplicas,omitempty"       yaml:"specReplicas,omitempty"`
+	// LayoutReplicas specifies `layout.replicasCount` of the scaled cluster as of the last reconcile
+	LayoutReplicas int32 `json:"layoutReplicas,omitempty"     yaml:"layoutReplicas,omitempty"`
+}
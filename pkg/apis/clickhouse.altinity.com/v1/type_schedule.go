@@ -0,0 +1,131 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"time"
+
+	clickhouse_altinity_com "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// AnnotationScheduleKeepRunningUntil specifies annotation, which keeps scheduled CHI running till specified time
+// regardless of the schedule. Time is expected in RFC3339 format.
+const AnnotationScheduleKeepRunningUntil = clickhouse_altinity_com.AnnotationScheduleKeepRunningUntil
+
+// Possible schedule transitions
+const (
+	ScheduleTransitionStop  = "Stop"
+	ScheduleTransitionStart = "Start"
+)
+
+// ChiSchedule defines schedule, which stops and starts CHI automatically.
+// CHI is in the state of the latest transition, be it stop or start.
+type ChiSchedule struct {
+	// Stop specifies cron expression of moments to stop CHI
+	Stop *types.String `json:"stop,omitempty"     yaml:"stop,omitempty"`
+	// Start specifies cron expression of moments to start CHI
+	Start *types.String `json:"start,omitempty"    yaml:"start,omitempty"`
+	// Timezone specifies IANA time zone cron expressions are evaluated in. UTC by default
+	Timezone *types.String `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+}
+
+// NewChiSchedule creates new schedule
+func NewChiSchedule() *ChiSchedule {
+	return new(ChiSchedule)
+}
+
+// HasSchedule checks whether any transition is scheduled
+func (s *ChiSchedule) HasSchedule() bool {
+	if s == nil {
+		return false
+	}
+	return s.Stop.HasValue() || s.Start.HasValue()
+}
+
+// GetStop gets stop cron expression
+func (s *ChiSchedule) GetStop() string {
+	if s == nil {
+		return ""
+	}
+	return s.Stop.Value()
+}
+
+// GetStart gets start cron expression
+func (s *ChiSchedule) GetStart() string {
+	if s == nil {
+		return ""
+	}
+	return s.Start.Value()
+}
+
+// GetLocation gets location of the time zone of the schedule
+func (s *ChiSchedule) GetLocation() (*time.Location, error) {
+	if s == nil || !s.Timezone.HasValue() {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone.Value())
+}
+
+// MergeFrom merges from specified schedule
+func (s *ChiSchedule) MergeFrom(from *ChiSchedule, _type MergeType) *ChiSchedule {
+	if from == nil {
+		return s
+	}
+
+	if s == nil {
+		s = NewChiSchedule()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		s.Stop = s.Stop.MergeFrom(from.Stop)
+		s.Start = s.Start.MergeFrom(from.Start)
+		s.Timezone = s.Timezone.MergeFrom(from.Timezone)
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.Stop.HasValue() {
+			// Override by non-empty values only
+			s.Stop = from.Stop
+		}
+		if from.Start.HasValue() {
+			// Override by non-empty values only
+			s.Start = from.Start
+		}
+		if from.Timezone.HasValue() {
+			// Override by non-empty values only
+			s.Timezone = from.Timezone
+		}
+	}
+
+	return s
+}
+
+// ScheduleStatus defines status of the schedule
+type ScheduleStatus struct {
+	// NextTransition specifies what is going to happen next - stop or start
+	NextTransition string `json:"nextTransition,omitempty"     yaml:"nextTransition,omitempty"`
+	// NextTransitionTime specifies when next transition is going to happen, in RFC3339 format
+	NextTransitionTime string `json:"nextTransitionTime,omitempty" yaml:"nextTransitionTime,omitempty"`
+	// KeepRunningUntil specifies time till which CHI is kept running by the override annotation
+	KeepRunningUntil string `json:"keepRunningUntil,omitempty"   yaml:"keepRunningUntil,omitempty"`
+}
+
+// Equal checks whether schedule statuses are equal
+func (s *ScheduleStatus) Equal(to *ScheduleStatus) bool {
+	if s == nil || to == nil {
+		return s == to
+	}
+	return *s == *to
+}
@@ -87,6 +87,24 @@ func (shard *ChiShard) GetServiceTemplate() (*ServiceTemplate, bool) {
 	return shard.Runtime.CHI.GetServiceTemplate(name)
 }
 
+// GetIngressTemplate gets ingress template
+func (shard *ChiShard) GetIngressTemplate() (*IngressTemplate, bool) {
+	if !shard.Templates.HasShardIngressTemplate() {
+		return nil, false
+	}
+	name := shard.Templates.GetShardIngressTemplate()
+	return shard.Runtime.CHI.GetIngressTemplate(name)
+}
+
+// GetRouteTemplate gets route template
+func (shard *ChiShard) GetRouteTemplate() (*RouteTemplate, bool) {
+	if !shard.Templates.HasShardRouteTemplate() {
+		return nil, false
+	}
+	name := shard.Templates.GetShardRouteTemplate()
+	return shard.Runtime.CHI.GetRouteTemplate(name)
+}
+
 // HasReplicasCount checks whether shard has replicas count specified
 func (shard *ChiShard) HasReplicasCount() bool {
 	if shard == nil {
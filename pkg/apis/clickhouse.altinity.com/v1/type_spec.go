@@ -20,17 +20,21 @@ import (
 
 // ChiSpec defines spec section of ClickHouseInstallation resource
 type ChiSpec struct {
-	TaskID                 *types.String     `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
-	Stop                   *types.StringBool `json:"stop,omitempty"                   yaml:"stop,omitempty"`
-	Restart                *types.String     `json:"restart,omitempty"                yaml:"restart,omitempty"`
-	Troubleshoot           *types.StringBool `json:"troubleshoot,omitempty"           yaml:"troubleshoot,omitempty"`
-	NamespaceDomainPattern *types.String     `json:"namespaceDomainPattern,omitempty" yaml:"namespaceDomainPattern,omitempty"`
-	Templating             *ChiTemplating    `json:"templating,omitempty"             yaml:"templating,omitempty"`
-	Reconciling            *Reconciling      `json:"reconciling,omitempty"            yaml:"reconciling,omitempty"`
-	Defaults               *Defaults         `json:"defaults,omitempty"               yaml:"defaults,omitempty"`
-	Configuration          *Configuration    `json:"configuration,omitempty"          yaml:"configuration,omitempty"`
-	Templates              *Templates        `json:"templates,omitempty"              yaml:"templates,omitempty"`
-	UseTemplates           []*TemplateRef    `json:"useTemplates,omitempty"           yaml:"useTemplates,omitempty"`
+	TaskID                 *types.String       `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
+	Stop                   *types.StringBool   `json:"stop,omitempty"                   yaml:"stop,omitempty"`
+	Restart                *types.String       `json:"restart,omitempty"                yaml:"restart,omitempty"`
+	Troubleshoot           *types.StringBool   `json:"troubleshoot,omitempty"           yaml:"troubleshoot,omitempty"`
+	NamespaceDomainPattern *types.String       `json:"namespaceDomainPattern,omitempty" yaml:"namespaceDomainPattern,omitempty"`
+	Templating             *ChiTemplating      `json:"templating,omitempty"             yaml:"templating,omitempty"`
+	Reconciling            *Reconciling        `json:"reconciling,omitempty"            yaml:"reconciling,omitempty"`
+	Scaling                *ChiScaling         `json:"scaling,omitempty"                yaml:"scaling,omitempty"`
+	Schedule               *ChiSchedule        `json:"schedule,omitempty"               yaml:"schedule,omitempty"`
+	NetworkPolicy          *ChiNetworkPolicy   `json:"networkPolicy,omitempty"          yaml:"networkPolicy,omitempty"`
+	KeeperMigration        *ChiKeeperMigration `json:"keeperMigration,omitempty"        yaml:"keeperMigration,omitempty"`
+	Defaults               *Defaults           `json:"defaults,omitempty"               yaml:"defaults,omitempty"`
+	Configuration          *Configuration      `json:"configuration,omitempty"          yaml:"configuration,omitempty"`
+	Templates              *Templates          `json:"templates,omitempty"              yaml:"templates,omitempty"`
+	UseTemplates           []*TemplateRef      `json:"useTemplates,omitempty"           yaml:"useTemplates,omitempty"`
 }
 
 // HasTaskID checks whether task id is specified
@@ -63,6 +67,22 @@ func (spec *ChiSpec) GetTemplating() *ChiTemplating {
 	return spec.Templating
 }
 
+func (spec *ChiSpec) GetScaling() *ChiScaling {
+	return spec.Scaling
+}
+
+func (spec *ChiSpec) GetSchedule() *ChiSchedule {
+	return spec.Schedule
+}
+
+func (spec *ChiSpec) GetNetworkPolicy() *ChiNetworkPolicy {
+	return spec.NetworkPolicy
+}
+
+func (spec *ChiSpec) GetKeeperMigration() *ChiKeeperMigration {
+	return spec.KeeperMigration
+}
+
 func (spec *ChiSpec) GetDefaults() *Defaults {
 	return spec.Defaults
 }
@@ -121,6 +141,10 @@ func (spec *ChiSpec) MergeFrom(from *ChiSpec, _type MergeType) {
 
 	spec.Templating = spec.Templating.MergeFrom(from.Templating, _type)
 	spec.Reconciling = spec.Reconciling.MergeFrom(from.Reconciling, _type)
+	spec.Scaling = spec.Scaling.MergeFrom(from.Scaling, _type)
+	spec.Schedule = spec.Schedule.MergeFrom(from.Schedule, _type)
+	spec.NetworkPolicy = spec.NetworkPolicy.MergeFrom(from.NetworkPolicy, _type)
+	spec.KeeperMigration = spec.KeeperMigration.MergeFrom(from.KeeperMigration, _type)
 	spec.Defaults = spec.Defaults.MergeFrom(from.Defaults, _type)
 	spec.Configuration = spec.Configuration.MergeFrom(from.Configuration, _type)
 	spec.Templates = spec.Templates.MergeFrom(from.Templates, _type)
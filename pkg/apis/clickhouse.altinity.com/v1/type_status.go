@@ -44,41 +44,60 @@ const (
 // that application logic sticks to the synchronized getter/setters by auditing whether all explicit Go field-level
 // accesses are strictly within _this_ source file OR the generated deep copy source file.
 type Status struct {
-	CHOpVersion            string                  `json:"chop-version,omitempty"           yaml:"chop-version,omitempty"`
-	CHOpCommit             string                  `json:"chop-commit,omitempty"            yaml:"chop-commit,omitempty"`
-	CHOpDate               string                  `json:"chop-date,omitempty"              yaml:"chop-date,omitempty"`
-	CHOpIP                 string                  `json:"chop-ip,omitempty"                yaml:"chop-ip,omitempty"`
-	ClustersCount          int                     `json:"clusters,omitempty"               yaml:"clusters,omitempty"`
-	ShardsCount            int                     `json:"shards,omitempty"                 yaml:"shards,omitempty"`
-	ReplicasCount          int                     `json:"replicas,omitempty"               yaml:"replicas,omitempty"`
-	HostsCount             int                     `json:"hosts,omitempty"                  yaml:"hosts,omitempty"`
-	Status                 string                  `json:"status,omitempty"                 yaml:"status,omitempty"`
-	TaskID                 string                  `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
-	TaskIDsStarted         []string                `json:"taskIDsStarted,omitempty"         yaml:"taskIDsStarted,omitempty"`
-	TaskIDsCompleted       []string                `json:"taskIDsCompleted,omitempty"       yaml:"taskIDsCompleted,omitempty"`
-	Action                 string                  `json:"action,omitempty"                 yaml:"action,omitempty"`
-	Actions                []string                `json:"actions,omitempty"                yaml:"actions,omitempty"`
-	Error                  string                  `json:"error,omitempty"                  yaml:"error,omitempty"`
-	Errors                 []string                `json:"errors,omitempty"                 yaml:"errors,omitempty"`
-	HostsUpdatedCount      int                     `json:"hostsUpdated,omitempty"           yaml:"hostsUpdated,omitempty"`
-	HostsAddedCount        int                     `json:"hostsAdded,omitempty"             yaml:"hostsAdded,omitempty"`
-	HostsUnchangedCount    int                     `json:"hostsUnchanged,omitempty"         yaml:"hostsUnchanged,omitempty"`
-	HostsFailedCount       int                     `json:"hostsFailed,omitempty"            yaml:"hostsFailed,omitempty"`
-	HostsCompletedCount    int                     `json:"hostsCompleted,omitempty"         yaml:"hostsCompleted,omitempty"`
-	HostsDeletedCount      int                     `json:"hostsDeleted,omitempty"           yaml:"hostsDeleted,omitempty"`
-	HostsDeleteCount       int                     `json:"hostsDelete,omitempty"            yaml:"hostsDelete,omitempty"`
-	Pods                   []string                `json:"pods,omitempty"                   yaml:"pods,omitempty"`
-	PodIPs                 []string                `json:"pod-ips,omitempty"                yaml:"pod-ips,omitempty"`
-	FQDNs                  []string                `json:"fqdns,omitempty"                  yaml:"fqdns,omitempty"`
-	Endpoint               string                  `json:"endpoint,omitempty"               yaml:"endpoint,omitempty"`
-	NormalizedCR           *ClickHouseInstallation `json:"normalized,omitempty"             yaml:"normalized,omitempty"`
-	NormalizedCRCompleted  *ClickHouseInstallation `json:"normalizedCompleted,omitempty"    yaml:"normalizedCompleted,omitempty"`
-	HostsWithTablesCreated []string                `json:"hostsWithTablesCreated,omitempty" yaml:"hostsWithTablesCreated,omitempty"`
-	UsedTemplates          []*TemplateRef          `json:"usedTemplates,omitempty"          yaml:"usedTemplates,omitempty"`
+	CHOpVersion            string                   `json:"chop-version,omitempty"           yaml:"chop-version,omitempty"`
+	CHOpCommit             string                   `json:"chop-commit,omitempty"            yaml:"chop-commit,omitempty"`
+	CHOpDate               string                   `json:"chop-date,omitempty"              yaml:"chop-date,omitempty"`
+	CHOpIP                 string                   `json:"chop-ip,omitempty"                yaml:"chop-ip,omitempty"`
+	ClustersCount          int                      `json:"clusters,omitempty"               yaml:"clusters,omitempty"`
+	ShardsCount            int                      `json:"shards,omitempty"                 yaml:"shards,omitempty"`
+	ReplicasCount          int                      `json:"replicas,omitempty"               yaml:"replicas,omitempty"`
+	HostsCount             int                      `json:"hosts,omitempty"                  yaml:"hosts,omitempty"`
+	Status                 string                   `json:"status,omitempty"                 yaml:"status,omitempty"`
+	TaskID                 string                   `json:"taskID,omitempty"                 yaml:"taskID,omitempty"`
+	TaskIDsStarted         []string                 `json:"taskIDsStarted,omitempty"         yaml:"taskIDsStarted,omitempty"`
+	TaskIDsCompleted       []string                 `json:"taskIDsCompleted,omitempty"       yaml:"taskIDsCompleted,omitempty"`
+	Action                 string                   `json:"action,omitempty"                 yaml:"action,omitempty"`
+	Actions                []string                 `json:"actions,omitempty"                yaml:"actions,omitempty"`
+	Error                  string                   `json:"error,omitempty"                  yaml:"error,omitempty"`
+	Errors                 []string                 `json:"errors,omitempty"                 yaml:"errors,omitempty"`
+	HostsUpdatedCount      int                      `json:"hostsUpdated,omitempty"           yaml:"hostsUpdated,omitempty"`
+	HostsAddedCount        int                      `json:"hostsAdded,omitempty"             yaml:"hostsAdded,omitempty"`
+	HostsUnchangedCount    int                      `json:"hostsUnchanged,omitempty"         yaml:"hostsUnchanged,omitempty"`
+	HostsFailedCount       int                      `json:"hostsFailed,omitempty"            yaml:"hostsFailed,omitempty"`
+	HostsCompletedCount    int                      `json:"hostsCompleted,omitempty"         yaml:"hostsCompleted,omitempty"`
+	HostsDeletedCount      int                      `json:"hostsDeleted,omitempty"           yaml:"hostsDeleted,omitempty"`
+	HostsDeleteCount       int                      `json:"hostsDelete,omitempty"            yaml:"hostsDelete,omitempty"`
+	Pods                   []string                 `json:"pods,omitempty"                   yaml:"pods,omitempty"`
+	PodIPs                 []string                 `json:"pod-ips,omitempty"                yaml:"pod-ips,omitempty"`
+	FQDNs                  []string                 `json:"fqdns,omitempty"                  yaml:"fqdns,omitempty"`
+	Endpoint               string                   `json:"endpoint,omitempty"               yaml:"endpoint,omitempty"`
+	NormalizedCR           *ClickHouseInstallation  `json:"normalized,omitempty"             yaml:"normalized,omitempty"`
+	NormalizedCRCompleted  *ClickHouseInstallation  `json:"normalizedCompleted,omitempty"    yaml:"normalizedCompleted,omitempty"`
+	HostsWithTablesCreated []string                 `json:"hostsWithTablesCreated,omitempty" yaml:"hostsWithTablesCreated,omitempty"`
+	UsedTemplates          []*TemplateRef           `json:"usedTemplates,omitempty"          yaml:"usedTemplates,omitempty"`
+	Scaling                *ScalingStatus           `json:"scaling,omitempty"                yaml:"scaling,omitempty"`
+	Schedule               *ScheduleStatus          `json:"schedule,omitempty"               yaml:"schedule,omitempty"`
+	TLS                    *TLSStatus               `json:"tls,omitempty"                    yaml:"tls,omitempty"`
+	KeeperMigration        *KeeperMigrationStatus   `json:"keeperMigration,omitempty"        yaml:"keeperMigration,omitempty"`
+	ZookeeperIdentity      *ZookeeperIdentityStatus `json:"zookeeperIdentity,omitempty"      yaml:"zookeeperIdentity,omitempty"`
+	HostsKept              []*HostKeptStatus        `json:"hostsKept,omitempty"              yaml:"hostsKept,omitempty"`
 
 	mu sync.RWMutex `json:"-" yaml:"-"`
 }
 
+// HostKeptStatus defines host removed from the CR, which is kept, since it is the last replica holding data in its shard.
+// Host is kept till any other replica of the shard holds data.
+type HostKeptStatus struct {
+	// Host specifies name of the host
+	Host    string `json:"host,omitempty"    yaml:"host,omitempty"`
+	Cluster string `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+	Shard   string `json:"shard,omitempty"   yaml:"shard,omitempty"`
+	// Replica specifies name of the replica of the host in ZooKeeper, which is dropped when host is released
+	Replica string `json:"replica,omitempty" yaml:"replica,omitempty"`
+	// Selector specifies labels of the objects of the host, which are not purged while host is kept
+	Selector map[string]string `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
 // FillStatusParams is a struct used to fill status params
 type FillStatusParams struct {
 	CHOpIP              string
@@ -155,6 +174,108 @@ func (s *Status) PushHostTablesCreated(host string) {
 	})
 }
 
+// RemoveHostTablesCreated removes host from the list of hosts with created tables
+func (s *Status) RemoveHostTablesCreated(host string) {
+	doWithWriteLock(s, func(s *Status) {
+		s.HostsWithTablesCreated = util.RemoveFromArray(host, s.HostsWithTablesCreated)
+	})
+}
+
+// SetScaling sets replicas count and selector of the scaled cluster
+func (s *Status) SetScaling(replicas int32, selector string) {
+	doWithWriteLock(s, func(s *Status) {
+		if s.Scaling == nil {
+			s.Scaling = new(ScalingStatus)
+		}
+		s.Scaling.Replicas = replicas
+		s.Scaling.Selector = selector
+	})
+}
+
+// SetScalingLastScaleTime sets time of the last scale operation performed by the autoscaler
+func (s *Status) SetScalingLastScaleTime(lastScaleTime string) {
+	doWithWriteLock(s, func(s *Status) {
+		if s.Scaling == nil {
+			s.Scaling = new(ScalingStatus)
+		}
+		s.Scaling.LastScaleTime = lastScaleTime
+	})
+}
+
+// SetScalingAutoscalerReplicas sets replicas count decided by the autoscaler
+func (s *Status) SetScalingAutoscalerReplicas(replicas int32) {
+	doWithWriteLock(s, func(s *Status) {
+		if s.Scaling == nil {
+			s.Scaling = new(ScalingStatus)
+		}
+		s.Scaling.AutoscalerReplicas = replicas
+	})
+}
+
+// SetScalingSources sets scaling replicas and layout replicas count the replicas count of the scaled cluster is based on
+func (s *Status) SetScalingSources(specReplicas, layoutReplicas int32) {
+	doWithWriteLock(s, func(s *Status) {
+		if s.Scaling == nil {
+			s.Scaling = new(ScalingStatus)
+		}
+		s.Scaling.SpecReplicas = specReplicas
+		s.Scaling.LayoutReplicas = layoutReplicas
+	})
+}
+
+// SetSchedule sets schedule status
+func (s *Status) SetSchedule(schedule *ScheduleStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.Schedule = schedule
+	})
+}
+
+// SetTLS sets TLS status
+func (s *Status) SetTLS(tls *TLSStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.TLS = tls
+	})
+}
+
+// SetKeeperMigration sets keeper migration status
+func (s *Status) SetKeeperMigration(migration *KeeperMigrationStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.KeeperMigration = migration
+	})
+}
+
+// SetZookeeperIdentity sets zookeeper identity status
+func (s *Status) SetZookeeperIdentity(identity *ZookeeperIdentityStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.ZookeeperIdentity = identity
+	})
+}
+
+// PushHostKept pushes host to the list of kept hosts, the same host of the cluster is replaced
+func (s *Status) PushHostKept(host *HostKeptStatus) {
+	doWithWriteLock(s, func(s *Status) {
+		s.HostsKept = removeHostKeptNoSync(s.HostsKept, host.Cluster, host.Host)
+		s.HostsKept = append(s.HostsKept, host)
+	})
+}
+
+// RemoveHostKept removes host of the cluster from the list of kept hosts
+func (s *Status) RemoveHostKept(cluster, host string) {
+	doWithWriteLock(s, func(s *Status) {
+		s.HostsKept = removeHostKeptNoSync(s.HostsKept, cluster, host)
+	})
+}
+
+// removeHostKeptNoSync removes host of the cluster from the list of kept hosts
+func removeHostKeptNoSync(hosts []*HostKeptStatus, cluster, host string) (res []*HostKeptStatus) {
+	for _, h := range hosts {
+		if (h.Cluster != cluster) || (h.Host != host) {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
 // SyncHostTablesCreated syncs list of hosts with tables created with actual list of hosts
 func (s *Status) SyncHostTablesCreated() {
 	doWithWriteLock(s, func(s *Status) {
@@ -335,6 +456,12 @@ func (s *Status) CopyFrom(f *Status, opts types.CopyStatusOptions) {
 				s.Actions = from.Actions
 				s.Errors = from.Errors
 				s.HostsWithTablesCreated = from.HostsWithTablesCreated
+				s.Scaling = from.Scaling
+				s.Schedule = from.Schedule
+				s.TLS = from.TLS
+				s.KeeperMigration = from.KeeperMigration
+				s.ZookeeperIdentity = from.ZookeeperIdentity
+				s.HostsKept = from.HostsKept
 			}
 
 			if opts.Actions {
@@ -384,12 +511,42 @@ func (s *Status) CopyFrom(f *Status, opts types.CopyStatusOptions) {
 				s.FQDNs = from.FQDNs
 				s.Endpoint = from.Endpoint
 				s.NormalizedCR = from.NormalizedCR
+				s.Scaling = from.Scaling
+				s.Schedule = from.Schedule
+				s.TLS = from.TLS
+				s.KeeperMigration = from.KeeperMigration
+				s.ZookeeperIdentity = from.ZookeeperIdentity
+				s.HostsKept = from.HostsKept
 			}
 
 			if opts.Normalized {
 				s.NormalizedCR = from.NormalizedCR
 			}
 
+			if opts.Scaling {
+				s.Scaling = from.Scaling
+			}
+
+			if opts.Schedule {
+				s.Schedule = from.Schedule
+			}
+
+			if opts.TLS {
+				s.TLS = from.TLS
+			}
+
+			if opts.KeeperMigration {
+				s.KeeperMigration = from.KeeperMigration
+			}
+
+			if opts.ZookeeperIdentity {
+				s.ZookeeperIdentity = from.ZookeeperIdentity
+			}
+
+			if opts.HostsKept {
+				s.HostsKept = from.HostsKept
+			}
+
 			if opts.WholeStatus {
 				s.CHOpVersion = from.CHOpVersion
 				s.CHOpCommit = from.CHOpCommit
@@ -419,6 +576,12 @@ func (s *Status) CopyFrom(f *Status, opts types.CopyStatusOptions) {
 				s.Endpoint = from.Endpoint
 				s.NormalizedCR = from.NormalizedCR
 				s.NormalizedCRCompleted = from.NormalizedCRCompleted
+				s.Scaling = from.Scaling
+				s.Schedule = from.Schedule
+				s.TLS = from.TLS
+				s.KeeperMigration = from.KeeperMigration
+				s.ZookeeperIdentity = from.ZookeeperIdentity
+				s.HostsKept = from.HostsKept
 			}
 		})
 	})
@@ -648,6 +811,101 @@ func (s *Status) GetHostsWithTablesCreated() []string {
 	})
 }
 
+// GetScalingReplicas gets replicas count of the scaled cluster
+func (s *Status) GetScalingReplicas() int {
+	return getIntWithReadLock(s, func(s *Status) int {
+		if s.Scaling == nil {
+			return 0
+		}
+		return int(s.Scaling.Replicas)
+	})
+}
+
+// GetScalingAutoscalerReplicas gets replicas count decided by the autoscaler
+func (s *Status) GetScalingAutoscalerReplicas() int {
+	return getIntWithReadLock(s, func(s *Status) int {
+		if s.Scaling == nil {
+			return 0
+		}
+		return int(s.Scaling.AutoscalerReplicas)
+	})
+}
+
+// GetScalingSpecReplicas gets scaling replicas as of the last reconcile
+func (s *Status) GetScalingSpecReplicas() int {
+	return getIntWithReadLock(s, func(s *Status) int {
+		if s.Scaling == nil {
+			return 0
+		}
+		return int(s.Scaling.SpecReplicas)
+	})
+}
+
+// GetScalingLayoutReplicas gets layout replicas count of the scaled cluster as of the last reconcile
+func (s *Status) GetScalingLayoutReplicas() int {
+	return getIntWithReadLock(s, func(s *Status) int {
+		if s.Scaling == nil {
+			return 0
+		}
+		return int(s.Scaling.LayoutReplicas)
+	})
+}
+
+// GetScalingLastScaleTime gets time of the last scale operation performed by the autoscaler
+func (s *Status) GetScalingLastScaleTime() string {
+	return getStringWithReadLock(s, func(s *Status) string {
+		if s.Scaling == nil {
+			return ""
+		}
+		return s.Scaling.LastScaleTime
+	})
+}
+
+// GetSchedule gets schedule status
+func (s *Status) GetSchedule() *ScheduleStatus {
+	var schedule *ScheduleStatus
+	doWithReadLock(s, func(s *Status) {
+		schedule = s.Schedule
+	})
+	return schedule
+}
+
+// GetTLS gets TLS status
+func (s *Status) GetTLS() *TLSStatus {
+	var tls *TLSStatus
+	doWithReadLock(s, func(s *Status) {
+		tls = s.TLS
+	})
+	return tls
+}
+
+// GetKeeperMigration gets keeper migration status
+func (s *Status) GetKeeperMigration() *KeeperMigrationStatus {
+	var migration *KeeperMigrationStatus
+	doWithReadLock(s, func(s *Status) {
+		migration = s.KeeperMigration
+	})
+	return migration
+}
+
+// GetHostsKept gets hosts kept as the last replicas holding data
+func (s *Status) GetHostsKept() []*HostKeptStatus {
+	var hosts []*HostKeptStatus
+	doWithReadLock(s, func(s *Status) {
+		hosts = append(hosts, s.HostsKept...)
+	})
+	return hosts
+}
+
+// GetZookeeperIdentity gets zookeeper identity status
+func (s *Status) GetZookeeperIdentity() *ZookeeperIdentityStatus {
+	var identity *ZookeeperIdentityStatus
+	doWithReadLock(s, func(s *Status) {
+		identity = s.ZookeeperIdentity
+	})
+	return identity
+}
+
 // Begin helpers
 
 func doWithWriteLock(s *Status, f func(s *Status)) {
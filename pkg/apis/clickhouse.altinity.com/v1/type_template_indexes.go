@@ -229,3 +229,111 @@ func (i *ServiceTemplatesIndex) Walk(f func(template *ServiceTemplate)) {
 		f(entry)
 	}
 }
+
+// IngressTemplatesIndex describes index of ingress templates
+type IngressTemplatesIndex struct {
+	// templates maps 'name of the template' -> 'template itself'
+	templates map[string]*IngressTemplate `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
+}
+
+// NewIngressTemplatesIndex creates new IngressTemplatesIndex object
+func NewIngressTemplatesIndex() *IngressTemplatesIndex {
+	return &IngressTemplatesIndex{
+		templates: make(map[string]*IngressTemplate),
+	}
+}
+
+// Has checks whether index has entity `name`
+func (i *IngressTemplatesIndex) Has(name string) bool {
+	if i == nil {
+		return false
+	}
+	if i.templates == nil {
+		return false
+	}
+	_, ok := i.templates[name]
+	return ok
+}
+
+// Get returns entity `name` from the index
+func (i *IngressTemplatesIndex) Get(name string) *IngressTemplate {
+	if !i.Has(name) {
+		return nil
+	}
+	return i.templates[name]
+}
+
+// Set sets named template into index
+func (i *IngressTemplatesIndex) Set(name string, entry *IngressTemplate) {
+	if i == nil {
+		return
+	}
+	if i.templates == nil {
+		return
+	}
+	i.templates[name] = entry
+}
+
+// Walk calls specified function over each entry in the index
+func (i *IngressTemplatesIndex) Walk(f func(template *IngressTemplate)) {
+	if i == nil {
+		return
+	}
+	for _, entry := range i.templates {
+		f(entry)
+	}
+}
+
+// RouteTemplatesIndex describes index of route templates
+type RouteTemplatesIndex struct {
+	// templates maps 'name of the template' -> 'template itself'
+	templates map[string]*RouteTemplate `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
+}
+
+// NewRouteTemplatesIndex creates new RouteTemplatesIndex object
+func NewRouteTemplatesIndex() *RouteTemplatesIndex {
+	return &RouteTemplatesIndex{
+		templates: make(map[string]*RouteTemplate),
+	}
+}
+
+// Has checks whether index has entity `name`
+func (i *RouteTemplatesIndex) Has(name string) bool {
+	if i == nil {
+		return false
+	}
+	if i.templates == nil {
+		return false
+	}
+	_, ok := i.templates[name]
+	return ok
+}
+
+// Get returns entity `name` from the index
+func (i *RouteTemplatesIndex) Get(name string) *RouteTemplate {
+	if !i.Has(name) {
+		return nil
+	}
+	return i.templates[name]
+}
+
+// Set sets named template into index
+func (i *RouteTemplatesIndex) Set(name string, entry *RouteTemplate) {
+	if i == nil {
+		return
+	}
+	if i.templates == nil {
+		return
+	}
+	i.templates[name] = entry
+}
+
+// Walk calls specified function over each entry in the index
+func (i *RouteTemplatesIndex) Walk(f func(template *RouteTemplate)) {
+	if i == nil {
+		return
+	}
+	for _, entry := range i.templates {
+		f(entry)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/imdario/mergo"
 
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,12 +29,16 @@ type Templates struct {
 	PodTemplates         []PodTemplate         `json:"podTemplates,omitempty"         yaml:"podTemplates,omitempty"`
 	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty" yaml:"volumeClaimTemplates,omitempty"`
 	ServiceTemplates     []ServiceTemplate     `json:"serviceTemplates,omitempty"     yaml:"serviceTemplates,omitempty"`
+	IngressTemplates     []IngressTemplate     `json:"ingressTemplates,omitempty"     yaml:"ingressTemplates,omitempty"`
+	RouteTemplates       []RouteTemplate       `json:"routeTemplates,omitempty"       yaml:"routeTemplates,omitempty"`
 
 	// Index maps template name to template itself
 	HostTemplatesIndex        *HostTemplatesIndex        `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
 	PodTemplatesIndex         *PodTemplatesIndex         `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
 	VolumeClaimTemplatesIndex *VolumeClaimTemplatesIndex `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
 	ServiceTemplatesIndex     *ServiceTemplatesIndex     `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
+	IngressTemplatesIndex     *IngressTemplatesIndex     `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
+	RouteTemplatesIndex       *RouteTemplatesIndex       `json:",omitempty" yaml:",omitempty" testdiff:"ignore"`
 }
 
 // HostTemplate defines full Host Template
@@ -80,6 +85,38 @@ type ServiceTemplate struct {
 	Spec         core.ServiceSpec `json:"spec,omitempty"         yaml:"spec,omitempty"`
 }
 
+// IngressTemplate defines CHI ingress template
+type IngressTemplate struct {
+	Name         string                 `json:"name"                   yaml:"name"`
+	GenerateName string                 `json:"generateName,omitempty" yaml:"generateName,omitempty"`
+	ObjectMeta   meta.ObjectMeta        `json:"metadata,omitempty"     yaml:"metadata,omitempty"`
+	Spec         networking.IngressSpec `json:"spec,omitempty"         yaml:"spec,omitempty"`
+}
+
+// Possible kinds of Gateway API routes
+const (
+	RouteKindHTTPRoute = "HTTPRoute"
+	RouteKindTLSRoute  = "TLSRoute"
+)
+
+// RouteTemplate defines CHI Gateway API route template
+type RouteTemplate struct {
+	Name         string `json:"name"                   yaml:"name"`
+	GenerateName string `json:"generateName,omitempty" yaml:"generateName,omitempty"`
+	// Kind specifies kind of the route - HTTPRoute or TLSRoute
+	Kind       string          `json:"kind,omitempty"         yaml:"kind,omitempty"`
+	ObjectMeta meta.ObjectMeta `json:"metadata,omitempty"     yaml:"metadata,omitempty"`
+	Spec       RouteSpec       `json:"spec,omitempty"         yaml:"spec,omitempty"`
+}
+
+// GetKind gets kind of the route. HTTPRoute by default
+func (t *RouteTemplate) GetKind() string {
+	if t == nil || t.Kind == "" {
+		return RouteKindHTTPRoute
+	}
+	return t.Kind
+}
+
 // NewTemplates creates new Templates object
 func NewTemplates() *Templates {
 	return new(Templates)
@@ -113,6 +150,20 @@ func (templates *Templates) GetServiceTemplates() []ServiceTemplate {
 	return templates.ServiceTemplates
 }
 
+func (templates *Templates) GetIngressTemplates() []IngressTemplate {
+	if templates == nil {
+		return nil
+	}
+	return templates.IngressTemplates
+}
+
+func (templates *Templates) GetRouteTemplates() []RouteTemplate {
+	if templates == nil {
+		return nil
+	}
+	return templates.RouteTemplates
+}
+
 // Len returns accumulated len of all templates
 func (templates *Templates) Len() int {
 	if templates == nil {
@@ -123,7 +174,9 @@ func (templates *Templates) Len() int {
 		len(templates.HostTemplates) +
 		len(templates.PodTemplates) +
 		len(templates.VolumeClaimTemplates) +
-		len(templates.ServiceTemplates)
+		len(templates.ServiceTemplates) +
+		len(templates.IngressTemplates) +
+		len(templates.RouteTemplates)
 }
 
 // MergeFrom merges from specified object
@@ -155,6 +208,8 @@ func (templates *Templates) MergeFrom(_from any, _type MergeType) *Templates {
 	templates.mergePodTemplates(from)
 	templates.mergeVolumeClaimTemplates(from)
 	templates.mergeServiceTemplates(from)
+	templates.mergeIngressTemplates(from)
+	templates.mergeRouteTemplates(from)
 
 	return templates
 }
@@ -296,6 +351,72 @@ func (templates *Templates) mergeServiceTemplates(from *Templates) {
 	}
 }
 
+// mergeIngressTemplates merges ingress templates section
+func (templates *Templates) mergeIngressTemplates(from *Templates) {
+	if len(from.IngressTemplates) == 0 {
+		return
+	}
+
+	// We have templates to merge from
+	// Loop over all 'from' templates and either copy it in case no such template in receiver or merge it
+	for fromIndex := range from.IngressTemplates {
+		fromTemplate := &from.IngressTemplates[fromIndex]
+
+		// Try to find entry with the same name among local templates in receiver
+		sameNameFound := false
+		for toIndex := range templates.IngressTemplates {
+			toTemplate := &templates.IngressTemplates[toIndex]
+			if toTemplate.Name == fromTemplate.Name {
+				// Receiver already have such a template
+				sameNameFound = true
+				// Merge `to` template with `from` template
+				_ = mergo.Merge(toTemplate, *fromTemplate, mergo.WithSliceDeepCopy)
+				// Receiver `to` template is processed
+				break
+			}
+		}
+
+		if !sameNameFound {
+			// Receiver does not have template with such a name
+			// Append template from `from`
+			templates.IngressTemplates = append(templates.IngressTemplates, *fromTemplate.DeepCopy())
+		}
+	}
+}
+
+// mergeRouteTemplates merges route templates section
+func (templates *Templates) mergeRouteTemplates(from *Templates) {
+	if len(from.RouteTemplates) == 0 {
+		return
+	}
+
+	// We have templates to merge from
+	// Loop over all 'from' templates and either copy it in case no such template in receiver or merge it
+	for fromIndex := range from.RouteTemplates {
+		fromTemplate := &from.RouteTemplates[fromIndex]
+
+		// Try to find entry with the same name among local templates in receiver
+		sameNameFound := false
+		for toIndex := range templates.RouteTemplates {
+			toTemplate := &templates.RouteTemplates[toIndex]
+			if toTemplate.Name == fromTemplate.Name {
+				// Receiver already have such a template
+				sameNameFound = true
+				// Merge `to` template with `from` template
+				_ = mergo.Merge(toTemplate, *fromTemplate, mergo.WithSliceDeepCopy)
+				// Receiver `to` template is processed
+				break
+			}
+		}
+
+		if !sameNameFound {
+			// Receiver does not have template with such a name
+			// Append template from `from`
+			templates.RouteTemplates = append(templates.RouteTemplates, *fromTemplate.DeepCopy())
+		}
+	}
+}
+
 // GetHostTemplatesIndex returns index of host templates
 func (templates *Templates) GetHostTemplatesIndex() *HostTemplatesIndex {
 	if templates == nil {
@@ -375,3 +496,43 @@ func (templates *Templates) EnsureServiceTemplatesIndex() *ServiceTemplatesIndex
 	templates.ServiceTemplatesIndex = NewServiceTemplatesIndex()
 	return templates.ServiceTemplatesIndex
 }
+
+// GetIngressTemplatesIndex returns index of Ingress templates
+func (templates *Templates) GetIngressTemplatesIndex() *IngressTemplatesIndex {
+	if templates == nil {
+		return nil
+	}
+	return templates.IngressTemplatesIndex
+}
+
+// EnsureIngressTemplatesIndex ensures index exists
+func (templates *Templates) EnsureIngressTemplatesIndex() *IngressTemplatesIndex {
+	if templates == nil {
+		return nil
+	}
+	if templates.IngressTemplatesIndex != nil {
+		return templates.IngressTemplatesIndex
+	}
+	templates.IngressTemplatesIndex = NewIngressTemplatesIndex()
+	return templates.IngressTemplatesIndex
+}
+
+// GetRouteTemplatesIndex returns index of Route templates
+func (templates *Templates) GetRouteTemplatesIndex() *RouteTemplatesIndex {
+	if templates == nil {
+		return nil
+	}
+	return templates.RouteTemplatesIndex
+}
+
+// EnsureRouteTemplatesIndex ensures index exists
+func (templates *Templates) EnsureRouteTemplatesIndex() *RouteTemplatesIndex {
+	if templates == nil {
+		return nil
+	}
+	if templates.RouteTemplatesIndex != nil {
+		return templates.RouteTemplatesIndex
+	}
+	templates.RouteTemplatesIndex = NewRouteTemplatesIndex()
+	return templates.RouteTemplatesIndex
+}
@@ -24,6 +24,12 @@ type TemplatesList struct {
 	ClusterServiceTemplate  string `json:"clusterServiceTemplate,omitempty"  yaml:"clusterServiceTemplate,omitempty"`
 	ShardServiceTemplate    string `json:"shardServiceTemplate,omitempty"    yaml:"shardServiceTemplate,omitempty"`
 	ReplicaServiceTemplate  string `json:"replicaServiceTemplate,omitempty"  yaml:"replicaServiceTemplate,omitempty"`
+	IngressTemplate         string `json:"ingressTemplate,omitempty"         yaml:"ingressTemplate,omitempty"`
+	ClusterIngressTemplate  string `json:"clusterIngressTemplate,omitempty"  yaml:"clusterIngressTemplate,omitempty"`
+	ShardIngressTemplate    string `json:"shardIngressTemplate,omitempty"    yaml:"shardIngressTemplate,omitempty"`
+	RouteTemplate           string `json:"routeTemplate,omitempty"           yaml:"routeTemplate,omitempty"`
+	ClusterRouteTemplate    string `json:"clusterRouteTemplate,omitempty"    yaml:"clusterRouteTemplate,omitempty"`
+	ShardRouteTemplate      string `json:"shardRouteTemplate,omitempty"      yaml:"shardRouteTemplate,omitempty"`
 
 	// VolumeClaimTemplate is deprecated in favor of DataVolumeClaimTemplate and LogVolumeClaimTemplate
 	// !!! DEPRECATED !!!
@@ -163,6 +169,102 @@ func (tl *TemplatesList) GetReplicaServiceTemplate() string {
 	return tl.ReplicaServiceTemplate
 }
 
+// HasIngressTemplate checks whether ingress template is specified
+func (tl *TemplatesList) HasIngressTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.IngressTemplate) > 0
+}
+
+// GetIngressTemplate gets ingress template
+func (tl *TemplatesList) GetIngressTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.IngressTemplate
+}
+
+// HasClusterIngressTemplate checks whether cluster ingress template is specified
+func (tl *TemplatesList) HasClusterIngressTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.ClusterIngressTemplate) > 0
+}
+
+// GetClusterIngressTemplate gets cluster ingress template
+func (tl *TemplatesList) GetClusterIngressTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.ClusterIngressTemplate
+}
+
+// HasShardIngressTemplate checks whether shard ingress template is specified
+func (tl *TemplatesList) HasShardIngressTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.ShardIngressTemplate) > 0
+}
+
+// GetShardIngressTemplate gets shard ingress template
+func (tl *TemplatesList) GetShardIngressTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.ShardIngressTemplate
+}
+
+// HasRouteTemplate checks whether route template is specified
+func (tl *TemplatesList) HasRouteTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.RouteTemplate) > 0
+}
+
+// GetRouteTemplate gets route template
+func (tl *TemplatesList) GetRouteTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.RouteTemplate
+}
+
+// HasClusterRouteTemplate checks whether cluster route template is specified
+func (tl *TemplatesList) HasClusterRouteTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.ClusterRouteTemplate) > 0
+}
+
+// GetClusterRouteTemplate gets cluster route template
+func (tl *TemplatesList) GetClusterRouteTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.ClusterRouteTemplate
+}
+
+// HasShardRouteTemplate checks whether shard route template is specified
+func (tl *TemplatesList) HasShardRouteTemplate() bool {
+	if tl == nil {
+		return false
+	}
+	return len(tl.ShardRouteTemplate) > 0
+}
+
+// GetShardRouteTemplate gets shard route template
+func (tl *TemplatesList) GetShardRouteTemplate() string {
+	if tl == nil {
+		return ""
+	}
+	return tl.ShardRouteTemplate
+}
+
 // HandleDeprecatedFields helps to deal with deprecated fields
 func (tl *TemplatesList) HandleDeprecatedFields() {
 	if tl == nil {
@@ -222,6 +324,24 @@ func (tl *TemplatesList) mergeFromFillEmptyValues(from *TemplatesList) *Template
 	if tl.ReplicaServiceTemplate == "" {
 		tl.ReplicaServiceTemplate = from.ReplicaServiceTemplate
 	}
+	if tl.IngressTemplate == "" {
+		tl.IngressTemplate = from.IngressTemplate
+	}
+	if tl.ClusterIngressTemplate == "" {
+		tl.ClusterIngressTemplate = from.ClusterIngressTemplate
+	}
+	if tl.ShardIngressTemplate == "" {
+		tl.ShardIngressTemplate = from.ShardIngressTemplate
+	}
+	if tl.RouteTemplate == "" {
+		tl.RouteTemplate = from.RouteTemplate
+	}
+	if tl.ClusterRouteTemplate == "" {
+		tl.ClusterRouteTemplate = from.ClusterRouteTemplate
+	}
+	if tl.ShardRouteTemplate == "" {
+		tl.ShardRouteTemplate = from.ShardRouteTemplate
+	}
 	return tl
 }
 
@@ -254,5 +374,23 @@ func (tl *TemplatesList) mergeFromOverwriteByNonEmptyValues(from *TemplatesList)
 	if from.ReplicaServiceTemplate != "" {
 		tl.ReplicaServiceTemplate = from.ReplicaServiceTemplate
 	}
+	if from.IngressTemplate != "" {
+		tl.IngressTemplate = from.IngressTemplate
+	}
+	if from.ClusterIngressTemplate != "" {
+		tl.ClusterIngressTemplate = from.ClusterIngressTemplate
+	}
+	if from.ShardIngressTemplate != "" {
+		tl.ShardIngressTemplate = from.ShardIngressTemplate
+	}
+	if from.RouteTemplate != "" {
+		tl.RouteTemplate = from.RouteTemplate
+	}
+	if from.ClusterRouteTemplate != "" {
+		tl.ClusterRouteTemplate = from.ClusterRouteTemplate
+	}
+	if from.ShardRouteTemplate != "" {
+		tl.ShardRouteTemplate = from.ShardRouteTemplate
+	}
 	return tl
 }
@@ -0,0 +1,222 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"time"
+
+	clickhouse_altinity_com "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+)
+
+// AnnotationTLSCertificateFingerprint specifies Pod template annotation with fingerprint of the TLS certificate in use.
+// Changed certificate changes Pod template, thus Pods are rolled over to pick up new certificate.
+const AnnotationTLSCertificateFingerprint = clickhouse_altinity_com.APIGroupName + "/" + "tls-certificate-fingerprint"
+
+// TLS defaults
+const (
+	// TLSDefaultDuration specifies default validity period of the issued certificate
+	TLSDefaultDuration = 90 * 24 * time.Hour
+	// TLSDefaultRenewBefore specifies default period before certificate expiration when certificate is renewed
+	TLSDefaultRenewBefore = 15 * 24 * time.Hour
+	// TLSDefaultVerificationMode specifies default openSSL verification mode
+	TLSDefaultVerificationMode = "relaxed"
+)
+
+// TLS defines how TLS certificates for hosts are issued.
+// Certificates are issued either by cert-manager or by the operator itself from the provided CA.
+type TLS struct {
+	// CertManager specifies cert-manager issuer to issue certificates with
+	CertManager *TLSCertManager `json:"certManager,omitempty"      yaml:"certManager,omitempty"`
+	// CASecret specifies Secret with CA certificate and key to issue certificates with
+	CASecret *TLSCASecret `json:"caSecret,omitempty"         yaml:"caSecret,omitempty"`
+	// Duration specifies validity period of the issued certificate, as Go duration
+	Duration *types.String `json:"duration,omitempty"         yaml:"duration,omitempty"`
+	// RenewBefore specifies period before expiration when certificate is renewed and hosts are restarted, as Go duration
+	RenewBefore *types.String `json:"renewBefore,omitempty"      yaml:"renewBefore,omitempty"`
+	// VerificationMode specifies openSSL verification mode of server and client
+	VerificationMode *types.String `json:"verificationMode,omitempty" yaml:"verificationMode,omitempty"`
+}
+
+// TLSCertManager specifies cert-manager issuer
+type TLSCertManager struct {
+	IssuerRef *TLSIssuerRef `json:"issuerRef,omitempty" yaml:"issuerRef,omitempty"`
+}
+
+// TLSIssuerRef specifies reference to cert-manager Issuer or ClusterIssuer
+type TLSIssuerRef struct {
+	Name  string `json:"name,omitempty"  yaml:"name,omitempty"`
+	Kind  string `json:"kind,omitempty"  yaml:"kind,omitempty"`
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// TLSCASecret specifies Secret with CA certificate and key in `tls.crt` and `tls.key` fields
+type TLSCASecret struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// NewTLS creates new TLS
+func NewTLS() *TLS {
+	return new(TLS)
+}
+
+// IsEnabled checks whether TLS certificates are to be issued
+func (t *TLS) IsEnabled() bool {
+	return t.IsCertManager() || t.IsCASecret()
+}
+
+// IsCertManager checks whether certificates are issued by cert-manager
+func (t *TLS) IsCertManager() bool {
+	if t == nil {
+		return false
+	}
+	return t.CertManager != nil
+}
+
+// IsCASecret checks whether certificates are issued by the operator from the CA Secret.
+// cert-manager takes precedence in case both are specified.
+func (t *TLS) IsCASecret() bool {
+	if t == nil {
+		return false
+	}
+	return !t.IsCertManager() && (t.CASecret != nil) && (t.CASecret.Name != "")
+}
+
+// GetIssuerRef gets cert-manager issuer reference
+func (t *TLS) GetIssuerRef() *TLSIssuerRef {
+	if !t.IsCertManager() {
+		return nil
+	}
+	return t.CertManager.IssuerRef
+}
+
+// GetCASecretName gets name of the CA Secret
+func (t *TLS) GetCASecretName() string {
+	if !t.IsCASecret() {
+		return ""
+	}
+	return t.CASecret.Name
+}
+
+// GetDuration gets validity period of the issued certificate
+func (t *TLS) GetDuration() time.Duration {
+	if t == nil {
+		return TLSDefaultDuration
+	}
+	return parseDuration(t.Duration, TLSDefaultDuration)
+}
+
+// GetRenewBefore gets period before expiration when certificate is renewed
+func (t *TLS) GetRenewBefore() time.Duration {
+	if t == nil {
+		return TLSDefaultRenewBefore
+	}
+	return parseDuration(t.RenewBefore, TLSDefaultRenewBefore)
+}
+
+// GetVerificationMode gets openSSL verification mode
+func (t *TLS) GetVerificationMode() string {
+	if t == nil || !t.VerificationMode.HasValue() {
+		return TLSDefaultVerificationMode
+	}
+	return t.VerificationMode.Value()
+}
+
+// parseDuration parses duration, falls back to default in case of absent or malformed value
+func parseDuration(str *types.String, _default time.Duration) time.Duration {
+	if !str.HasValue() {
+		return _default
+	}
+	d, err := time.ParseDuration(str.Value())
+	if err != nil || d <= 0 {
+		return _default
+	}
+	return d
+}
+
+// MergeFrom merges from specified TLS
+func (t *TLS) MergeFrom(from *TLS, _type MergeType) *TLS {
+	if from == nil {
+		return t
+	}
+
+	if t == nil {
+		t = NewTLS()
+	}
+
+	switch _type {
+	case MergeTypeFillEmptyValues:
+		if t.CertManager == nil {
+			t.CertManager = from.CertManager
+		}
+		if t.CASecret == nil {
+			t.CASecret = from.CASecret
+		}
+		t.Duration = t.Duration.MergeFrom(from.Duration)
+		t.RenewBefore = t.RenewBefore.MergeFrom(from.RenewBefore)
+		t.VerificationMode = t.VerificationMode.MergeFrom(from.VerificationMode)
+	case MergeTypeOverrideByNonEmptyValues:
+		if from.CertManager != nil {
+			// Override by non-empty values only
+			t.CertManager = from.CertManager
+		}
+		if from.CASecret != nil {
+			// Override by non-empty values only
+			t.CASecret = from.CASecret
+		}
+		if from.Duration.HasValue() {
+			// Override by non-empty values only
+			t.Duration = from.Duration
+		}
+		if from.RenewBefore.HasValue() {
+			// Override by non-empty values only
+			t.RenewBefore = from.RenewBefore
+		}
+		if from.VerificationMode.HasValue() {
+			// Override by non-empty values only
+			t.VerificationMode = from.VerificationMode
+		}
+	}
+
+	return t
+}
+
+// TLSStatus defines status of the TLS certificate in use
+type TLSStatus struct {
+	// SecretName specifies Secret where certificate is kept
+	SecretName string `json:"secretName,omitempty"  yaml:"secretName,omitempty"`
+	// NotAfter specifies expiration time of the certificate, in RFC3339 format
+	NotAfter string `json:"notAfter,omitempty"    yaml:"notAfter,omitempty"`
+	// Fingerprint specifies SHA-256 fingerprint of the certificate
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	// CA specifies whether Secret provides CA certificate. Not every issuer provides one
+	CA bool `json:"ca,omitempty"          yaml:"ca,omitempty"`
+}
+
+// GetFingerprint gets fingerprint of the certificate
+func (s *TLSStatus) GetFingerprint() string {
+	if s == nil {
+		return ""
+	}
+	return s.Fingerprint
+}
+
+// HasCA checks whether Secret with certificate provides CA certificate as well
+func (s *TLSStatus) HasCA() bool {
+	if s == nil {
+		return false
+	}
+	return s.CA
+}
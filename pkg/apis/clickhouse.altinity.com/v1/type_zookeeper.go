@@ -17,8 +17,17 @@ package v1
 import (
 	"gopkg.in/d4l3k/messagediff.v1"
 	"strings"
+
+	core "k8s.io/api/core/v1"
+
+	clickhouse_altinity_com "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
 )
 
+// AnnotationZookeeperIdentityFingerprint specifies Pod template annotation with fingerprint of the versions of the Secrets
+// with zookeeper identities in use. Changed identity changes Pod template, thus Pods are rolled over to pick up new identity from the Secret.
+const AnnotationZookeeperIdentityFingerprint = clickhouse_altinity_com.APIGroupName + "/" + "zookeeper-identity-fingerprint"
+
 // ZookeeperConfig defines zookeeper section of .spec.configuration
 // Refers to
 // https://clickhouse.yandex/docs/en/single/index.html?#server-settings_zookeeper
@@ -28,6 +37,37 @@ type ZookeeperConfig struct {
 	OperationTimeoutMs int            `json:"operation_timeout_ms,omitempty" yaml:"operation_timeout_ms,omitempty"`
 	Root               string         `json:"root,omitempty"                 yaml:"root,omitempty"`
 	Identity           string         `json:"identity,omitempty"             yaml:"identity,omitempty"`
+	// IdentitySecret refers to the Secret key with identity in `user:password` format.
+	// Has priority over plaintext identity and is rotated along with the Secret.
+	IdentitySecret *core.SecretKeySelector `json:"identitySecret,omitempty"       yaml:"identitySecret,omitempty"`
+	// ACL specifies whether root path has to be protected by digest ACL of the identity
+	ACL *types.StringBool `json:"acl,omitempty"                  yaml:"acl,omitempty"`
+	// KeeperRef refers to ClickHouseKeeperInstallation to be used as zookeeper.
+	// Nodes are resolved from the referenced CHK during normalization.
+	KeeperRef *ZookeeperKeeperRef `json:"keeperRef,omitempty"            yaml:"keeperRef,omitempty"`
+}
+
+// ZookeeperKeeperRef defines reference to ClickHouseKeeperInstallation
+type ZookeeperKeeperRef struct {
+	Name string `json:"name,omitempty"      yaml:"name,omitempty"`
+	// Namespace of the CHK. Namespace of the CHI is used when not specified
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// GetName gets name of the referenced CHK
+func (r *ZookeeperKeeperRef) GetName() string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+// GetNamespace gets namespace of the referenced CHK
+func (r *ZookeeperKeeperRef) GetNamespace() string {
+	if r == nil {
+		return ""
+	}
+	return r.Namespace
 }
 
 type ZookeeperNodes []ZookeeperNode
@@ -66,6 +106,47 @@ func (zkc *ZookeeperConfig) IsEmpty() bool {
 	return len(zkc.Nodes) == 0
 }
 
+// HasKeeperRef checks whether config refers to ClickHouseKeeperInstallation
+func (zkc *ZookeeperConfig) HasKeeperRef() bool {
+	if zkc == nil {
+		return false
+	}
+	return len(zkc.KeeperRef.GetName()) > 0
+}
+
+// GetKeeperRef gets reference to ClickHouseKeeperInstallation
+func (zkc *ZookeeperConfig) GetKeeperRef() *ZookeeperKeeperRef {
+	if zkc == nil {
+		return nil
+	}
+	return zkc.KeeperRef
+}
+
+// HasIdentitySecret checks whether identity is provided via the Secret
+func (zkc *ZookeeperConfig) HasIdentitySecret() bool {
+	if zkc == nil || zkc.IdentitySecret == nil {
+		return false
+	}
+	return (len(zkc.IdentitySecret.Name) > 0) && (len(zkc.IdentitySecret.Key) > 0)
+}
+
+// GetIdentitySecret gets reference to the Secret key with identity
+func (zkc *ZookeeperConfig) GetIdentitySecret() *core.SecretKeySelector {
+	if zkc == nil {
+		return nil
+	}
+	return zkc.IdentitySecret
+}
+
+// IsACLEnabled checks whether root path has to be protected by digest ACL.
+// ACL is applied only in case identity is provided via the Secret.
+func (zkc *ZookeeperConfig) IsACLEnabled() bool {
+	if !zkc.HasIdentitySecret() {
+		return false
+	}
+	return zkc.ACL.Value()
+}
+
 // MergeFrom merges from provided object
 func (zkc *ZookeeperConfig) MergeFrom(from *ZookeeperConfig, _type MergeType) *ZookeeperConfig {
 	if from == nil {
@@ -114,10 +195,35 @@ func (zkc *ZookeeperConfig) MergeFrom(from *ZookeeperConfig, _type MergeType) *Z
 	if from.Identity != "" {
 		zkc.Identity = from.Identity
 	}
+	if from.HasKeeperRef() {
+		ref := *from.KeeperRef
+		zkc.KeeperRef = &ref
+	}
+	if from.HasIdentitySecret() {
+		zkc.IdentitySecret = from.IdentitySecret.DeepCopy()
+	}
+	if from.ACL.HasValue() {
+		zkc.ACL = from.ACL
+	}
 
 	return zkc
 }
 
+// ZookeeperIdentityStatus defines status of the zookeeper identities provided via Secrets
+type ZookeeperIdentityStatus struct {
+	// Fingerprint specifies SHA-256 fingerprint of the versions of the Secrets with identities in use.
+	// Identities themselves are never hashed into fingerprint, since it is published
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+}
+
+// GetFingerprint gets fingerprint of the identities
+func (s *ZookeeperIdentityStatus) GetFingerprint() string {
+	if s == nil {
+		return ""
+	}
+	return s.Fingerprint
+}
+
 // Equals checks whether config is equal to another one
 func (zkc *ZookeeperConfig) Equals(b *ZookeeperConfig) bool {
 	_, equals := messagediff.DeepDiff(zkc, b)
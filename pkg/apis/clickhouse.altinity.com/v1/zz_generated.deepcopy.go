@@ -26,9 +26,56 @@ import (
 	swversion "github.com/altinity/clickhouse-operator/pkg/apis/swversion"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiAutoscaler) DeepCopyInto(out *ChiAutoscaler) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.TargetQueries != nil {
+		in, out := &in.TargetQueries, &out.TargetQueries
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.TargetCPU != nil {
+		in, out := &in.TargetCPU, &out.TargetCPU
+		*out = new(types.Int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiAutoscaler.
+func (in *ChiAutoscaler) DeepCopy() *ChiAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChiClusterAddress) DeepCopyInto(out *ChiClusterAddress) {
 	*out = *in
@@ -110,6 +157,110 @@ func (in *ChiClusterRuntime) DeepCopy() *ChiClusterRuntime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiKeeperMigration) DeepCopyInto(out *ChiKeeperMigration) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(ZookeeperKeeperRef)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiKeeperMigration.
+func (in *ChiKeeperMigration) DeepCopy() *ChiKeeperMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiKeeperMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiNetworkPolicy) DeepCopyInto(out *ChiNetworkPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(networkingv1.NetworkPolicyPeer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(ChiNetworkPolicyEgress)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiNetworkPolicy.
+func (in *ChiNetworkPolicy) DeepCopy() *ChiNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiNetworkPolicyEgress) DeepCopyInto(out *ChiNetworkPolicyEgress) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.Zookeeper != nil {
+		in, out := &in.Zookeeper, &out.Zookeeper
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiNetworkPolicyEgress.
+func (in *ChiNetworkPolicyEgress) DeepCopy() *ChiNetworkPolicyEgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiNetworkPolicyEgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChiReplica) DeepCopyInto(out *ChiReplica) {
 	*out = *in
@@ -191,6 +342,68 @@ func (in *ChiReplicaRuntime) DeepCopy() *ChiReplicaRuntime {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiScaling) DeepCopyInto(out *ChiScaling) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Autoscaler != nil {
+		in, out := &in.Autoscaler, &out.Autoscaler
+		*out = new(ChiAutoscaler)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiScaling.
+func (in *ChiScaling) DeepCopy() *ChiScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChiSchedule) DeepCopyInto(out *ChiSchedule) {
+	*out = *in
+	if in.Stop != nil {
+		in, out := &in.Stop, &out.Stop
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChiSchedule.
+func (in *ChiSchedule) DeepCopy() *ChiSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ChiSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChiShard) DeepCopyInto(out *ChiShard) {
 	*out = *in
@@ -320,6 +533,26 @@ func (in *ChiSpec) DeepCopyInto(out *ChiSpec) {
 		*out = new(Reconciling)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(ChiScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ChiSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(ChiNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeeperMigration != nil {
+		in, out := &in.KeeperMigration, &out.KeeperMigration
+		*out = new(ChiKeeperMigration)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Defaults != nil {
 		in, out := &in.Defaults, &out.Defaults
 		*out = new(Defaults)
@@ -687,6 +920,16 @@ func (in *Cluster) DeepCopyInto(out *Cluster) {
 		*out = new(ChiClusterLayout)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Federation != nil {
+		in, out := &in.Federation, &out.Federation
+		*out = new(ClusterFederation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(ClusterDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Runtime.DeepCopyInto(&out.Runtime)
 	return
 }
@@ -701,6 +944,98 @@ func (in *Cluster) DeepCopy() *Cluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDiscovery) DeepCopyInto(out *ClusterDiscovery) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDiscovery.
+func (in *ClusterDiscovery) DeepCopy() *ClusterDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFederation) DeepCopyInto(out *ClusterFederation) {
+	*out = *in
+	if in.Installation != nil {
+		in, out := &in.Installation, &out.Installation
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.ReplicaOffset != nil {
+		in, out := &in.ReplicaOffset, &out.ReplicaOffset
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Parts != nil {
+		in, out := &in.Parts, &out.Parts
+		*out = make([]ClusterFederationPart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFederation.
+func (in *ClusterFederation) DeepCopy() *ClusterFederation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFederation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFederationPart) DeepCopyInto(out *ClusterFederationPart) {
+	*out = *in
+	if in.ReplicaOffset != nil {
+		in, out := &in.ReplicaOffset, &out.ReplicaOffset
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.ReplicasCount != nil {
+		in, out := &in.ReplicasCount, &out.ReplicasCount
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(types.String)
+		**out = **in
+	}
+	in.HostSecure.DeepCopyInto(&out.HostSecure)
+	in.HostPorts.DeepCopyInto(&out.HostPorts)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFederationPart.
+func (in *ClusterFederationPart) DeepCopy() *ClusterFederationPart {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFederationPart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSecret) DeepCopyInto(out *ClusterSecret) {
 	*out = *in
@@ -813,6 +1148,11 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(Settings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]*Cluster, len(*in))
@@ -947,6 +1287,8 @@ func (in *Host) DeepCopyInto(out *Host) {
 	in.HostSecure.DeepCopyInto(&out.HostSecure)
 	in.HostPorts.DeepCopyInto(&out.HostPorts)
 	in.HostSettings.DeepCopyInto(&out.HostSettings)
+	in.HostStorage.DeepCopyInto(&out.HostStorage)
+	in.HostExternal.DeepCopyInto(&out.HostExternal)
 	if in.Templates != nil {
 		in, out := &in.Templates, &out.Templates
 		*out = new(TemplatesList)
@@ -983,37 +1325,91 @@ func (in *HostAddress) DeepCopy() *HostAddress {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostPorts) DeepCopyInto(out *HostPorts) {
+func (in *HostExternal) DeepCopyInto(out *HostExternal) {
 	*out = *in
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(types.Int32)
-		**out = **in
-	}
-	if in.TCPPort != nil {
-		in, out := &in.TCPPort, &out.TCPPort
-		*out = new(types.Int32)
-		**out = **in
-	}
-	if in.TLSPort != nil {
-		in, out := &in.TLSPort, &out.TLSPort
-		*out = new(types.Int32)
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(types.StringBool)
 		**out = **in
 	}
-	if in.HTTPPort != nil {
-		in, out := &in.HTTPPort, &out.HTTPPort
-		*out = new(types.Int32)
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(types.String)
 		**out = **in
 	}
-	if in.HTTPSPort != nil {
-		in, out := &in.HTTPSPort, &out.HTTPSPort
-		*out = new(types.Int32)
+	if in.SchemaSource != nil {
+		in, out := &in.SchemaSource, &out.SchemaSource
+		*out = new(types.StringBool)
 		**out = **in
 	}
-	if in.InterserverHTTPPort != nil {
-		in, out := &in.InterserverHTTPPort, &out.InterserverHTTPPort
-		*out = new(types.Int32)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostExternal.
+func (in *HostExternal) DeepCopy() *HostExternal {
+	if in == nil {
+		return nil
+	}
+	out := new(HostExternal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostKeptStatus) DeepCopyInto(out *HostKeptStatus) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostKeptStatus.
+func (in *HostKeptStatus) DeepCopy() *HostKeptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostKeptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPorts) DeepCopyInto(out *HostPorts) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.TCPPort != nil {
+		in, out := &in.TCPPort, &out.TCPPort
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.TLSPort != nil {
+		in, out := &in.TLSPort, &out.TLSPort
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.HTTPPort != nil {
+		in, out := &in.HTTPPort, &out.HTTPPort
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.HTTPSPort != nil {
+		in, out := &in.HTTPSPort, &out.HTTPSPort
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.InterserverHTTPPort != nil {
+		in, out := &in.InterserverHTTPPort, &out.InterserverHTTPPort
+		*out = new(types.Int32)
+		**out = **in
 	}
 	if in.ZKPort != nil {
 		in, out := &in.ZKPort, &out.ZKPort
@@ -1171,6 +1567,27 @@ func (in *HostSettings) DeepCopy() *HostSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostStorage) DeepCopyInto(out *HostStorage) {
+	*out = *in
+	if in.StorageMode != nil {
+		in, out := &in.StorageMode, &out.StorageMode
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStorage.
+func (in *HostStorage) DeepCopy() *HostStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(HostStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostTemplate) DeepCopyInto(out *HostTemplate) {
 	*out = *in
@@ -1257,6 +1674,95 @@ func (in *HostsField) DeepCopy() *HostsField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTemplate) DeepCopyInto(out *IngressTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTemplate.
+func (in *IngressTemplate) DeepCopy() *IngressTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressTemplatesIndex) DeepCopyInto(out *IngressTemplatesIndex) {
+	*out = *in
+	if in.templates != nil {
+		in, out := &in.templates, &out.templates
+		*out = make(map[string]*IngressTemplate, len(*in))
+		for key, val := range *in {
+			var outVal *IngressTemplate
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(IngressTemplate)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressTemplatesIndex.
+func (in *IngressTemplatesIndex) DeepCopy() *IngressTemplatesIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressTemplatesIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeeperMigrationStatus) DeepCopyInto(out *KeeperMigrationStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(ZookeeperNodes, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FrozenACLs != nil {
+		in, out := &in.FrozenACLs, &out.FrozenACLs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HostsSwitched != nil {
+		in, out := &in.HostsSwitched, &out.HostsSwitched
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostsVerified != nil {
+		in, out := &in.HostsVerified, &out.HostsVerified
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeeperMigrationStatus.
+func (in *KeeperMigrationStatus) DeepCopy() *KeeperMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeeperMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectAddress) DeepCopyInto(out *ObjectAddress) {
 	*out = *in
@@ -1436,6 +1942,20 @@ func (in *OperatorConfigClickHouse) DeepCopyInto(out *OperatorConfigClickHouse)
 	in.ConfigRestartPolicy.DeepCopyInto(&out.ConfigRestartPolicy)
 	out.Access = in.Access
 	out.Metrics = in.Metrics
+	if in.Metrics.Custom != nil {
+		in, out := &in.Metrics.Custom, &out.Metrics.Custom
+		*out = make([]OperatorConfigCustomMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Metrics.QueryLog.DeepCopyInto(&out.Metrics.QueryLog)
+	if in.Metrics.Scrape.Background != nil {
+		in, out := &in.Metrics.Scrape.Background, &out.Metrics.Scrape.Background
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	in.Metrics.Sharding.DeepCopyInto(&out.Metrics.Sharding)
 	return
 }
 
@@ -1468,6 +1988,61 @@ func (in *OperatorConfigConfig) DeepCopy() *OperatorConfigConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigCustomMetric) DeepCopyInto(out *OperatorConfigCustomMetric) {
+	*out = *in
+	if in.LabelColumns != nil {
+		in, out := &in.LabelColumns, &out.LabelColumns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Selector.DeepCopyInto(&out.Selector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigCustomMetric.
+func (in *OperatorConfigCustomMetric) DeepCopy() *OperatorConfigCustomMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigCustomMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigCustomMetricSelector) DeepCopyInto(out *OperatorConfigCustomMetricSelector) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(TargetSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigCustomMetricSelector.
+func (in *OperatorConfigCustomMetricSelector) DeepCopy() *OperatorConfigCustomMetricSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigCustomMetricSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorConfigDefault) DeepCopyInto(out *OperatorConfigDefault) {
 	*out = *in
@@ -1588,6 +2163,70 @@ func (in *OperatorConfigLabel) DeepCopy() *OperatorConfigLabel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigMetricsSharding) DeepCopyInto(out *OperatorConfigMetricsSharding) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigMetricsSharding.
+func (in *OperatorConfigMetricsSharding) DeepCopy() *OperatorConfigMetricsSharding {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigMetricsSharding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigQueryLogMetrics) DeepCopyInto(out *OperatorConfigQueryLogMetrics) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(types.StringBool)
+		**out = **in
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]float64, len(*in))
+		copy(*out, *in)
+	}
+	out.Limits = in.Limits
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigQueryLogMetrics.
+func (in *OperatorConfigQueryLogMetrics) DeepCopy() *OperatorConfigQueryLogMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigQueryLogMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigQueryLogMetricsLimits) DeepCopyInto(out *OperatorConfigQueryLogMetricsLimits) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigQueryLogMetricsLimits.
+func (in *OperatorConfigQueryLogMetricsLimits) DeepCopy() *OperatorConfigQueryLogMetricsLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigQueryLogMetricsLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorConfigReconcile) DeepCopyInto(out *OperatorConfigReconcile) {
 	*out = *in
@@ -1642,6 +2281,7 @@ func (in *OperatorConfigReconcileHostWait) DeepCopyInto(out *OperatorConfigRecon
 		*out = new(types.StringBool)
 		**out = **in
 	}
+	in.Replicas.DeepCopyInto(&out.Replicas)
 	return
 }
 
@@ -1655,6 +2295,32 @@ func (in *OperatorConfigReconcileHostWait) DeepCopy() *OperatorConfigReconcileHo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigReconcileHostWaitReplicas) DeepCopyInto(out *OperatorConfigReconcileHostWaitReplicas) {
+	*out = *in
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(types.Int32)
+		**out = **in
+	}
+	if in.QueueSize != nil {
+		in, out := &in.QueueSize, &out.QueueSize
+		*out = new(types.Int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigReconcileHostWaitReplicas.
+func (in *OperatorConfigReconcileHostWaitReplicas) DeepCopy() *OperatorConfigReconcileHostWaitReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigReconcileHostWaitReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorConfigRestartPolicy) DeepCopyInto(out *OperatorConfigRestartPolicy) {
 	*out = *in
@@ -1934,6 +2600,87 @@ func (in *Reconciling) DeepCopy() *Reconciling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTemplate) DeepCopyInto(out *RouteTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTemplate.
+func (in *RouteTemplate) DeepCopy() *RouteTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTemplatesIndex) DeepCopyInto(out *RouteTemplatesIndex) {
+	*out = *in
+	if in.templates != nil {
+		in, out := &in.templates, &out.templates
+		*out = make(map[string]*RouteTemplate, len(*in))
+		for key, val := range *in {
+			var outVal *RouteTemplate
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(RouteTemplate)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTemplatesIndex.
+func (in *RouteTemplatesIndex) DeepCopy() *RouteTemplatesIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTemplatesIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingStatus) DeepCopyInto(out *ScalingStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingStatus.
+func (in *ScalingStatus) DeepCopy() *ScalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleStatus) DeepCopyInto(out *ScheduleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleStatus.
+func (in *ScheduleStatus) DeepCopy() *ScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchemaPolicy) DeepCopyInto(out *SchemaPolicy) {
 	*out = *in
@@ -2200,6 +2947,42 @@ func (in *Status) DeepCopyInto(out *Status) {
 			}
 		}
 	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(ScalingStatus)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleStatus)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSStatus)
+		**out = **in
+	}
+	if in.KeeperMigration != nil {
+		in, out := &in.KeeperMigration, &out.KeeperMigration
+		*out = new(KeeperMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZookeeperIdentity != nil {
+		in, out := &in.ZookeeperIdentity, &out.ZookeeperIdentity
+		*out = new(ZookeeperIdentityStatus)
+		**out = **in
+	}
+	if in.HostsKept != nil {
+		in, out := &in.HostsKept, &out.HostsKept
+		*out = make([]*HostKeptStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(HostKeptStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	out.mu = in.mu
 	return
 }
@@ -2230,6 +3013,116 @@ func (in *StorageManagement) DeepCopy() *StorageManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(TLSCertManager)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(TLSCASecret)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(types.String)
+		**out = **in
+	}
+	if in.VerificationMode != nil {
+		in, out := &in.VerificationMode, &out.VerificationMode
+		*out = new(types.String)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCASecret) DeepCopyInto(out *TLSCASecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCASecret.
+func (in *TLSCASecret) DeepCopy() *TLSCASecret {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCASecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCertManager) DeepCopyInto(out *TLSCertManager) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(TLSIssuerRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCertManager.
+func (in *TLSCertManager) DeepCopy() *TLSCertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSIssuerRef) DeepCopyInto(out *TLSIssuerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSIssuerRef.
+func (in *TLSIssuerRef) DeepCopy() *TLSIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSStatus) DeepCopyInto(out *TLSStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSStatus.
+func (in *TLSStatus) DeepCopy() *TLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in TargetSelector) DeepCopyInto(out *TargetSelector) {
 	{
@@ -2299,6 +3192,20 @@ func (in *Templates) DeepCopyInto(out *Templates) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IngressTemplates != nil {
+		in, out := &in.IngressTemplates, &out.IngressTemplates
+		*out = make([]IngressTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RouteTemplates != nil {
+		in, out := &in.RouteTemplates, &out.RouteTemplates
+		*out = make([]RouteTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.HostTemplatesIndex != nil {
 		in, out := &in.HostTemplatesIndex, &out.HostTemplatesIndex
 		*out = new(HostTemplatesIndex)
@@ -2319,6 +3226,16 @@ func (in *Templates) DeepCopyInto(out *Templates) {
 		*out = new(ServiceTemplatesIndex)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IngressTemplatesIndex != nil {
+		in, out := &in.IngressTemplatesIndex, &out.IngressTemplatesIndex
+		*out = new(IngressTemplatesIndex)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteTemplatesIndex != nil {
+		in, out := &in.RouteTemplatesIndex, &out.RouteTemplatesIndex
+		*out = new(RouteTemplatesIndex)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2408,6 +3325,21 @@ func (in *ZookeeperConfig) DeepCopyInto(out *ZookeeperConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KeeperRef != nil {
+		in, out := &in.KeeperRef, &out.KeeperRef
+		*out = new(ZookeeperKeeperRef)
+		**out = **in
+	}
+	if in.IdentitySecret != nil {
+		in, out := &in.IdentitySecret, &out.IdentitySecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACL != nil {
+		in, out := &in.ACL, &out.ACL
+		*out = new(types.StringBool)
+		**out = **in
+	}
 	return
 }
 
@@ -2421,6 +3353,38 @@ func (in *ZookeeperConfig) DeepCopy() *ZookeeperConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZookeeperIdentityStatus) DeepCopyInto(out *ZookeeperIdentityStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZookeeperIdentityStatus.
+func (in *ZookeeperIdentityStatus) DeepCopy() *ZookeeperIdentityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZookeeperIdentityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZookeeperKeeperRef) DeepCopyInto(out *ZookeeperKeeperRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZookeeperKeeperRef.
+func (in *ZookeeperKeeperRef) DeepCopy() *ZookeeperKeeperRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ZookeeperKeeperRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZookeeperNode) DeepCopyInto(out *ZookeeperNode) {
 	*out = *in
@@ -22,6 +22,13 @@ type CopyStatusOptions struct {
 	MainFields        bool
 	WholeStatus       bool
 	InheritableFields bool
+	Scaling           bool
+	Schedule          bool
+	TLS               bool
+	KeeperMigration   bool
+	ZookeeperIdentity bool
+	Keeper            bool
+	HostsKept         bool
 }
 
 // UpdateStatusOptions defines how to update CHI status
@@ -34,8 +34,15 @@ const (
 	// Misc section
 	PodDistributionMaxNumberPerNode                    = "MaxNumberPerNode"
 	PodDistributionMaxNumberPerNodeEqualsReplicasCount = 2000000000
+	// TopologySpread section
+	// Pods of the scope are spread evenly across topology domains, specified by topology key, pod can not be scheduled otherwise
+	PodDistributionTopologySpread = "TopologySpread"
+	// Pods of the scope are spread evenly across topology domains, specified by topology key, whenever possible
+	PodDistributionPreferredTopologySpread = "PreferredTopologySpread"
 	// Shortcuts section
 	PodDistributionCircularReplication = "CircularReplication"
+	// Replicas of each shard are spread across zones, pods of each zone are spread across nodes
+	PodDistributionZoneBalanced = "ZoneBalanced"
 
 	PodDistributionScopeUnspecified = "Unspecified"
 	// Pods from different ClickHouseInstallation.Cluster.Shard can co-exist on one node
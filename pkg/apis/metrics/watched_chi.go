@@ -43,6 +43,7 @@ type WatchedHost struct {
 	TLSPort   int32  `json:"tlsPort,omitempty"   yaml:"tlsPort,omitempty"`
 	HTTPPort  int32  `json:"httpPort,omitempty"  yaml:"httpPort,omitempty"`
 	HTTPSPort int32  `json:"httpsPort,omitempty" yaml:"httpsPort,omitempty"`
+	ZKPort    int32  `json:"zkPort,omitempty"    yaml:"zkPort,omitempty"`
 }
 
 // NewWatchedCHI creates new watched CHI
@@ -153,4 +154,5 @@ func (host *WatchedHost) readFrom(h *api.Host) {
 	host.TLSPort = h.TLSPort.Value()
 	host.HTTPPort = h.HTTPPort.Value()
 	host.HTTPSPort = h.HTTPSPort.Value()
+	host.ZKPort = h.ZKPort.Value()
 }
@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
 	kube "k8s.io/client-go/kubernetes"
 	kuberest "k8s.io/client-go/rest"
 	kubeclientcmd "k8s.io/client-go/tools/clientcmd"
@@ -65,11 +66,12 @@ func getKubeConfig(kubeConfigFile, masterURL string) (*kuberest.Config, error) {
 	return conf, nil
 }
 
-// GetClientset gets k8s API clients - both kube native client and our custom client
+// GetClientset gets k8s API clients - kube native client, our custom client and dynamic client
 func GetClientset(kubeConfigFile, masterURL string) (
 	*kube.Clientset,
 	*apiextensions.Clientset,
 	*chopclientset.Clientset,
+	dynamic.Interface,
 ) {
 	kubeConfig, err := getKubeConfig(kubeConfigFile, masterURL)
 	if err != nil {
@@ -116,7 +118,12 @@ func GetClientset(kubeConfigFile, masterURL string) (
 		log.F().Fatal("Unable to initialize clickhouse-operator API clientset: %s", err.Error())
 	}
 
-	return kubeClientset, apiextensionsClientset, chopClientset
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		log.F().Fatal("Unable to initialize kubernetes API dynamic client: %s", err.Error())
+	}
+
+	return kubeClientset, apiextensionsClientset, chopClientset, dynamicClient
 }
 
 var chop *CHOp
@@ -27,6 +27,8 @@ const (
 	ReconcileAdd    = "add"
 	ReconcileUpdate = "update"
 	ReconcileDelete = "delete"
+	// ReconcileForce requests reconcile of the CR even in case it has nothing changed
+	ReconcileForce = "force"
 )
 
 // PriorityQueueItem specifies item of the priority queue
@@ -19,8 +19,14 @@ import (
 )
 
 const (
-	componentName   = "clickhouse-operator"
-	runWorkerPeriod = time.Second
+	componentName         = "clickhouse-operator"
+	runWorkerPeriod       = time.Second
+	autoscalePeriod       = 30 * time.Second
+	schedulePeriod        = 30 * time.Second
+	tlsRotatePeriod       = 10 * time.Minute
+	keeperRefPeriod       = 1 * time.Minute
+	keeperMigrationPeriod = 30 * time.Second
+	zkIdentityPeriod      = 1 * time.Minute
 )
 
 const (
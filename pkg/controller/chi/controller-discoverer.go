@@ -17,6 +17,7 @@ package chi
 import (
 	"context"
 
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
@@ -48,6 +49,10 @@ func (c *Controller) discovery(ctx context.Context, cr api.ICustomResource) *mod
 	// Comment out PV
 	//c.discoveryPVs(ctx, r, chi, opts)
 	c.discoveryPDBs(ctx, r, cr, opts)
+	c.discoveryNetworkPolicies(ctx, r, cr, opts)
+	c.discoveryIngresses(ctx, r, cr, opts)
+	c.discoveryRoutes(ctx, r, cr, api.RouteKindHTTPRoute, opts)
+	c.discoveryRoutes(ctx, r, cr, api.RouteKindTLSRoute, opts)
 	return r
 }
 
@@ -156,3 +161,53 @@ func (c *Controller) discoveryPDBs(ctx context.Context, r *model.Registry, cr ap
 		r.RegisterPDB(obj.GetObjectMeta())
 	}
 }
+
+func (c *Controller) discoveryNetworkPolicies(ctx context.Context, r *model.Registry, cr api.ICustomResource, opts meta.ListOptions) {
+	list, err := c.kube.NetworkPolicy().List(ctx, cr.GetNamespace(), opts)
+	if err != nil {
+		log.M(cr).F().Error("FAIL to list NetworkPolicy - err: %v", err)
+		return
+	}
+	if list == nil {
+		log.M(cr).F().Error("FAIL to list NetworkPolicy - list is nil")
+		return
+	}
+	for _, obj := range list {
+		r.RegisterNetworkPolicy(obj.GetObjectMeta())
+	}
+}
+
+func (c *Controller) discoveryIngresses(ctx context.Context, r *model.Registry, cr api.ICustomResource, opts meta.ListOptions) {
+	list, err := c.kube.Ingress().List(ctx, cr.GetNamespace(), opts)
+	if err != nil {
+		log.M(cr).F().Error("FAIL to list Ingress - err: %v", err)
+		return
+	}
+	if list == nil {
+		log.M(cr).F().Error("FAIL to list Ingress - list is nil")
+		return
+	}
+	for _, obj := range list {
+		r.RegisterIngress(obj.GetObjectMeta())
+	}
+}
+
+func (c *Controller) discoveryRoutes(ctx context.Context, r *model.Registry, cr api.ICustomResource, kind string, opts meta.ListOptions) {
+	list, err := c.kube.Route().List(ctx, kind, cr.GetNamespace(), opts)
+	if apiErrors.IsNotFound(err) {
+		// Gateway API is not installed in the k8s cluster, thus there can be no routes
+		log.V(2).M(cr).F().Info("unable to list %s - Gateway API is not installed", kind)
+		return
+	}
+	if err != nil {
+		log.M(cr).F().Error("FAIL to list %s - err: %v", kind, err)
+		return
+	}
+	if list == nil {
+		log.M(cr).F().Error("FAIL to list %s - list is nil", kind)
+		return
+	}
+	for i := range list {
+		registerRoute(r, &list[i])
+	}
+}
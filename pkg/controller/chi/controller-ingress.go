@@ -0,0 +1,77 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+func (c *Controller) getIngress(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
+	return c.kube.Ingress().Get(ctx, ingress.GetNamespace(), ingress.GetName())
+}
+
+func (c *Controller) createIngress(ctx context.Context, ingress *networking.Ingress) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.Ingress().Create(ctx, ingress)
+
+	return err
+}
+
+func (c *Controller) updateIngress(ctx context.Context, ingress *networking.Ingress) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.Ingress().Update(ctx, ingress)
+
+	return err
+}
+
+func (c *Controller) getRoute(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return c.kube.Route().Get(ctx, route.GetKind(), route.GetNamespace(), route.GetName())
+}
+
+func (c *Controller) createRoute(ctx context.Context, route *unstructured.Unstructured) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.Route().Create(ctx, route)
+
+	return err
+}
+
+func (c *Controller) updateRoute(ctx context.Context, route *unstructured.Unstructured) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.Route().Update(ctx, route)
+
+	return err
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+
+	networking "k8s.io/api/networking/v1"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+func (c *Controller) getNetworkPolicy(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error) {
+	return c.kube.NetworkPolicy().Get(ctx, networkPolicy.GetNamespace(), networkPolicy.GetName())
+}
+
+func (c *Controller) createNetworkPolicy(ctx context.Context, networkPolicy *networking.NetworkPolicy) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.NetworkPolicy().Create(ctx, networkPolicy)
+
+	return err
+}
+
+func (c *Controller) updateNetworkPolicy(ctx context.Context, networkPolicy *networking.NetworkPolicy) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	_, err := c.kube.NetworkPolicy().Update(ctx, networkPolicy)
+
+	return err
+}
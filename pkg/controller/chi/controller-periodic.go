@@ -0,0 +1,69 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/chi/cmd_queue"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// periodicTask specifies task run periodically over each watched CR.
+// CR provided is a copy of the informer cache object and may be modified by the task
+type periodicTask func(ctx context.Context, cr *api.ClickHouseInstallation)
+
+// runPeriodic runs task over watched CRs every period till context is done.
+// CRs are listed from the informer cache, thus periodic tasks do not load API server.
+func (c *Controller) runPeriodic(ctx context.Context, name string, period time.Duration, task periodicTask) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for _, cr := range c.listWatchedCRs(name) {
+			if util.IsContextDone(ctx) {
+				log.V(2).Info("task is done")
+				return
+			}
+			task(ctx, cr)
+		}
+	}, period)
+}
+
+// listWatchedCRs lists copies of the CRs of the watched namespaces from the informer cache
+func (c *Controller) listWatchedCRs(name string) (list []*api.ClickHouseInstallation) {
+	crs, err := c.chiLister.ClickHouseInstallations(chop.Config().GetInformerNamespace()).List(labels.Everything())
+	if err != nil {
+		log.V(1).F().Error("unable to list CHIs for %s. err: %v", name, err)
+		return nil
+	}
+	for _, cr := range crs {
+		if chop.Config().IsWatchedNamespace(cr.GetNamespace()) {
+			list = append(list, cr.DeepCopy())
+		}
+	}
+	return list
+}
+
+// enqueueReconcileForce enqueues reconcile of the CR, which runs even in case CR has nothing changed.
+// Used by periodic tasks to apply changes of the objects CR depends on, instead of modifying the CR.
+func (c *Controller) enqueueReconcileForce(cr *api.ClickHouseInstallation, reason string) {
+	log.V(1).M(cr).F().Info("Enqueue forced reconcile of CR %s. Reason: %s", util.NamespaceNameString(cr), reason)
+	c.enqueueObject(cmd_queue.NewReconcileCHI(cmd_queue.ReconcileForce, nil, cr))
+}
@@ -30,6 +30,7 @@ import (
 	kubeTypes "k8s.io/apimachinery/pkg/types"
 	utilRuntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	kubeInformers "k8s.io/client-go/informers"
 	kube "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -46,6 +47,7 @@ import (
 	chopClientSet "github.com/altinity/clickhouse-operator/pkg/client/clientset/versioned"
 	chopClientSetScheme "github.com/altinity/clickhouse-operator/pkg/client/clientset/versioned/scheme"
 	chopInformers "github.com/altinity/clickhouse-operator/pkg/client/informers/externalversions"
+	chopListers "github.com/altinity/clickhouse-operator/pkg/client/listers/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/controller"
 	"github.com/altinity/clickhouse-operator/pkg/controller/chi/cmd_queue"
 	chiKube "github.com/altinity/clickhouse-operator/pkg/controller/chi/kube"
@@ -71,6 +73,9 @@ type Controller struct {
 	extClient  apiExtensions.Interface
 	chopClient chopClientSet.Interface
 
+	// chiLister lists CHIs from the informer cache
+	chiLister chopListers.ClickHouseInstallationLister
+
 	// queues used to organize events queue processed by operator
 	queues []queue.PriorityQueue
 	// not used explicitly
@@ -86,6 +91,7 @@ func NewController(
 	chopClient chopClientSet.Interface,
 	extClient apiExtensions.Interface,
 	kubeClient kube.Interface,
+	dynamicClient dynamic.Interface,
 	chopInformerFactory chopInformers.SharedInformerFactory,
 	kubeInformerFactory kubeInformers.SharedInformerFactory,
 ) *Controller {
@@ -109,13 +115,14 @@ func NewController(
 	)
 
 	namer := managers.NewNameManager(managers.NameManagerTypeClickHouse)
-	kube := chiKube.NewAdapter(kubeClient, chopClient, namer)
+	kube := chiKube.NewAdapter(kubeClient, chopClient, dynamicClient, namer)
 
 	// Create Controller instance
 	controller := &Controller{
 		kubeClient:  kubeClient,
 		extClient:   extClient,
 		chopClient:  chopClient,
+		chiLister:   chopInformerFactory.Clickhouse().V1().ClickHouseInstallations().Lister(),
 		recorder:    recorder,
 		namer:       namer,
 		kube:        kube,
@@ -524,6 +531,30 @@ func (c *Controller) Run(ctx context.Context) {
 	}
 	defer log.V(1).F().Info("ClickHouseInstallation controller: shutting down workers")
 
+	// Autoscaler runs on its own, outside of reconcile queues
+	autoscaler := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "autoscaler", autoscalePeriod, autoscaler.autoscale)
+
+	// Scheduler runs on its own, outside of reconcile queues
+	scheduler := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "scheduler", schedulePeriod, scheduler.schedule)
+
+	// TLS certificates rotation runs on its own, outside of reconcile queues
+	tlsRotator := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "TLS rotation", tlsRotatePeriod, tlsRotator.rotateTLS)
+
+	// Keeper references are watched on their own, outside of reconcile queues
+	keeperRefWatcher := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "keeper refs", keeperRefPeriod, keeperRefWatcher.watchKeeperRef)
+
+	// Keeper migrations advance on their own, outside of reconcile queues
+	keeperMigrator := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "keeper migration", keeperMigrationPeriod, keeperMigrator.migrateKeeperCR)
+
+	// Zookeeper identities rotation runs on its own, outside of reconcile queues
+	zkIdentityRotator := c.newWorker(nil, true)
+	go c.runPeriodic(ctx, "zookeeper identity rotation", zkIdentityPeriod, zkIdentityRotator.rotateZookeeperIdentityCR)
+
 	log.V(1).F().Info("ClickHouseInstallation controller: workers started")
 	<-ctx.Done()
 }
@@ -594,7 +625,7 @@ func (c *Controller) enqueueObject(obj queue.PriorityQueueItem) {
 		variants := len(c.queues) - api.DefaultReconcileSystemThreadsNumber
 		index = api.DefaultReconcileSystemThreadsNumber + util.HashIntoIntTopped(handle, variants)
 		switch command.Cmd {
-		case cmd_queue.ReconcileAdd:
+		case cmd_queue.ReconcileAdd, cmd_queue.ReconcileForce:
 			enqueue = prepareCHIAdd(command)
 		case cmd_queue.ReconcileUpdate:
 			enqueue = prepareCHIUpdate(command)
@@ -623,6 +654,10 @@ func (c *Controller) updateWatch(chi *api.ClickHouseInstallation) {
 
 // updateWatchAsync
 func (c *Controller) updateWatchAsync(chi *metrics.WatchedCHI) {
+	if chop.Config().ClickHouse.Metrics.Sharding.IsEnabled() {
+		// CHIs are discovered by the sharded metrics exporter replicas
+		return
+	}
 	if err := clickhouse.InformMetricsExporterAboutWatchedCHI(chi); err != nil {
 		log.V(1).F().Info("FAIL update watch (%s/%s): %q", chi.Namespace, chi.Name, err)
 	} else {
@@ -638,6 +673,10 @@ func (c *Controller) deleteWatch(chi *api.ClickHouseInstallation) {
 
 // deleteWatchAsync
 func (c *Controller) deleteWatchAsync(chi *metrics.WatchedCHI) {
+	if chop.Config().ClickHouse.Metrics.Sharding.IsEnabled() {
+		// CHIs are discovered by the sharded metrics exporter replicas
+		return
+	}
 	if err := clickhouse.InformMetricsExporterToDeleteWatchedCHI(chi); err != nil {
 		log.V(1).F().Info("FAIL delete watch (%s/%s): %q", chi.Namespace, chi.Name, err)
 	} else {
@@ -0,0 +1,64 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+)
+
+// Certificate manages cert-manager Certificates. cert-manager is not a part of k8s core API, so dynamic client is used
+type Certificate struct {
+	dynamicClient dynamic.Interface
+}
+
+func NewCertificate(dynamicClient dynamic.Interface) *Certificate {
+	return &Certificate{
+		dynamicClient: dynamicClient,
+	}
+}
+
+func (c *Certificate) Get(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.CertificateGroupVersionResource).Namespace(namespace).Get(ctx, name, controller.NewGetOptions())
+}
+
+func (c *Certificate) Create(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.CertificateGroupVersionResource).Namespace(certificate.GetNamespace()).Create(ctx, certificate, controller.NewCreateOptions())
+}
+
+func (c *Certificate) Update(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.CertificateGroupVersionResource).Namespace(certificate.GetNamespace()).Update(ctx, certificate, controller.NewUpdateOptions())
+}
+
+func (c *Certificate) Delete(ctx context.Context, namespace, name string) error {
+	c.dynamicClient.Resource(k8s.CertificateGroupVersionResource).Namespace(namespace).Delete(ctx, name, controller.NewDeleteOptions())
+	return poller.New(ctx, fmt.Sprintf("%s/%s", namespace, name)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				_, err := c.Get(ctx, namespace, name)
+				return errors.IsNotFound(err)
+			},
+		}).Poll()
+}
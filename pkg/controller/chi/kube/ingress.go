@@ -0,0 +1,74 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube "k8s.io/client-go/kubernetes"
+
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+)
+
+type Ingress struct {
+	kubeClient kube.Interface
+}
+
+func NewIngress(kubeClient kube.Interface) *Ingress {
+	return &Ingress{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Ingress) Get(ctx context.Context, namespace, name string) (*networking.Ingress, error) {
+	return c.kubeClient.NetworkingV1().Ingresses(namespace).Get(ctx, name, controller.NewGetOptions())
+}
+
+func (c *Ingress) Create(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
+	return c.kubeClient.NetworkingV1().Ingresses(ingress.Namespace).Create(ctx, ingress, controller.NewCreateOptions())
+}
+
+func (c *Ingress) Update(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
+	return c.kubeClient.NetworkingV1().Ingresses(ingress.Namespace).Update(ctx, ingress, controller.NewUpdateOptions())
+}
+
+func (c *Ingress) Delete(ctx context.Context, namespace, name string) error {
+	c.kubeClient.NetworkingV1().Ingresses(namespace).Delete(ctx, name, controller.NewDeleteOptions())
+	return poller.New(ctx, fmt.Sprintf("%s/%s", namespace, name)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				_, err := c.Get(ctx, namespace, name)
+				return errors.IsNotFound(err)
+			},
+		}).Poll()
+}
+
+func (c *Ingress) List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.Ingress, error) {
+	list, err := c.kubeClient.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+)
+
+// Keeper gets ClickHouseKeeperInstallations. CHK is not a part of chop clientset, so dynamic client is used
+type Keeper struct {
+	dynamicClient dynamic.Interface
+}
+
+func NewKeeper(dynamicClient dynamic.Interface) *Keeper {
+	return &Keeper{
+		dynamicClient: dynamicClient,
+	}
+}
+
+func (c *Keeper) Get(ctx context.Context, namespace, name string) (*apiChk.ClickHouseKeeperInstallation, error) {
+	obj, err := c.dynamicClient.Resource(apiChk.SchemeGroupVersion.WithResource("clickhousekeeperinstallations")).Namespace(namespace).Get(ctx, name, controller.NewGetOptions())
+	if err != nil {
+		return nil, err
+	}
+	chk := &apiChk.ClickHouseKeeperInstallation{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), chk); err != nil {
+		return nil, err
+	}
+	return chk, nil
+}
+
+// Create creates ClickHouseKeeperInstallation
+func (c *Keeper) Create(ctx context.Context, chk *apiChk.ClickHouseKeeperInstallation) (*apiChk.ClickHouseKeeperInstallation, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(chk)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.dynamicClient.Resource(apiChk.SchemeGroupVersion.WithResource("clickhousekeeperinstallations")).Namespace(chk.GetNamespace()).Create(ctx, &unstructured.Unstructured{Object: content}, controller.NewCreateOptions())
+	if err != nil {
+		return nil, err
+	}
+	created := &apiChk.ClickHouseKeeperInstallation{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListTemplates lists ClickHouseKeeperInstallationTemplates as plain CHKs
+func (c *Keeper) ListTemplates(ctx context.Context) ([]*apiChk.ClickHouseKeeperInstallation, error) {
+	list, err := c.dynamicClient.Resource(apiChk.SchemeGroupVersion.WithResource("clickhousekeeperinstallationtemplates")).Namespace(chop.Config().GetInformerNamespace()).List(ctx, controller.NewListOptions())
+	if err != nil {
+		return nil, err
+	}
+	var templates []*apiChk.ClickHouseKeeperInstallation
+	for i := range list.Items {
+		template := &apiChk.ClickHouseKeeperInstallation{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].UnstructuredContent(), template); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube "k8s.io/client-go/kubernetes"
+
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+)
+
+type NetworkPolicy struct {
+	kubeClient kube.Interface
+}
+
+func NewNetworkPolicy(kubeClient kube.Interface) *NetworkPolicy {
+	return &NetworkPolicy{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *NetworkPolicy) Create(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error) {
+	return c.kubeClient.NetworkingV1().NetworkPolicies(networkPolicy.Namespace).Create(ctx, networkPolicy, controller.NewCreateOptions())
+}
+
+func (c *NetworkPolicy) Get(ctx context.Context, namespace, name string) (*networking.NetworkPolicy, error) {
+	return c.kubeClient.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, controller.NewGetOptions())
+}
+
+func (c *NetworkPolicy) Update(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error) {
+	return c.kubeClient.NetworkingV1().NetworkPolicies(networkPolicy.Namespace).Update(ctx, networkPolicy, controller.NewUpdateOptions())
+}
+
+func (c *NetworkPolicy) Delete(ctx context.Context, namespace, name string) error {
+	c.kubeClient.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, controller.NewDeleteOptions())
+	return poller.New(ctx, fmt.Sprintf("%s/%s", namespace, name)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				_, err := c.Get(ctx, namespace, name)
+				return errors.IsNotFound(err)
+			},
+		}).Poll()
+}
+
+func (c *NetworkPolicy) List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.NetworkPolicy, error) {
+	list, err := c.kubeClient.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
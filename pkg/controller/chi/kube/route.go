@@ -0,0 +1,76 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+)
+
+// Route manages Gateway API routes. Gateway API is not a part of k8s core API, so dynamic client is used
+type Route struct {
+	dynamicClient dynamic.Interface
+}
+
+func NewRoute(dynamicClient dynamic.Interface) *Route {
+	return &Route{
+		dynamicClient: dynamicClient,
+	}
+}
+
+func (c *Route) Get(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.RouteGroupVersionResource(kind)).Namespace(namespace).Get(ctx, name, controller.NewGetOptions())
+}
+
+func (c *Route) Create(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.RouteGroupVersionResource(route.GetKind())).Namespace(route.GetNamespace()).Create(ctx, route, controller.NewCreateOptions())
+}
+
+func (c *Route) Update(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return c.dynamicClient.Resource(k8s.RouteGroupVersionResource(route.GetKind())).Namespace(route.GetNamespace()).Update(ctx, route, controller.NewUpdateOptions())
+}
+
+func (c *Route) Delete(ctx context.Context, kind, namespace, name string) error {
+	c.dynamicClient.Resource(k8s.RouteGroupVersionResource(kind)).Namespace(namespace).Delete(ctx, name, controller.NewDeleteOptions())
+	return poller.New(ctx, fmt.Sprintf("%s/%s", namespace, name)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				_, err := c.Get(ctx, kind, namespace, name)
+				return errors.IsNotFound(err)
+			},
+		}).Poll()
+}
+
+func (c *Route) List(ctx context.Context, kind, namespace string, opts meta.ListOptions) ([]unstructured.Unstructured, error) {
+	list, err := c.dynamicClient.Resource(k8s.RouteGroupVersionResource(kind)).Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
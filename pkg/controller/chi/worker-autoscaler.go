@@ -0,0 +1,186 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"math"
+	"time"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+)
+
+// autoscalerTolerance specifies relative deviation of the load from the target, which does not lead to scaling
+const autoscalerTolerance = 0.1
+
+// autoscale adjusts replicas count of the scaled cluster of the CR in case autoscaler is enabled
+func (w *worker) autoscale(ctx context.Context, cr *api.ClickHouseInstallation) {
+	if w.shouldAutoscale(cr) {
+		w.autoscaleCR(ctx, cr)
+	}
+}
+
+// setScalingSources records into status values replicas count of the scaled cluster of the normalized CR is based on,
+// so the next normalization is able to detect which one of them is changed.
+// Decision of the disabled autoscaler is dropped, it is not to be applied once autoscaler is enabled again
+func (w *worker) setScalingSources(cr *api.ClickHouseInstallation) {
+	scaling := cr.GetSpecT().GetScaling()
+	if scaling == nil {
+		return
+	}
+	cluster, _ := cr.FindCluster(scaling.GetCluster()).(*api.Cluster)
+	if cluster == nil {
+		return
+	}
+
+	if !scaling.GetAutoscaler().IsEnabled() {
+		cr.EnsureStatus().SetScalingAutoscalerReplicas(0)
+	}
+	cr.EnsureStatus().SetScalingSources(int32(scaling.GetReplicas().IntValue()), int32(cluster.Runtime.LayoutReplicasCount))
+}
+
+// shouldAutoscale checks whether CR is eligible for autoscaling at the moment
+func (w *worker) shouldAutoscale(cr *api.ClickHouseInstallation) bool {
+	autoscaler := cr.GetSpecT().GetScaling().GetAutoscaler()
+	switch {
+	case !autoscaler.IsEnabled():
+		return false
+	case cr.IsStopped():
+		return false
+	case cr.EnsureStatus().GetStatus() != api.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return false
+	}
+
+	// Respect cooldown since the last scale operation
+	lastScaleTime, err := time.Parse(time.RFC3339, cr.EnsureStatus().GetScalingLastScaleTime())
+	if err != nil {
+		// No scale operation performed yet
+		return true
+	}
+	cooldown := time.Duration(autoscaler.Cooldown.Normalize(api.AutoscalerDefaultCooldown).Value()) * time.Second
+	return time.Since(lastScaleTime) >= cooldown
+}
+
+// autoscaleCR adjusts replicas count of the scaled cluster of the CR based on the load
+func (w *worker) autoscaleCR(ctx context.Context, cr *api.ClickHouseInstallation) {
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to normalize CR for autoscaler. err: %v", err)
+		return
+	}
+
+	scaling := normalized.GetSpecT().GetScaling()
+	cluster, _ := normalized.FindCluster(scaling.GetCluster()).(*api.Cluster)
+	if cluster == nil {
+		w.a.V(1).M(cr).F().Warning("unable to find cluster to autoscale: %s", scaling.GetCluster())
+		return
+	}
+
+	current := cluster.Layout.ReplicasCount
+	desired, ok := w.autoscalerDesiredReplicas(ctx, cluster, scaling.GetAutoscaler())
+	if !ok || (desired == current) {
+		return
+	}
+
+	w.a.V(1).
+		WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateStarted).
+		M(cr).F().
+		Info("Autoscale cluster: %s replicas: %d -> %d", cluster.GetName(), current, desired)
+	if err := w.scaleCR(ctx, cr, desired); err != nil {
+		w.a.WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateFailed).
+			M(cr).F().
+			Error("Autoscale cluster: %s FAILED. err: %v", cluster.GetName(), err)
+	}
+}
+
+// autoscalerDesiredReplicas calculates desired replicas count of the cluster based on the average load of the hosts.
+// Returns false in case desired replicas count can not be calculated - no metrics are available or no targets are specified
+func (w *worker) autoscalerDesiredReplicas(ctx context.Context, cluster *api.Cluster, autoscaler *api.ChiAutoscaler) (int, bool) {
+	current := cluster.Layout.ReplicasCount
+
+	queries, cpu, hosts := 0, 0, 0
+	cluster.WalkHosts(func(host *api.Host) error {
+		q, err := w.ensureClusterSchemer(host).HostActiveQueriesNum(ctx, host)
+		if err != nil {
+			w.a.V(1).M(host).F().Warning("unable to get active queries on host: %s err: %v", host.GetName(), err)
+			return nil
+		}
+		c, err := w.ensureClusterSchemer(host).HostCPUUtilization(ctx, host)
+		if err != nil {
+			w.a.V(1).M(host).F().Warning("unable to get CPU utilization of host: %s err: %v", host.GetName(), err)
+			return nil
+		}
+		queries += q
+		cpu += c
+		hosts++
+		return nil
+	})
+	if hosts == 0 {
+		// No metrics available
+		return 0, false
+	}
+
+	// Desired replicas count may be zero in case of no load, it is fit into boundaries below
+	desired, ok := 0, false
+	if target := autoscaler.GetTargetQueries(); target > 0 {
+		desired = max(desired, desiredReplicas(current, float64(queries)/float64(hosts), float64(target)))
+		ok = true
+	}
+	if target := autoscaler.GetTargetCPU(); target > 0 {
+		desired = max(desired, desiredReplicas(current, float64(cpu)/float64(hosts), float64(target)))
+		ok = true
+	}
+	if !ok {
+		// No targets specified
+		return 0, false
+	}
+
+	// Fit into boundaries. Boundaries not specified explicitly keep current replicas count
+	desired = max(desired, autoscaler.GetMinReplicas(current))
+	desired = min(desired, autoscaler.GetMaxReplicas(current))
+
+	return desired, true
+}
+
+// desiredReplicas calculates desired replicas count having current average load and target load
+func desiredReplicas(current int, average, target float64) int {
+	ratio := average / target
+	if math.Abs(ratio-1.0) <= autoscalerTolerance {
+		// Close enough to the target
+		return current
+	}
+	return int(math.Ceil(float64(current) * ratio))
+}
+
+// scaleCR sets replicas count of the scaled cluster.
+// Decision of the autoscaler is kept in the status, thus user-specified spec of the CR is not modified
+func (w *worker) scaleCR(ctx context.Context, cr *api.ClickHouseInstallation, replicas int) error {
+	cr.EnsureStatus().SetScalingAutoscalerReplicas(int32(replicas))
+	cr.EnsureStatus().SetScalingLastScaleTime(time.Now().Format(time.RFC3339))
+	if err := w.c.updateCRObjectStatus(ctx, cr, types.UpdateStatusOptions{
+		CopyStatusOptions: types.CopyStatusOptions{
+			Scaling: true,
+		},
+	}); err != nil {
+		return err
+	}
+
+	w.c.enqueueReconcileForce(cr, "autoscale")
+	return nil
+}
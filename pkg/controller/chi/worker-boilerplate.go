@@ -23,6 +23,7 @@ import (
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
 	"github.com/altinity/clickhouse-operator/pkg/controller/chi/cmd_queue"
 	"github.com/altinity/clickhouse-operator/pkg/controller/chi/metrics"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
 	normalizerCommon "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
 	"github.com/altinity/clickhouse-operator/pkg/util"
 )
@@ -73,6 +74,8 @@ func (w *worker) processReconcileCHI(ctx context.Context, cmd *cmd_queue.Reconci
 		return w.updateCHI(ctx, nil, cmd.New)
 	case cmd_queue.ReconcileUpdate:
 		return w.updateCHI(ctx, cmd.Old, cmd.New)
+	case cmd_queue.ReconcileForce:
+		return w.updateCHI(common.WithReconcileForce(ctx), nil, cmd.New)
 	case cmd_queue.ReconcileDelete:
 		return w.discoveryAndDeleteCR(ctx, cmd.Old)
 	}
@@ -70,10 +70,16 @@ func (w *worker) reconcileCR(ctx context.Context, old, new *api.ClickHouseInstal
 	}
 
 	w.a.M(new).F().Info("Normalized OLD: %s", util.NamespaceNameString(new))
-	old = w.normalize(old)
+	old, _ = w.normalize(old)
 
 	w.a.M(new).F().Info("Normalized NEW: %s", util.NamespaceNameString(new))
-	new = w.normalize(new)
+	new, err := w.normalize(new)
+	if err != nil {
+		// Invalid sections of the CR are reported into status and dropped by the normalizer,
+		// so the rest of the CR is still reconciled
+		w.a.M(new).F().Warning("CR is partially invalid, invalid sections are not applied. CR: %s err: %v", util.NamespaceNameString(new), err)
+	}
+	w.setScalingSources(new)
 
 	new.SetAncestor(old)
 	common.LogOldAndNew("normalized", old, new)
@@ -86,6 +92,8 @@ func (w *worker) reconcileCR(ctx context.Context, old, new *api.ClickHouseInstal
 		w.a.M(new).F().Info("ActionPlan has actions - continue reconcile")
 	case w.isAfterFinalizerInstalled(old, new):
 		w.a.M(new).F().Info("isAfterFinalizerInstalled - continue reconcile-2")
+	case common.IsReconcileForced(ctx):
+		w.a.M(new).F().Info("ActionPlan has no actions but reconcile is forced - continue reconcile")
 	default:
 		w.a.M(new).F().Info("ActionPlan has no actions and no need to install finalizer - nothing to do")
 		return nil
@@ -118,6 +126,7 @@ func (w *worker) reconcileCR(ctx context.Context, old, new *api.ClickHouseInstal
 			log.V(2).Info("task is done")
 			return nil
 		}
+		w.keepLastReplicasWithData(ctx, new, actionPlan)
 		w.clean(ctx, new)
 		w.dropReplicas(ctx, new, actionPlan)
 		w.addCHIToMonitoring(new)
@@ -173,6 +182,21 @@ func (w *worker) reconcileCRAuxObjectsPreliminary(ctx context.Context, cr *api.C
 	w.a.V(2).M(cr).S().P()
 	defer w.a.V(2).M(cr).E().P()
 
+	// TLS certificate has to be available before hosts are reconciled
+	if err := w.reconcileTLS(ctx, cr); err != nil {
+		w.a.F().Error("failed to reconcile TLS certificate. err: %v", err)
+	}
+
+	// Zookeeper identities have to be granted access before hosts are reconciled
+	if err := w.reconcileZookeeperIdentity(ctx, cr); err != nil {
+		w.a.F().Error("failed to reconcile zookeeper identity. err: %v", err)
+	}
+
+	// NetworkPolicy has to allow inter-host traffic before hosts are reconciled
+	if err := w.reconcileCRNetworkPolicy(ctx, cr); err != nil {
+		w.a.F().Error("failed to reconcile network policy. err: %v", err)
+	}
+
 	// CR common ConfigMap without added hosts
 	cr.GetRuntime().LockCommonConfig()
 	if err := w.reconcileConfigMapCommon(ctx, cr, w.options()); err != nil {
@@ -214,7 +238,8 @@ func (w *worker) reconcileCRServiceFinal(ctx context.Context, cr api.ICustomReso
 		w.task.RegistryReconciled().RegisterService(service.GetObjectMeta())
 	}
 
-	return nil
+	// Expose the whole CHI outside of k8s cluster
+	return w.reconcileIngressAndRoute(ctx, interfaces.IngressCR)
 }
 
 // reconcileCRAuxObjectsFinal reconciles CR global objects
@@ -231,6 +256,11 @@ func (w *worker) reconcileCRAuxObjectsFinal(ctx context.Context, cr *api.ClickHo
 	cr.GetRuntime().LockCommonConfig()
 	err = w.reconcileConfigMapCommon(ctx, cr, nil)
 	cr.GetRuntime().UnlockCommonConfig()
+
+	// Previous zookeeper identities are not used by hosts anymore
+	if err := w.finalizeZookeeperIdentity(ctx, cr); err != nil {
+		w.a.F().Error("failed to finalize zookeeper identity. err: %v", err)
+	}
 	return err
 }
 
@@ -399,6 +429,12 @@ func (w *worker) reconcileCluster(ctx context.Context, cluster *api.Cluster) err
 		}
 	}
 
+	// Add Cluster Ingress and route.
+	// Failed ones are registered as failed objects and do not abort reconcile, same as on shard level
+	if err := w.reconcileIngressAndRoute(ctx, interfaces.IngressCluster, cluster); err != nil {
+		w.a.V(1).M(cluster).F().Warning("unable to reconcile ingress or route. Cluster: %s err: %v", cluster.GetName(), err)
+	}
+
 	w.reconcileClusterSecret(ctx, cluster)
 
 	pdb := w.task.Creator().CreatePodDisruptionBudget(cluster)
@@ -408,7 +444,13 @@ func (w *worker) reconcileCluster(ctx context.Context, cluster *api.Cluster) err
 		w.task.RegistryFailed().RegisterPDB(pdb.GetObjectMeta())
 	}
 
-	reconcileZookeeperRootPath(cluster)
+	// Cluster is not ready until keeper it refers to is
+	if err := w.waitKeeperRefQuorum(ctx, cluster); err != nil {
+		w.a.V(1).M(cluster).F().Warning("keeper quorum is not healthy. Cluster: %s err: %v", cluster.GetName(), err)
+		return err
+	}
+
+	w.reconcileZookeeperRootPath(ctx, cluster)
 	return nil
 }
 
@@ -463,6 +505,11 @@ func (w *worker) reconcileShardsAndHosts(ctx context.Context, shards []*api.ChiS
 		opts = &common.ReconcileShardsAndHostsOptions{}
 	}
 
+	if !opts.FullFanOut && shards[0].GetRuntime().GetCR().GetReconciling().IsReconcilingOrderZone() {
+		// Roll hosts zone by zone instead of shard by shard
+		return w.reconcileShardsAndHostsByZones(ctx, shards)
+	}
+
 	// Which shard to start concurrent processing with
 	var startShard int
 	if opts.FullFanOut {
@@ -541,9 +588,17 @@ func (w *worker) reconcileShard(ctx context.Context, shard api.IShard) error {
 	w.a.V(2).M(shard).S().P()
 	defer w.a.V(2).M(shard).E().P()
 
-	err := w.reconcileShardService(ctx, shard)
+	if err := w.reconcileShardService(ctx, shard); err != nil {
+		return err
+	}
+
+	// Add Shard's Ingress and route.
+	// Failed ones are registered as failed objects and do not abort reconcile, same as on cluster level
+	if err := w.reconcileIngressAndRoute(ctx, interfaces.IngressShard, shard); err != nil {
+		w.a.V(1).M(shard).F().Warning("unable to reconcile ingress or route. Shard: %s err: %v", shard.GetName(), err)
+	}
 
-	return err
+	return nil
 }
 
 func (w *worker) reconcileShardService(ctx context.Context, shard api.IShard) error {
@@ -580,6 +635,22 @@ func (w *worker) reconcileHost(ctx context.Context, host *api.Host) error {
 		defer w.reconcileCRServiceFinal(ctx, host.GetCR())
 	}
 
+	if host.IsExternal() {
+		// External host is not managed by the operator, it is listed in remote_servers only
+		w.a.V(1).M(host).F().Info("Skip external host: %s", host.GetExternalHostname())
+		// It is not counted in hosts of the status as well
+		host.GetReconcileAttributes().UnsetAdd()
+		return nil
+	}
+
+	if err := w.reconcileHostTLS(ctx, host); err != nil {
+		metrics.HostReconcilesErrors(ctx, host.GetCR())
+		w.a.V(1).
+			M(host).F().
+			Warning("Reconcile Host interrupted with an error 1. Host: %s Err: %v", host.GetName(), err)
+		return err
+	}
+
 	// Create artifacts
 	w.stsReconciler.PrepareHostStatefulSetWithStatus(ctx, host, false)
 
@@ -665,6 +736,11 @@ func (w *worker) reconcileHostMain(ctx context.Context, host *api.Host) error {
 
 	w.setHasData(host)
 
+	replicateEphemeral := w.shouldReplicateEphemeralHost(host)
+	if replicateEphemeral {
+		migrateTableOpts = w.prepareEphemeralHost(host)
+	}
+
 	w.a.V(1).
 		M(host).F().
 		Info("Reconcile PVCs and check possible data loss for host: %s", host.GetName())
@@ -718,6 +794,17 @@ func (w *worker) reconcileHostMain(ctx context.Context, host *api.Host) error {
 	}
 	_ = w.migrateTables(ctx, host, migrateTableOpts)
 
+	if replicateEphemeral {
+		// Host has to catch up with other replicas before being included into the cluster
+		if err := w.syncEphemeralHost(ctx, host); err != nil {
+			metrics.HostReconcilesErrors(ctx, host.GetCR())
+			w.a.V(1).
+				M(host).F().
+				Warning("Reconcile Host interrupted with an error 5. Host: %s Err: %v", host.GetName(), err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -48,12 +48,14 @@ func (w *worker) clean(ctx context.Context, cr api.ICustomResource) {
 		Info("remove items scheduled for deletion")
 
 	// Remove deleted items
-	w.a.V(1).M(cr).F().Info("List of objects which have failed to reconcile:\n%s", w.task.RegistryFailed)
-	w.a.V(1).M(cr).F().Info("List of successfully reconciled objects:\n%s", w.task.RegistryReconciled)
+	w.a.V(1).M(cr).F().Info("List of objects which have failed to reconcile:\n%s", w.task.RegistryFailed())
+	w.a.V(1).M(cr).F().Info("List of successfully reconciled objects:\n%s", w.task.RegistryReconciled())
 	objs := w.c.discovery(ctx, cr)
 	need := w.task.RegistryReconciled()
 	w.a.V(1).M(cr).F().Info("Existing objects:\n%s", objs)
 	objs.Subtract(need)
+	// Objects of the hosts kept as the last replicas holding data are not purged
+	objs.Subtract(keptHostsObjects(cr, objs))
 	w.a.V(1).M(cr).F().Info("Non-reconciled objects:\n%s", objs)
 	if w.purge(ctx, cr, objs, w.task.RegistryFailed()) > 0 {
 		w.c.enqueueObject(cmd_queue.NewDropDns(cr))
@@ -64,6 +66,7 @@ func (w *worker) clean(ctx context.Context, cr api.ICustomResource) {
 }
 
 // dropReplicas cleans Zookeeper for replicas that are properly deleted - via AP
+// Replicas of the hosts, which are kept as the last replicas holding data, are skipped
 func (w *worker) dropReplicas(ctx context.Context, cr api.ICustomResource, ap *action_plan.ActionPlan) {
 	if util.IsContextDone(ctx) {
 		log.V(2).Info("task is done")
@@ -78,6 +81,9 @@ func (w *worker) dropReplicas(ctx context.Context, cr api.ICustomResource, ap *a
 		func(shard api.IShard) {
 		},
 		func(host *api.Host) {
+			if isHostKept(cr, host) {
+				return
+			}
 			_ = w.dropReplica(ctx, host)
 			cnt++
 		},
@@ -111,6 +117,14 @@ func (w *worker) purge(
 			w.purgeSecret(ctx, cr, reconcileFailedObjs, m)
 		case model.PDB:
 			w.purgePDB(ctx, cr, reconcileFailedObjs, m)
+		case model.NetworkPolicy:
+			w.purgeNetworkPolicy(ctx, cr, reconcileFailedObjs, m)
+		case model.Ingress:
+			w.purgeIngress(ctx, cr, reconcileFailedObjs, m)
+		case model.HTTPRoute:
+			w.purgeRoute(ctx, cr, reconcileFailedObjs, api.RouteKindHTTPRoute, m)
+		case model.TLSRoute:
+			w.purgeRoute(ctx, cr, reconcileFailedObjs, api.RouteKindTLSRoute, m)
 		}
 	})
 	return cnt
@@ -204,6 +218,49 @@ func (w *worker) purgePDB(
 	}
 }
 
+func (w *worker) purgeNetworkPolicy(
+	ctx context.Context,
+	cr api.ICustomResource,
+	reconcileFailedObjs *model.Registry,
+	m meta.Object,
+) {
+	if shouldPurgeNetworkPolicy(cr, reconcileFailedObjs, m) {
+		w.a.V(1).M(m).F().Info("Delete NetworkPolicy: %s", util.NamespaceNameString(m))
+		if err := w.c.kube.NetworkPolicy().Delete(ctx, m.GetNamespace(), m.GetName()); err != nil {
+			w.a.V(1).M(m).F().Error("FAILED to delete NetworkPolicy: %s, err: %v", util.NamespaceNameString(m), err)
+		}
+	}
+}
+
+func (w *worker) purgeIngress(
+	ctx context.Context,
+	cr api.ICustomResource,
+	reconcileFailedObjs *model.Registry,
+	m meta.Object,
+) {
+	if shouldPurgeIngress(cr, reconcileFailedObjs, m) {
+		w.a.V(1).M(m).F().Info("Delete Ingress: %s", util.NamespaceNameString(m))
+		if err := w.c.kube.Ingress().Delete(ctx, m.GetNamespace(), m.GetName()); err != nil {
+			w.a.V(1).M(m).F().Error("FAILED to delete Ingress: %s, err: %v", util.NamespaceNameString(m), err)
+		}
+	}
+}
+
+func (w *worker) purgeRoute(
+	ctx context.Context,
+	cr api.ICustomResource,
+	reconcileFailedObjs *model.Registry,
+	kind string,
+	m meta.Object,
+) {
+	if shouldPurgeRoute(cr, reconcileFailedObjs, kind, m) {
+		w.a.V(1).M(m).F().Info("Delete %s: %s", kind, util.NamespaceNameString(m))
+		if err := w.c.kube.Route().Delete(ctx, kind, m.GetNamespace(), m.GetName()); err != nil {
+			w.a.V(1).M(m).F().Error("FAILED to delete %s: %s, err: %v", kind, util.NamespaceNameString(m), err)
+		}
+	}
+}
+
 func shouldPurgeStatefulSet(cr api.ICustomResource, reconcileFailedObjs *model.Registry, m meta.Object) bool {
 	if reconcileFailedObjs.HasStatefulSet(m) {
 		return cr.GetReconciling().GetCleanup().GetReconcileFailedObjects().GetStatefulSet() == api.ObjectsCleanupDelete
@@ -243,6 +300,34 @@ func shouldPurgePDB(cr api.ICustomResource, reconcileFailedObjs *model.Registry,
 	return true
 }
 
+func shouldPurgeNetworkPolicy(cr api.ICustomResource, reconcileFailedObjs *model.Registry, m meta.Object) bool {
+	if reconcileFailedObjs.HasNetworkPolicy(m) {
+		return cr.GetReconciling().GetCleanup().GetReconcileFailedObjects().GetNetworkPolicy() == api.ObjectsCleanupDelete
+	}
+	return cr.GetReconciling().GetCleanup().GetUnknownObjects().GetNetworkPolicy() == api.ObjectsCleanupDelete
+}
+
+func shouldPurgeIngress(cr api.ICustomResource, reconcileFailedObjs *model.Registry, m meta.Object) bool {
+	if reconcileFailedObjs.HasIngress(m) {
+		return cr.GetReconciling().GetCleanup().GetReconcileFailedObjects().GetIngress() == api.ObjectsCleanupDelete
+	}
+	return cr.GetReconciling().GetCleanup().GetUnknownObjects().GetIngress() == api.ObjectsCleanupDelete
+}
+
+func shouldPurgeRoute(cr api.ICustomResource, reconcileFailedObjs *model.Registry, kind string, m meta.Object) bool {
+	failed := false
+	switch kind {
+	case api.RouteKindTLSRoute:
+		failed = reconcileFailedObjs.HasTLSRoute(m)
+	default:
+		failed = reconcileFailedObjs.HasHTTPRoute(m)
+	}
+	if failed {
+		return cr.GetReconciling().GetCleanup().GetReconcileFailedObjects().GetRoute() == api.ObjectsCleanupDelete
+	}
+	return cr.GetReconciling().GetCleanup().GetUnknownObjects().GetRoute() == api.ObjectsCleanupDelete
+}
+
 // discoveryAndDeleteCR deletes all kubernetes resources related to chi *chop.ClickHouseInstallation
 func (w *worker) discoveryAndDeleteCR(ctx context.Context, cr api.ICustomResource) error {
 	if util.IsContextDone(ctx) {
@@ -347,7 +432,7 @@ func (w *worker) canDropReplica(ctx context.Context, host *api.Host, opts ...*dr
 		// Replica's state has to be kept in Zookeeper for retained volumes.
 		// ClickHouse expects to have state of the non-empty replica in-place when replica rejoins.
 		if chiLabeler.New(nil).GetReclaimPolicy(pvc.GetObjectMeta()) == api.PVCReclaimPolicyRetain {
-			w.a.V(1).F().Info("PVC: %s/%s blocks drop replica. Reclaim policy: %s", api.PVCReclaimPolicyRetain.String())
+			w.a.V(1).F().Info("PVC: %s/%s blocks drop replica. Reclaim policy: %s", pvc.GetNamespace(), pvc.GetName(), api.PVCReclaimPolicyRetain.String())
 			can = false
 		}
 	})
@@ -393,6 +478,12 @@ func (w *worker) dropReplica(ctx context.Context, hostToDrop *api.Host, opts ...
 		return nil
 	}
 
+	if hostToDrop.IsExternal() && !NewDropReplicaOptionsArr(opts...).First().ForceDrop() {
+		// External host is not managed by the operator, its replica is dropped on explicit request only
+		w.a.V(1).M(hostToDrop).F().Info("Skip drop replica of external host: %s", hostToDrop.GetExternalHostname())
+		return nil
+	}
+
 	if !w.canDropReplica(ctx, hostToDrop, opts...) {
 		w.a.V(1).F().Warning("CAN NOT drop replica. hostToDrop: %s", hostToDrop.GetName())
 		return nil
@@ -467,6 +558,12 @@ func (w *worker) deleteHost(ctx context.Context, chi *api.ClickHouseInstallation
 	w.a.V(2).M(host).S().Info(host.Runtime.Address.HostName)
 	defer w.a.V(2).M(host).E().Info(host.Runtime.Address.HostName)
 
+	if host.IsExternal() {
+		// External host is not managed by the operator, there is nothing to delete
+		w.a.V(1).M(host).F().Info("Skip external host: %s", host.GetExternalHostname())
+		return nil
+	}
+
 	w.a.V(1).
 		WithEvent(host.GetCR(), common.EventActionDelete, common.EventReasonDeleteStarted).
 		WithStatusAction(host.GetCR()).
@@ -171,6 +171,17 @@ func (w *worker) excludeHostFromClickHouseCluster(ctx context.Context, host *api
 		Info("going to exclude host. Host/shard/cluster: %d/%d/%s",
 			host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
 
+	if host.GetCluster().GetDiscovery().IsEnabled() {
+		// Host of the discovery cluster is not listed in remote_servers, so it can not be excluded in advance.
+		// Host unregisters itself on graceful shutdown only, in case it is killed it stays registered
+		// until its ZooKeeper/Keeper session expires. Explicit wait for exclusion is rejected by the normalizer
+		w.a.V(1).
+			M(host).F().
+			Info("Discovery cluster, host can not be excluded and would unregister itself on shutdown. Host/shard/cluster: %d/%d/%s",
+				host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
+		return
+	}
+
 	// Specify in options to exclude this host from ClickHouse config file
 	host.GetCR().GetRuntime().LockCommonConfig()
 	host.GetReconcileAttributes().SetExclude()
@@ -196,11 +207,14 @@ func (w *worker) includeHostIntoClickHouseCluster(ctx context.Context, host *api
 		Info("going to include host. Host/shard/cluster: %d/%d/%s",
 			host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
 
-	// Specify in options to add this host into ClickHouse config file
-	host.GetCR().GetRuntime().LockCommonConfig()
-	host.GetReconcileAttributes().UnsetExclude()
-	_ = w.reconcileConfigMapCommon(ctx, host.GetCR(), w.options())
-	host.GetCR().GetRuntime().UnlockCommonConfig()
+	if !host.GetCluster().GetDiscovery().IsEnabled() {
+		// Specify in options to add this host into ClickHouse config file.
+		// Host of the discovery cluster registers itself in the cluster, no need to rewrite remote_servers
+		host.GetCR().GetRuntime().LockCommonConfig()
+		host.GetReconcileAttributes().UnsetExclude()
+		_ = w.reconcileConfigMapCommon(ctx, host.GetCR(), w.options())
+		host.GetCR().GetRuntime().UnlockCommonConfig()
+	}
 
 	if !w.shouldWaitIncludeHost(host) {
 		return
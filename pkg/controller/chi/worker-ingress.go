@@ -0,0 +1,128 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+
+	networking "k8s.io/api/networking/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// reconcileIngressAndRoute reconciles Ingress and Gateway API route which expose specified scope.
+// Both are optional and are created only in case the corresponding template is specified.
+func (w *worker) reconcileIngressAndRoute(ctx context.Context, what interfaces.IngressType, params ...any) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	var res error
+
+	if ingress := w.task.Creator().CreateIngress(what, params...); ingress != nil {
+		if err := w.reconcileIngress(ctx, ingress); err == nil {
+			w.task.RegistryReconciled().RegisterIngress(ingress.GetObjectMeta())
+		} else {
+			w.task.RegistryFailed().RegisterIngress(ingress.GetObjectMeta())
+			res = err
+		}
+	}
+
+	if route := w.task.Creator().CreateRoute(what, params...); route != nil {
+		if err := w.reconcileRoute(ctx, route); err == nil {
+			registerRoute(w.task.RegistryReconciled(), route)
+		} else {
+			registerRoute(w.task.RegistryFailed(), route)
+			res = err
+		}
+	}
+
+	return res
+}
+
+// registerRoute registers route in the registry according to the route's kind
+func registerRoute(registry *model.Registry, route *unstructured.Unstructured) {
+	switch route.GetKind() {
+	case api.RouteKindTLSRoute:
+		registry.RegisterTLSRoute(route)
+	default:
+		registry.RegisterHTTPRoute(route)
+	}
+}
+
+// reconcileIngress reconciles Ingress
+func (w *worker) reconcileIngress(ctx context.Context, ingress *networking.Ingress) error {
+	cur, err := w.c.getIngress(ctx, ingress)
+	switch {
+	case err == nil:
+		ingress.ResourceVersion = cur.ResourceVersion
+		err := w.c.updateIngress(ctx, ingress)
+		if err == nil {
+			log.V(1).Info("Ingress updated: %s", util.NamespaceNameString(ingress))
+		} else {
+			log.Error("FAILED to update Ingress: %s err: %v", util.NamespaceNameString(ingress), err)
+			return err
+		}
+	case apiErrors.IsNotFound(err):
+		err := w.c.createIngress(ctx, ingress)
+		if err == nil {
+			log.V(1).Info("Ingress created: %s", util.NamespaceNameString(ingress))
+		} else {
+			log.Error("FAILED create Ingress: %s err: %v", util.NamespaceNameString(ingress), err)
+			return err
+		}
+	default:
+		log.Error("FAILED get Ingress: %s err: %v", util.NamespaceNameString(ingress), err)
+		return err
+	}
+
+	return nil
+}
+
+// reconcileRoute reconciles Gateway API route
+func (w *worker) reconcileRoute(ctx context.Context, route *unstructured.Unstructured) error {
+	cur, err := w.c.getRoute(ctx, route)
+	switch {
+	case err == nil:
+		route.SetResourceVersion(cur.GetResourceVersion())
+		err := w.c.updateRoute(ctx, route)
+		if err == nil {
+			log.V(1).Info("%s updated: %s", route.GetKind(), util.NamespaceNameString(route))
+		} else {
+			log.Error("FAILED to update %s: %s err: %v", route.GetKind(), util.NamespaceNameString(route), err)
+			return err
+		}
+	case apiErrors.IsNotFound(err):
+		err := w.c.createRoute(ctx, route)
+		if err == nil {
+			log.V(1).Info("%s created: %s", route.GetKind(), util.NamespaceNameString(route))
+		} else {
+			log.Error("FAILED create %s: %s err: %v", route.GetKind(), util.NamespaceNameString(route), err)
+			return err
+		}
+	default:
+		log.Error("FAILED get %s: %s err: %v", route.GetKind(), util.NamespaceNameString(route), err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,541 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+const (
+	// keeperMigrationTargetCluster specifies name of the cluster of the CHK created by keeper migration
+	keeperMigrationTargetCluster = "keeper"
+	// keeperMigrationTargetStorage specifies size of the data volume of the CHK created by keeper migration
+	keeperMigrationTargetStorage = "10Gi"
+)
+
+// keeperMigrationSource specifies zookeeper metadata tree being migrated, which may be shared by several clusters
+type keeperMigrationSource struct {
+	nodes  api.ZookeeperNodes
+	root   string
+	params *zookeeper.ConnectionParams
+}
+
+// migrateKeeperCR advances keeper migration of the CR by one step.
+// Each step ends with either status update or CR reconcile, so progress is always visible in the status.
+func (w *worker) migrateKeeperCR(ctx context.Context, cr *api.ClickHouseInstallation) {
+	switch {
+	case !cr.GetSpecT().GetKeeperMigration().HasTarget():
+		return
+	case cr.IsStopped():
+		return
+	case cr.EnsureStatus().GetStatus() != api.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return
+	}
+
+	migration := cr.GetSpecT().GetKeeperMigration()
+	status := cr.EnsureStatus().GetKeeperMigration().DeepCopy()
+	if status == nil {
+		status = &api.KeeperMigrationStatus{}
+	}
+	status.Target = util.NamespaceNameString(w.getKeeperMigrationTargetMeta(cr))
+
+	if status.GetPhase() == api.KeeperMigrationPhaseFinalized {
+		// Nothing to do anymore
+		return
+	}
+
+	switch migration.GetAction() {
+	case api.KeeperMigrationActionRollback:
+		w.rollbackKeeperMigration(ctx, cr, status)
+	case api.KeeperMigrationActionFinalize:
+		w.finalizeKeeperMigration(ctx, cr, status)
+	default:
+		w.runKeeperMigration(ctx, cr, status)
+	}
+}
+
+// runKeeperMigration advances migration towards all hosts switched to the target
+func (w *worker) runKeeperMigration(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	switch status.GetPhase() {
+	case "", api.KeeperMigrationPhaseCreatingTarget, api.KeeperMigrationPhaseRolledBack:
+		w.ensureKeeperMigrationTarget(ctx, cr, status)
+	case api.KeeperMigrationPhaseFreezing:
+		w.freezeKeeperMigrationSources(ctx, cr, status)
+	case api.KeeperMigrationPhaseCopyingMetadata:
+		w.copyKeeperMigrationMetadata(ctx, cr, status)
+	case api.KeeperMigrationPhaseSwitchingHosts:
+		w.verifyKeeperMigrationHosts(ctx, cr, status)
+	}
+}
+
+// ensureKeeperMigrationTarget creates target CHK in case it does not exist and waits for its quorum
+func (w *worker) ensureKeeperMigrationTarget(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	status.Phase = api.KeeperMigrationPhaseCreatingTarget
+	target := w.getKeeperMigrationTargetMeta(cr)
+
+	chk, err := w.c.kube.Keeper().Get(ctx, target.GetNamespace(), target.GetName())
+	if apiErrors.IsNotFound(err) {
+		w.a.V(1).
+			WithEvent(cr, common.EventActionCreate, common.EventReasonCreateStarted).
+			M(cr).F().
+			Info("Keeper migration: create target CHK: %s", status.Target)
+		_, err = w.c.kube.Keeper().Create(ctx, w.newKeeperMigrationTarget(cr))
+		if err != nil {
+			status.Message = fmt.Sprintf("unable to create target: %v", err)
+		} else {
+			status.Message = "target created, wait for quorum"
+		}
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+	if err != nil {
+		status.Message = fmt.Sprintf("unable to get target: %v", err)
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	nodes := chk.GetZookeeperNodes()
+	if !chk.EnsureStatus().GetKeeper().IsQuorumHealthy() || (len(nodes) == 0) {
+		status.Message = "wait for target quorum"
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	status.Nodes = nodes
+	status.Phase = api.KeeperMigrationPhaseFreezing
+	status.Message = "metadata is read-only from now on"
+	_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+}
+
+// freezeKeeperMigrationSources makes metadata trees read-only, so they do not change while copied and hosts are switched
+func (w *worker) freezeKeeperMigrationSources(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	err := w.walkKeeperMigrationSources(ctx, cr, func(source *keeperMigrationSource) error {
+		from := zookeeper.NewConnection(source.nodes, source.params)
+		defer from.Close()
+		w.a.V(1).M(cr).F().Info("Keeper migration: freeze metadata. Root: %s", source.root)
+		return w.freezeKeeperMigrationTree(ctx, cr, status, from, source.root)
+	})
+	if err != nil {
+		status.Message = fmt.Sprintf("unable to freeze metadata: %v", err)
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	status.Phase = api.KeeperMigrationPhaseCopyingMetadata
+	status.Message = ""
+	_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+}
+
+// copyKeeperMigrationMetadata copies frozen metadata trees to the target and switches all hosts to the target at once
+func (w *worker) copyKeeperMigrationMetadata(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	copied := 0
+	err := w.walkKeeperMigrationSources(ctx, cr, func(source *keeperMigrationSource) error {
+		from := zookeeper.NewConnection(source.nodes, source.params)
+		defer from.Close()
+		to := zookeeper.NewConnection(status.Nodes, source.params)
+		defer to.Close()
+
+		// Target may be left frozen by the previous rollback
+		if _, err := zookeeper.NewACLManager(to).Thaw(ctx, source.root, status.FrozenACLs); err != nil {
+			return err
+		}
+		w.a.V(1).M(cr).F().Info("Keeper migration: copy metadata. Root: %s", source.root)
+		n, err := zookeeper.NewTreeCopier(from, to, status.FrozenACLs).Copy(ctx, source.root)
+		copied += n
+		return err
+	})
+	status.NodesCopied = copied
+	if err != nil {
+		status.Message = fmt.Sprintf("unable to copy metadata: %v", err)
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	hosts, err := w.getKeeperMigrationHosts(cr)
+	if err != nil {
+		status.Message = err.Error()
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	// All hosts are switched in one step, since metadata stays frozen on the source until then
+	status.Phase = api.KeeperMigrationPhaseSwitchingHosts
+	status.HostsSwitched = hosts
+	status.HostsVerified = nil
+	status.Message = "switch all hosts"
+	if err := w.updateKeeperMigrationStatus(ctx, cr, status); err != nil {
+		return
+	}
+	w.c.enqueueReconcileForce(cr, "keeper migration: switch all hosts to the target")
+}
+
+// verifyKeeperMigrationHosts verifies switched hosts read system.zookeeper, hosts are reconciled already, since CR is completed
+func (w *worker) verifyKeeperMigrationHosts(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to normalize CR for keeper migration. err: %v", err)
+		return
+	}
+
+	var verifyErr error
+	normalized.WalkHosts(func(host *api.Host) error {
+		name := host.GetRuntime().GetAddress().GetStatefulSet()
+		if !status.IsHostSwitched(name) || status.IsHostVerified(name) {
+			return nil
+		}
+		if err := w.verifyKeeperMigrationHost(ctx, host); err != nil {
+			verifyErr = err
+			return nil
+		}
+		status.HostsVerified = append(status.HostsVerified, name)
+		return nil
+	})
+
+	if verifyErr != nil {
+		status.Message = verifyErr.Error()
+	} else {
+		status.Phase = api.KeeperMigrationPhaseSwitched
+		status.Message = "all hosts switched, finalize or rollback"
+	}
+	_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+}
+
+// verifyKeeperMigrationHost verifies switched host reads system.zookeeper
+func (w *worker) verifyKeeperMigrationHost(ctx context.Context, host *api.Host) error {
+	num, err := w.ensureClusterSchemer(host).HostZookeeperRootChildrenNum(ctx, host)
+	switch {
+	case err != nil:
+		return fmt.Errorf("unable to verify host: %s err: %v", host.GetName(), err)
+	case num == 0:
+		return fmt.Errorf("unable to verify host: %s no znodes are readable", host.GetName())
+	}
+	return nil
+}
+
+// rollbackKeeperMigration switches all hosts back to the zookeeper config of the CR.
+// In case hosts are switched already, target is frozen and metadata changed on the target is copied back first.
+// Target CHK is retained.
+func (w *worker) rollbackKeeperMigration(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	switch status.GetPhase() {
+	case "", api.KeeperMigrationPhaseRolledBack:
+		return
+	}
+
+	status.Phase = api.KeeperMigrationPhaseRollingBack
+	if len(status.HostsSwitched) > 0 {
+		if err := w.copyKeeperMigrationMetadataBack(ctx, cr, status); err != nil {
+			status.Message = fmt.Sprintf("unable to copy metadata back: %v", err)
+			_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+			return
+		}
+		status.HostsSwitched = nil
+		status.HostsVerified = nil
+		status.Message = "switch all hosts back"
+		if err := w.updateKeeperMigrationStatus(ctx, cr, status); err != nil {
+			return
+		}
+		w.c.enqueueReconcileForce(cr, "keeper migration: switch all hosts back")
+		return
+	}
+
+	// No host uses the target, source metadata is made writable again
+	err := w.walkKeeperMigrationSources(ctx, cr, func(source *keeperMigrationSource) error {
+		from := zookeeper.NewConnection(source.nodes, source.params)
+		defer from.Close()
+		_, err := zookeeper.NewACLManager(from).Thaw(ctx, source.root, status.FrozenACLs)
+		return err
+	})
+	if err != nil {
+		status.Message = fmt.Sprintf("unable to thaw metadata: %v", err)
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	status.Phase = api.KeeperMigrationPhaseRolledBack
+	status.Message = ""
+	_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+}
+
+// copyKeeperMigrationMetadataBack freezes metadata trees on the target and copies them back to the source
+func (w *worker) copyKeeperMigrationMetadataBack(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) error {
+	return w.walkKeeperMigrationSources(ctx, cr, func(source *keeperMigrationSource) error {
+		from := zookeeper.NewConnection(source.nodes, source.params)
+		defer from.Close()
+		to := zookeeper.NewConnection(status.Nodes, source.params)
+		defer to.Close()
+
+		w.a.V(1).M(cr).F().Info("Keeper migration: copy metadata back. Root: %s", source.root)
+		if err := w.freezeKeeperMigrationTree(ctx, cr, status, to, source.root); err != nil {
+			return err
+		}
+		if _, err := zookeeper.NewACLManager(from).Thaw(ctx, source.root, status.FrozenACLs); err != nil {
+			return err
+		}
+		_, err := zookeeper.NewTreeCopier(to, from, status.FrozenACLs).Copy(ctx, source.root)
+		return err
+	})
+}
+
+// freezeKeeperMigrationTree makes metadata tree read-only.
+// Original ACLs are stored in the status before the tree is frozen, thus they are never lost.
+func (w *worker) freezeKeeperMigrationTree(
+	ctx context.Context,
+	cr *api.ClickHouseInstallation,
+	status *api.KeeperMigrationStatus,
+	conn *zookeeper.Connection,
+	root string,
+) error {
+	manager := zookeeper.NewACLManager(conn)
+	frozen, err := manager.CollectFrozenACLs(ctx, root, status.FrozenACLs)
+	if err != nil {
+		return err
+	}
+	if len(frozen) > len(status.FrozenACLs) {
+		status.FrozenACLs = frozen
+		if err := w.updateKeeperMigrationStatus(ctx, cr, status); err != nil {
+			return err
+		}
+	}
+	_, err = manager.Freeze(ctx, root, status.FrozenACLs)
+	return err
+}
+
+// walkKeeperMigrationSources calls the function for each migrated metadata tree till the first error
+func (w *worker) walkKeeperMigrationSources(ctx context.Context, cr *api.ClickHouseInstallation, fn func(source *keeperMigrationSource) error) error {
+	sources, err := w.getKeeperMigrationSources(ctx, cr)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		if err := fn(source); err != nil {
+			return fmt.Errorf("root: %s err: %v", source.root, err)
+		}
+	}
+	return nil
+}
+
+// getKeeperMigrationSources gets metadata trees of the clusters being migrated.
+// Clusters may share the same zookeeper, each tree is listed once.
+func (w *worker) getKeeperMigrationSources(ctx context.Context, cr *api.ClickHouseInstallation) ([]*keeperMigrationSource, error) {
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		return nil, fmt.Errorf("unable to normalize CR: %v", err)
+	}
+
+	var sources []*keeperMigrationSource
+	listed := make(map[string]bool)
+	normalized.WalkClusters(func(cluster api.ICluster) error {
+		zk := cluster.GetZookeeper()
+		if (err != nil) || !isKeeperMigrationSource(zk) {
+			return nil
+		}
+		root := "/" + strings.Trim(strings.TrimSpace(zk.Root), "/")
+		if root == "/" {
+			// Whole ensemble would be frozen otherwise
+			err = fmt.Errorf("zookeeper root path is required for migration. Cluster: %s", cluster.GetName())
+			return nil
+		}
+		if listed[zk.Nodes.String()+root] {
+			return nil
+		}
+		listed[zk.Nodes.String()+root] = true
+
+		params := &zookeeper.ConnectionParams{}
+		if zk.HasIdentitySecret() {
+			var identity string
+			if identity, err = w.getZookeeperIdentity(ctx, cr.GetNamespace(), zk); err != nil {
+				return nil
+			}
+			params.Identities = []string{identity}
+		}
+		sources = append(sources, &keeperMigrationSource{
+			nodes:  zk.Nodes,
+			root:   root,
+			params: params,
+		})
+		return nil
+	})
+	return sources, err
+}
+
+// getKeeperMigrationHosts gets hosts, by StatefulSet name, which use zookeeper being migrated
+func (w *worker) getKeeperMigrationHosts(cr *api.ClickHouseInstallation) ([]string, error) {
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		return nil, fmt.Errorf("unable to normalize CR: %v", err)
+	}
+
+	var hosts []string
+	normalized.WalkHosts(func(host *api.Host) error {
+		if isKeeperMigrationSource(host.GetZookeeper()) && !host.IsExternal() {
+			hosts = append(hosts, host.GetRuntime().GetAddress().GetStatefulSet())
+		}
+		return nil
+	})
+	return hosts, nil
+}
+
+// finalizeKeeperMigration replaces zookeeper nodes of the CR with the reference to the target.
+// Rollback is not possible afterwards.
+func (w *worker) finalizeKeeperMigration(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) {
+	if status.GetPhase() != api.KeeperMigrationPhaseSwitched {
+		msg := "finalize requires all hosts to be switched"
+		if status.Message != msg {
+			status.Message = msg
+			_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		}
+		return
+	}
+
+	w.a.V(1).
+		WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateStarted).
+		M(cr).F().
+		Info("Keeper migration: finalize, refer to target CHK: %s", status.Target)
+
+	cur, err := w.c.chopClient.ClickhouseV1().ClickHouseInstallations(cr.GetNamespace()).Get(ctx, cr.GetName(), controller.NewGetOptions())
+	if err == nil {
+		ref := w.getKeeperMigrationTargetRef(cr)
+		if conf := cur.GetSpecT().Configuration; conf != nil {
+			conf.Zookeeper = finalizeKeeperMigrationZookeeper(conf.Zookeeper, ref)
+			for _, cluster := range conf.Clusters {
+				cluster.Zookeeper = finalizeKeeperMigrationZookeeper(cluster.Zookeeper, ref)
+			}
+		}
+		_, err = w.c.chopClient.ClickhouseV1().ClickHouseInstallations(cur.GetNamespace()).Update(ctx, cur, controller.NewUpdateOptions())
+	}
+	if err != nil {
+		w.a.WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateFailed).
+			M(cr).F().
+			Error("Keeper migration: finalize FAILED. err: %v", err)
+		status.Message = fmt.Sprintf("unable to finalize: %v", err)
+		_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+		return
+	}
+
+	status.Phase = api.KeeperMigrationPhaseFinalized
+	status.Message = ""
+	_ = w.updateKeeperMigrationStatus(ctx, cr, status)
+}
+
+// finalizeKeeperMigrationZookeeper replaces nodes of the zookeeper config with the reference to the target
+func finalizeKeeperMigrationZookeeper(zk *api.ZookeeperConfig, ref *api.ZookeeperKeeperRef) *api.ZookeeperConfig {
+	if !isKeeperMigrationSource(zk) {
+		return zk
+	}
+	zk.Nodes = nil
+	zk.KeeperRef = ref
+	return zk
+}
+
+// isKeeperMigrationSource checks whether zookeeper config is migrated, which is the case for explicitly specified nodes
+func isKeeperMigrationSource(zk *api.ZookeeperConfig) bool {
+	return !zk.IsEmpty() && !zk.HasKeeperRef()
+}
+
+// getKeeperMigrationTargetRef gets reference to the target CHK with namespace filled
+func (w *worker) getKeeperMigrationTargetRef(cr *api.ClickHouseInstallation) *api.ZookeeperKeeperRef {
+	ref := *cr.GetSpecT().GetKeeperMigration().GetTarget()
+	if ref.Namespace == "" {
+		ref.Namespace = cr.GetNamespace()
+	}
+	return &ref
+}
+
+// getKeeperMigrationTargetMeta gets meta of the target CHK
+func (w *worker) getKeeperMigrationTargetMeta(cr *api.ClickHouseInstallation) meta.Object {
+	ref := w.getKeeperMigrationTargetRef(cr)
+	return &meta.ObjectMeta{
+		Namespace: ref.GetNamespace(),
+		Name:      ref.GetName(),
+	}
+}
+
+// newKeeperMigrationTarget creates target CHK with one cluster and persistent data volume
+func (w *worker) newKeeperMigrationTarget(cr *api.ClickHouseInstallation) *apiChk.ClickHouseKeeperInstallation {
+	migration := cr.GetSpecT().GetKeeperMigration()
+	target := w.getKeeperMigrationTargetMeta(cr)
+	return &apiChk.ClickHouseKeeperInstallation{
+		TypeMeta: meta.TypeMeta{
+			APIVersion: apiChk.SchemeGroupVersion.String(),
+			Kind:       apiChk.ClickHouseKeeperInstallationCRDResourceKind,
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: target.GetNamespace(),
+			Name:      target.GetName(),
+		},
+		Spec: apiChk.ChkSpec{
+			Defaults: &api.Defaults{
+				Templates: &api.TemplatesList{
+					DataVolumeClaimTemplate: "default",
+				},
+			},
+			Configuration: &apiChk.Configuration{
+				Clusters: []*apiChk.Cluster{
+					{
+						Name: keeperMigrationTargetCluster,
+						Layout: &apiChk.ChkClusterLayout{
+							ReplicasCount: migration.GetReplicas(),
+						},
+					},
+				},
+			},
+			Templates: &api.Templates{
+				VolumeClaimTemplates: []api.VolumeClaimTemplate{
+					{
+						Name: "default",
+						Spec: core.PersistentVolumeClaimSpec{
+							AccessModes: []core.PersistentVolumeAccessMode{
+								core.ReadWriteOnce,
+							},
+							Resources: core.ResourceRequirements{
+								Requests: core.ResourceList{
+									core.ResourceStorage: resource.MustParse(keeperMigrationTargetStorage),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// updateKeeperMigrationStatus updates .status.keeperMigration of the CR
+func (w *worker) updateKeeperMigrationStatus(ctx context.Context, cr *api.ClickHouseInstallation, status *api.KeeperMigrationStatus) error {
+	cr.EnsureStatus().SetKeeperMigration(status)
+	return w.c.updateCRObjectStatus(ctx, cr, types.UpdateStatusOptions{
+		CopyStatusOptions: types.CopyStatusOptions{
+			KeeperMigration: true,
+		},
+		TolerateAbsence: true,
+	})
+}
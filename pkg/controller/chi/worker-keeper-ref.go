@@ -0,0 +1,107 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// waitKeeperRefQuorum waits for quorum of the ClickHouseKeeperInstallation referenced by the cluster to be healthy
+func (w *worker) waitKeeperRefQuorum(ctx context.Context, cluster *api.Cluster) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	if !cluster.Zookeeper.HasKeeperRef() {
+		return nil
+	}
+
+	ref := cluster.Zookeeper.GetKeeperRef()
+	namespace := ref.GetNamespace()
+	if namespace == "" {
+		namespace = cluster.GetRuntime().GetAddress().GetNamespace()
+	}
+
+	w.a.V(1).M(cluster).F().Info("wait for keeper quorum. CHK: %s/%s", namespace, ref.GetName())
+	return poller.New(ctx, fmt.Sprintf("%s/%s", namespace, ref.GetName())).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				chk, err := w.c.kube.Keeper().Get(_ctx, namespace, ref.GetName())
+				if err != nil {
+					w.a.V(1).M(cluster).F().Warning("unable to get CHK: %s/%s err: %v", namespace, ref.GetName(), err)
+					return false
+				}
+				return chk.EnsureStatus().GetKeeper().IsQuorumHealthy()
+			},
+		}).Poll()
+}
+
+// watchKeeperRef triggers reconcile of the CR in case nodes of CHKs referenced by the CR have changed
+func (w *worker) watchKeeperRef(ctx context.Context, cr *api.ClickHouseInstallation) {
+	if w.isKeeperRefChanged(ctx, cr) {
+		w.c.enqueueReconcileForce(cr, "keeper nodes changed, re-render zookeeper config")
+	}
+}
+
+// isKeeperRefChanged checks whether nodes of CHKs referenced by the CR differ from the ones CR was reconciled with
+func (w *worker) isKeeperRefChanged(ctx context.Context, cr *api.ClickHouseInstallation) bool {
+	switch {
+	case cr.IsStopped():
+		return false
+	case cr.EnsureStatus().GetStatus() != api.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return false
+	}
+
+	// Ancestor is what CR was reconciled with
+	reconciled := make(map[string]*api.ZookeeperConfig)
+	cr.GetAncestorT().WalkClusters(func(cluster api.ICluster) error {
+		if cluster.GetZookeeper().HasKeeperRef() {
+			reconciled[cluster.GetName()] = cluster.GetZookeeper()
+		}
+		return nil
+	})
+	if len(reconciled) == 0 {
+		return false
+	}
+
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to normalize CR for keeper refs. err: %v", err)
+		return false
+	}
+
+	changed := false
+	normalized.WalkClusters(func(cluster api.ICluster) error {
+		if zk, found := reconciled[cluster.GetName()]; found && cluster.GetZookeeper().HasKeeperRef() {
+			if !cluster.GetZookeeper().Equals(zk) {
+				w.a.V(1).M(cr).F().Info("keeper nodes changed. Cluster: %s", cluster.GetName())
+				changed = true
+			}
+		}
+		return nil
+	})
+	return changed
+}
@@ -0,0 +1,77 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+
+	networking "k8s.io/api/networking/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// reconcileCRNetworkPolicy reconciles NetworkPolicy of the CR, in case it is enabled
+func (w *worker) reconcileCRNetworkPolicy(ctx context.Context, cr *api.ClickHouseInstallation) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	policy := cr.GetSpecT().GetNetworkPolicy()
+	if !policy.IsEnabled() {
+		// NetworkPolicy is not registered as reconciled, thus it would be purged as unknown object
+		return nil
+	}
+
+	networkPolicy := w.task.Creator().CreateNetworkPolicy(policy)
+	if err := w.reconcileNetworkPolicy(ctx, networkPolicy); err != nil {
+		w.task.RegistryFailed().RegisterNetworkPolicy(networkPolicy.GetObjectMeta())
+		return err
+	}
+	w.task.RegistryReconciled().RegisterNetworkPolicy(networkPolicy.GetObjectMeta())
+	return nil
+}
+
+// reconcileNetworkPolicy reconciles NetworkPolicy
+func (w *worker) reconcileNetworkPolicy(ctx context.Context, networkPolicy *networking.NetworkPolicy) error {
+	cur, err := w.c.getNetworkPolicy(ctx, networkPolicy)
+	switch {
+	case err == nil:
+		networkPolicy.ResourceVersion = cur.ResourceVersion
+		err := w.c.updateNetworkPolicy(ctx, networkPolicy)
+		if err == nil {
+			log.V(1).Info("NetworkPolicy updated: %s", util.NamespaceNameString(networkPolicy))
+		} else {
+			log.Error("FAILED to update NetworkPolicy: %s err: %v", util.NamespaceNameString(networkPolicy), err)
+			return err
+		}
+	case apiErrors.IsNotFound(err):
+		err := w.c.createNetworkPolicy(ctx, networkPolicy)
+		if err == nil {
+			log.V(1).Info("NetworkPolicy created: %s", util.NamespaceNameString(networkPolicy))
+		} else {
+			log.Error("FAILED create NetworkPolicy: %s err: %v", util.NamespaceNameString(networkPolicy), err)
+			return err
+		}
+	default:
+		log.Error("FAILED get NetworkPolicy: %s err: %v", util.NamespaceNameString(networkPolicy), err)
+		return err
+	}
+
+	return nil
+}
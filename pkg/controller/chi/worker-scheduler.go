@@ -0,0 +1,200 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"time"
+	// Operator image may have no time zone database, while schedule time zones have to be resolved
+	_ "time/tzdata"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// getSchedule gets schedule of the CR. Schedule may be provided by the CR itself or by CR templates.
+func (w *worker) getSchedule(cr *api.ClickHouseInstallation) *api.ChiSchedule {
+	if cr.GetSpecT().GetSchedule().HasSchedule() {
+		return cr.GetSpecT().GetSchedule()
+	}
+	if normalized := cr.EnsureStatus().GetNormalizedCRCompleted(); normalized != nil {
+		return normalized.GetSpecT().GetSchedule()
+	}
+	return nil
+}
+
+// schedule stops or starts the CR according to its schedule
+func (w *worker) schedule(ctx context.Context, cr *api.ClickHouseInstallation) {
+	schedule := w.getSchedule(cr)
+	if !schedule.HasSchedule() {
+		if cr.EnsureStatus().GetSchedule() != nil {
+			// Schedule was removed
+			w.updateScheduleStatus(ctx, cr, nil)
+		}
+		return
+	}
+
+	status, stop, err := w.evaluateSchedule(cr, schedule, time.Now())
+	if err != nil {
+		w.a.V(1).M(cr).F().Warning("unable to evaluate schedule. err: %v", err)
+		return
+	}
+
+	if stop != cr.IsStopped() {
+		w.a.V(1).
+			WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateStarted).
+			M(cr).F().
+			Info("Scheduled stop: %t", stop)
+		if err := w.setStop(ctx, cr, stop); err != nil {
+			w.a.WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateFailed).
+				M(cr).F().
+				Error("Scheduled stop: %t FAILED. err: %v", stop, err)
+			return
+		}
+	}
+
+	if !status.Equal(cr.EnsureStatus().GetSchedule()) {
+		w.updateScheduleStatus(ctx, cr, status)
+	}
+}
+
+// evaluateSchedule evaluates schedule at specified moment and provides schedule status along with
+// whether CR has to be stopped
+func (w *worker) evaluateSchedule(
+	cr *api.ClickHouseInstallation,
+	schedule *api.ChiSchedule,
+	now time.Time,
+) (*api.ScheduleStatus, bool, error) {
+	loc, err := schedule.GetLocation()
+	if err != nil {
+		return nil, false, err
+	}
+	now = now.In(loc)
+
+	var stopCron, startCron *util.CronSchedule
+	if schedule.GetStop() != "" {
+		if stopCron, err = util.ParseCron(schedule.GetStop()); err != nil {
+			return nil, false, err
+		}
+	}
+	if schedule.GetStart() != "" {
+		if startCron, err = util.ParseCron(schedule.GetStart()); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// scheduledStop checks whether CR is stopped by the schedule at the specified moment
+	scheduledStop := func(t time.Time) bool {
+		lastStop, lastStart := cronPrev(stopCron, t), cronPrev(startCron, t)
+		if lastStop.IsZero() && lastStart.IsZero() {
+			// No transitions happened yet, keep current state
+			return cr.IsStopped()
+		}
+		return lastStop.After(lastStart)
+	}
+
+	status := &api.ScheduleStatus{}
+	stop := scheduledStop(now)
+
+	if keepRunningUntil, ok := w.getKeepRunningUntil(cr); ok && keepRunningUntil.After(now) {
+		// CR is kept running by the override, the next transition is the first stop after the override expires
+		stop = false
+		status.KeepRunningUntil = keepRunningUntil.In(loc).Format(time.RFC3339)
+		status.NextTransition = api.ScheduleTransitionStop
+		if scheduledStop(keepRunningUntil) {
+			status.NextTransitionTime = status.KeepRunningUntil
+		} else {
+			status.NextTransitionTime = formatCronTime(cronNext(stopCron, keepRunningUntil.In(loc)))
+		}
+		return status, stop, nil
+	}
+
+	if stop {
+		status.NextTransition = api.ScheduleTransitionStart
+		status.NextTransitionTime = formatCronTime(cronNext(startCron, now))
+	} else {
+		status.NextTransition = api.ScheduleTransitionStop
+		status.NextTransitionTime = formatCronTime(cronNext(stopCron, now))
+	}
+	if status.NextTransitionTime == "" {
+		// Nothing is going to happen
+		status.NextTransition = ""
+	}
+
+	return status, stop, nil
+}
+
+// getKeepRunningUntil gets time till which CR is kept running by the override annotation
+func (w *worker) getKeepRunningUntil(cr *api.ClickHouseInstallation) (time.Time, bool) {
+	value, ok := cr.GetAnnotations()[api.AnnotationScheduleKeepRunningUntil]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		w.a.V(1).M(cr).F().Warning("unable to parse annotation %s: %s err: %v", api.AnnotationScheduleKeepRunningUntil, value, err)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// setStop sets .spec.stop of the CR
+func (w *worker) setStop(ctx context.Context, cr *api.ClickHouseInstallation, stop bool) error {
+	cur, err := w.c.chopClient.ClickhouseV1().ClickHouseInstallations(cr.GetNamespace()).Get(ctx, cr.GetName(), controller.NewGetOptions())
+	if err != nil {
+		return err
+	}
+	cur.GetSpecT().Stop = types.NewStringBool(stop)
+	_, err = w.c.chopClient.ClickhouseV1().ClickHouseInstallations(cur.GetNamespace()).Update(ctx, cur, controller.NewUpdateOptions())
+	return err
+}
+
+// updateScheduleStatus updates .status.schedule of the CR
+func (w *worker) updateScheduleStatus(ctx context.Context, cr *api.ClickHouseInstallation, status *api.ScheduleStatus) {
+	cr.EnsureStatus().SetSchedule(status)
+	_ = w.c.updateCRObjectStatus(ctx, cr, types.UpdateStatusOptions{
+		CopyStatusOptions: types.CopyStatusOptions{
+			Schedule: true,
+		},
+		TolerateAbsence: true,
+	})
+}
+
+// cronPrev is a nil-safe wrapper for the previous activation of a cron schedule
+func cronPrev(cron *util.CronSchedule, t time.Time) time.Time {
+	if cron == nil {
+		return time.Time{}
+	}
+	return cron.Prev(t)
+}
+
+// cronNext is a nil-safe wrapper for the next activation of a cron schedule
+func cronNext(cron *util.CronSchedule, t time.Time) time.Time {
+	if cron == nil {
+		return time.Time{}
+	}
+	return cron.Next(t)
+}
+
+// formatCronTime formats activation time of a cron schedule, zero time is formatted as empty string
+func formatCronTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
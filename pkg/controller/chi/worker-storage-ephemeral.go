@@ -0,0 +1,327 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller/domain"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model"
+	"github.com/altinity/clickhouse-operator/pkg/model/common/action_plan"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// shouldReplicateEphemeralHost determines whether host with ephemeral storage has to be re-populated
+// from other replicas of the shard
+func (w *worker) shouldReplicateEphemeralHost(host *api.Host) bool {
+	switch {
+	case !host.IsStorageEphemeral():
+		return false
+	case host.IsStopped():
+		// Stopped host is not able to receive any data
+		return false
+	case host.IsInNewCluster():
+		// CHI is new, all hosts were added, there is no data to replicate
+		return false
+	case host.GetShard().HostsCount() == 1:
+		// No other replicas to replicate data from
+		return false
+	case w.isEphemeralStorageLost(host):
+		// Host gets a new pod with an empty local storage
+		return true
+	}
+
+	// Storage stays in place, replicate in case host has not caught up with other replicas yet
+	return !host.HasData()
+}
+
+// isEphemeralStorageLost determines whether host with ephemeral storage is going to get an empty storage
+func (w *worker) isEphemeralStorageLost(host *api.Host) bool {
+	switch {
+	case w.shouldForceRestartHost(host):
+		// Pod is going to be re-created
+		return true
+	case host.GetReconcileAttributes().GetStatus() == api.ObjectStatusSame:
+		// Pod is not going to be touched
+		return false
+	}
+
+	// New or modified host gets a new pod
+	return true
+}
+
+// prepareEphemeralHost prepares host with ephemeral storage for replication and provides tables migration options
+func (w *worker) prepareEphemeralHost(host *api.Host) *migrateTableOptions {
+	w.a.V(1).
+		M(host).F().
+		Info("Host has ephemeral storage, need to replicate. Host/shard/cluster: %d/%d/%s",
+			host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
+
+	storageLost := w.isEphemeralStorageLost(host)
+	if storageLost {
+		host.GetCR().(*api.ClickHouseInstallation).EnsureStatus().RemoveHostTablesCreated(w.c.namer.Name(interfaces.NameFQDN, host))
+		host.SetHasData(false)
+	}
+
+	return &migrateTableOptions{
+		forceMigrate: true,
+		// Replica of a modified host is still registered in ZooKeeper, while its local storage is empty
+		dropReplica: storageLost && (host.GetReconcileAttributes().GetStatus() != api.ObjectStatusNew),
+	}
+}
+
+// syncEphemeralHost waits for host with ephemeral storage to catch up with other replicas of the shard
+func (w *worker) syncEphemeralHost(ctx context.Context, host *api.Host) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	w.a.V(1).
+		WithEvent(host.GetCR(), common.EventActionReconcile, common.EventReasonReconcileInProgress).
+		WithStatusAction(host.GetCR()).
+		M(host).F().
+		Info("Sync replicated tables on host with ephemeral storage. Host/shard/cluster: %d/%d/%s",
+			host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
+
+	fqdn := w.c.namer.Name(interfaces.NameFQDN, host)
+	// Tables created does not mean data fetched, host holds no data until it catches up with other replicas
+	host.GetCR().(*api.ClickHouseInstallation).EnsureStatus().RemoveHostTablesCreated(fqdn)
+
+	if err := w.ensureClusterSchemer(host).HostSyncTables(ctx, host); err != nil {
+		w.a.V(1).M(host).F().Warning("Unable to sync tables on host: %s err: %v", host.GetName(), err)
+	}
+	if err := w.waitHostCaughtUp(ctx, host); err != nil {
+		w.a.WithEvent(host.GetCR(), common.EventActionReconcile, common.EventReasonReconcileFailed).
+			WithStatusError(host.GetCR()).
+			M(host).F().
+			Error("Replicas of the host: %s have not caught up with other replicas err: %v", host.GetName(), err)
+		return err
+	}
+
+	host.GetCR().IEnsureStatus().PushHostTablesCreated(fqdn)
+	host.SetHasData(true)
+
+	w.a.V(1).
+		WithEvent(host.GetCR(), common.EventActionReconcile, common.EventReasonReconcileInProgress).
+		WithStatusAction(host.GetCR()).
+		M(host).F().
+		Info("Host with ephemeral storage is in sync. Host/shard/cluster: %d/%d/%s",
+			host.Runtime.Address.ReplicaIndex, host.Runtime.Address.ShardIndex, host.Runtime.Address.ClusterName)
+	return nil
+}
+
+// waitHostCaughtUp waits for replicated tables of the host to catch up with other replicas,
+// that is for replication lag of the host to fit into thresholds specified by the operator config
+func (w *worker) waitHostCaughtUp(ctx context.Context, host *api.Host) error {
+	return domain.PollHost(ctx, host, func(ctx context.Context, host *api.Host) bool {
+		delay, err := w.ensureClusterSchemer(host).HostReplicasMaxDelay(ctx, host)
+		if err != nil {
+			return false
+		}
+		queueSize := 0
+		if chop.Config().Reconcile.Host.Wait.Replicas.QueueSize.HasValue() {
+			if queueSize, err = w.ensureClusterSchemer(host).HostReplicasMaxQueueSize(ctx, host); err != nil {
+				return false
+			}
+		}
+		return isReplicationLagAcceptable(delay, queueSize, chop.Config().Reconcile.Host.Wait.Replicas)
+	})
+}
+
+// isReplicationLagAcceptable checks whether replication delay and queue size fit into specified thresholds.
+// Queue size is not checked in case its threshold is not specified
+func isReplicationLagAcceptable(delay, queueSize int, replicas api.OperatorConfigReconcileHostWaitReplicas) bool {
+	if delay > replicas.Delay.IntValue() {
+		return false
+	}
+	if replicas.QueueSize.HasValue() && (queueSize > replicas.QueueSize.IntValue()) {
+		return false
+	}
+	return true
+}
+
+// hostHoldsData checks whether host is known to hold data.
+// Host with persistent storage is considered to hold data always,
+// host with ephemeral storage holds data only after it is in sync with other replicas.
+func hostHoldsData(cr api.ICustomResource, host *api.Host, fqdn string) bool {
+	if !host.IsStorageEphemeral() {
+		return true
+	}
+	return util.InArray(fqdn, cr.IEnsureStatus().GetHostsWithTablesCreated())
+}
+
+// isLastReplicaWithData checks whether removed host is the last replica in the shard which holds data
+func (w *worker) isLastReplicaWithData(cr *api.ClickHouseInstallation, removedHost *api.Host) bool {
+	if !hostHoldsData(cr, removedHost, w.c.namer.Name(interfaces.NameFQDN, removedHost)) {
+		// Removed host has no data to lose
+		return false
+	}
+
+	shard, _ := cr.FindShard(removedHost.Runtime.Address.ClusterName, removedHost.Runtime.Address.ShardName).(*api.ChiShard)
+	if shard == nil {
+		// The whole shard is removed, this is what was explicitly requested
+		return false
+	}
+
+	return !w.shardHoldsData(cr, shard)
+}
+
+// shardHoldsData checks whether any host of the shard holds data
+func (w *worker) shardHoldsData(cr *api.ClickHouseInstallation, shard *api.ChiShard) bool {
+	holdsData := false
+	shard.WalkHosts(func(host *api.Host) error {
+		if hostHoldsData(cr, host, w.c.namer.Name(interfaces.NameFQDN, host)) {
+			holdsData = true
+		}
+		return nil
+	})
+	return holdsData
+}
+
+// keepLastReplicasWithData prevents removal of replicas, which are the last ones holding data in the shard.
+// Kept hosts are persisted in status, thus they are kept over consequent reconciles till any other replica
+// of the shard holds data. Objects of the kept hosts are not purged and replicas of them are not dropped meanwhile.
+func (w *worker) keepLastReplicasWithData(ctx context.Context, cr *api.ClickHouseInstallation, ap *action_plan.ActionPlan) {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return
+	}
+
+	w.releaseKeptHosts(ctx, cr)
+
+	ap.WalkRemoved(
+		func(cluster api.ICluster) {
+		},
+		func(shard api.IShard) {
+		},
+		func(host *api.Host) {
+			if !w.isLastReplicaWithData(cr, host) {
+				return
+			}
+
+			w.a.V(1).
+				WithEvent(cr, common.EventActionDelete, common.EventReasonDeleteFailed).
+				WithStatusError(cr).
+				M(host).F().
+				Warning("Refuse to remove host: %s - it is the last replica holding data in shard: %s. "+
+					"Remaining replicas have ephemeral storage and are not in sync yet",
+					host.GetName(), host.Runtime.Address.ShardName)
+
+			cr.EnsureStatus().PushHostKept(&api.HostKeptStatus{
+				Host:     host.GetName(),
+				Cluster:  host.Runtime.Address.ClusterName,
+				Shard:    host.Runtime.Address.ShardName,
+				Replica:  w.c.namer.Name(interfaces.NameInstanceHostname, host),
+				Selector: getLabeler(cr).Selector(interfaces.SelectorHostScope, host),
+			})
+		},
+	)
+
+	_ = w.c.updateCRObjectStatus(ctx, cr, types.UpdateStatusOptions{
+		CopyStatusOptions: types.CopyStatusOptions{
+			HostsKept: true,
+		},
+	})
+}
+
+// releaseKeptHosts releases kept hosts, which are not the last replicas holding data in their shards anymore.
+// Replicas of the released hosts are dropped, objects of them are purged by the clean step of the reconcile.
+func (w *worker) releaseKeptHosts(ctx context.Context, cr *api.ClickHouseInstallation) {
+	for _, kept := range cr.EnsureStatus().GetHostsKept() {
+		shard, _ := cr.FindShard(kept.Cluster, kept.Shard).(*api.ChiShard)
+		switch {
+		case shard == nil:
+			// The whole shard is removed, this is what was explicitly requested
+			w.a.V(1).M(cr).F().Info("Release kept host: %s - shard: %s is removed", kept.Host, kept.Shard)
+		case shardHasHost(shard, kept.Host):
+			// Host is added back, it is reconciled as a regular host
+			w.a.V(1).M(cr).F().Info("Release kept host: %s - host is added back", kept.Host)
+		case w.shardHoldsData(cr, shard):
+			w.a.V(1).M(cr).F().Info("Release kept host: %s - other replicas of shard: %s hold data", kept.Host, kept.Shard)
+			w.dropKeptReplica(ctx, shard, kept)
+		default:
+			w.a.V(1).M(cr).F().Info("Keep host: %s - it is still the last replica holding data in shard: %s", kept.Host, kept.Shard)
+			continue
+		}
+		cr.EnsureStatus().RemoveHostKept(kept.Cluster, kept.Host)
+	}
+}
+
+// shardHasHost checks whether shard has host with specified name
+func shardHasHost(shard *api.ChiShard, name string) bool {
+	found := false
+	shard.WalkHosts(func(host *api.Host) error {
+		if host.GetName() == name {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// dropKeptReplica drops replica of the released kept host
+func (w *worker) dropKeptReplica(ctx context.Context, shard *api.ChiShard, kept *api.HostKeptStatus) {
+	hostToRunOn := shard.FirstHost()
+	if hostToRunOn == nil {
+		return
+	}
+	if err := w.ensureClusterSchemer(hostToRunOn).HostDropReplicaByName(ctx, hostToRunOn, kept.Replica); err != nil {
+		w.a.WithEvent(hostToRunOn.GetCR(), common.EventActionDelete, common.EventReasonDeleteFailed).
+			WithStatusError(hostToRunOn.GetCR()).
+			M(hostToRunOn).F().
+			Error("FAILED to drop replica of the released host: %s with error: %v", kept.Host, err)
+	}
+}
+
+// isHostKept checks whether host is kept as the last replica holding data
+func isHostKept(cr api.ICustomResource, host *api.Host) bool {
+	chi, ok := cr.(*api.ClickHouseInstallation)
+	if !ok {
+		return false
+	}
+	for _, kept := range chi.EnsureStatus().GetHostsKept() {
+		if (kept.Cluster == host.Runtime.Address.ClusterName) && (kept.Host == host.GetName()) {
+			return true
+		}
+	}
+	return false
+}
+
+// keptHostsObjects selects objects of the kept hosts
+func keptHostsObjects(cr api.ICustomResource, objs *model.Registry) *model.Registry {
+	chi, ok := cr.(*api.ClickHouseInstallation)
+	if !ok {
+		return model.NewRegistry()
+	}
+	kept := chi.EnsureStatus().GetHostsKept()
+	return objs.Filter(func(_ model.EntityType, obj meta.Object) bool {
+		for _, host := range kept {
+			if (len(host.Selector) > 0) && labels.SelectorFromSet(host.Selector).Matches(labels.Set(obj.GetLabels())) {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,106 @@
+package chi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/model"
+)
+
+func newTestHost(cluster, name string) *api.Host {
+	host := &api.Host{}
+	host.Name = name
+	host.Runtime.Address.ClusterName = cluster
+	return host
+}
+
+func TestHostsKeptStatus(t *testing.T) {
+	chi := &api.ClickHouseInstallation{}
+	chi.EnsureStatus().PushHostKept(&api.HostKeptStatus{Host: "0-1", Cluster: "a", Shard: "0"})
+	chi.EnsureStatus().PushHostKept(&api.HostKeptStatus{Host: "0-1", Cluster: "b", Shard: "0"})
+	// The same host of the same cluster is replaced
+	chi.EnsureStatus().PushHostKept(&api.HostKeptStatus{Host: "0-1", Cluster: "a", Shard: "0", Replica: "r"})
+	require.Len(t, chi.EnsureStatus().GetHostsKept(), 2)
+
+	require.True(t, isHostKept(chi, newTestHost("a", "0-1")))
+	require.True(t, isHostKept(chi, newTestHost("b", "0-1")))
+	require.False(t, isHostKept(chi, newTestHost("a", "0-0")))
+
+	chi.EnsureStatus().RemoveHostKept("a", "0-1")
+	require.False(t, isHostKept(chi, newTestHost("a", "0-1")))
+	require.True(t, isHostKept(chi, newTestHost("b", "0-1")))
+
+	// Kept hosts survive status copy, as done on status update
+	copied := &api.ClickHouseInstallation{}
+	copied.EnsureStatus().CopyFrom(chi.EnsureStatus(), types.CopyStatusOptions{HostsKept: true})
+	require.True(t, isHostKept(copied, newTestHost("b", "0-1")))
+}
+
+func TestKeptHostsObjects(t *testing.T) {
+	chi := &api.ClickHouseInstallation{}
+	chi.EnsureStatus().PushHostKept(&api.HostKeptStatus{
+		Host:    "0-1",
+		Cluster: "a",
+		Shard:   "0",
+		Selector: map[string]string{
+			"clickhouse.altinity.com/cluster": "a",
+			"clickhouse.altinity.com/shard":   "0",
+			"clickhouse.altinity.com/replica": "1",
+		},
+	})
+
+	objs := model.NewRegistry()
+	kept := &meta.ObjectMeta{Namespace: "ns", Name: "chi-a-0-1", Labels: map[string]string{
+		"clickhouse.altinity.com/cluster": "a",
+		"clickhouse.altinity.com/shard":   "0",
+		"clickhouse.altinity.com/replica": "1",
+		"other":                           "label",
+	}}
+	other := &meta.ObjectMeta{Namespace: "ns", Name: "chi-a-0-0", Labels: map[string]string{
+		"clickhouse.altinity.com/cluster": "a",
+		"clickhouse.altinity.com/shard":   "0",
+		"clickhouse.altinity.com/replica": "0",
+	}}
+	objs.RegisterStatefulSet(kept)
+	objs.RegisterPVC(kept)
+	objs.RegisterStatefulSet(other)
+
+	selected := keptHostsObjects(chi, objs)
+	require.True(t, selected.HasStatefulSet(kept))
+	require.True(t, selected.HasPVC(kept))
+	require.False(t, selected.HasStatefulSet(other))
+
+	objs.Subtract(selected)
+	require.Equal(t, 1, objs.Len(model.StatefulSet, model.PVC))
+	require.True(t, objs.HasStatefulSet(other))
+}
+
+func TestIsReplicationLagAcceptable(t *testing.T) {
+	delayOnly := api.OperatorConfigReconcileHostWaitReplicas{Delay: types.NewInt32(10)}
+	withQueueSize := api.OperatorConfigReconcileHostWaitReplicas{Delay: types.NewInt32(10), QueueSize: types.NewInt32(5)}
+
+	tests := []struct {
+		name      string
+		delay     int
+		queueSize int
+		replicas  api.OperatorConfigReconcileHostWaitReplicas
+		want      bool
+	}{
+		{name: "in sync", replicas: delayOnly, want: true},
+		{name: "delay within threshold", delay: 10, replicas: delayOnly, want: true},
+		{name: "delay above threshold", delay: 11, replicas: delayOnly, want: false},
+		{name: "queue size is not checked", queueSize: 1000, replicas: delayOnly, want: true},
+		{name: "queue size within threshold", queueSize: 5, replicas: withQueueSize, want: true},
+		{name: "queue size above threshold", queueSize: 6, replicas: withQueueSize, want: false},
+		{name: "delay above threshold with queue size within", delay: 11, queueSize: 1, replicas: withQueueSize, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isReplicationLagAcceptable(tt.delay, tt.queueSize, tt.replicas))
+		})
+	}
+}
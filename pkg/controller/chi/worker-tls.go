@@ -0,0 +1,229 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/tls"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// reconcileTLS reconciles TLS certificate of the CR hosts and sets TLS status of the CR.
+// Fingerprint of the certificate is used by the pod template, so certificate change leads to rolling restart.
+func (w *worker) reconcileTLS(ctx context.Context, cr *api.ClickHouseInstallation) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	conf := cr.GetSpecT().Configuration.GetTLS()
+	if !conf.IsEnabled() {
+		cr.EnsureStatus().SetTLS(nil)
+		return nil
+	}
+
+	w.a.V(2).M(cr).S().P()
+	defer w.a.V(2).M(cr).E().P()
+
+	var secret *core.Secret
+	var err error
+	switch {
+	case conf.IsCertManager():
+		secret, err = w.reconcileTLSCertManager(ctx, cr)
+	case conf.IsCASecret():
+		secret, err = w.reconcileTLSCASecret(ctx, cr)
+	}
+	if err != nil {
+		w.a.WithEvent(cr, common.EventActionReconcile, common.EventReasonReconcileFailed).
+			WithStatusAction(cr).
+			WithStatusError(cr).
+			M(cr).F().
+			Error("FAILED to reconcile TLS certificate for CHI: %s err: %v", cr.GetName(), err)
+		return err
+	}
+
+	cert, err := tls.GetCertificate(secret)
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to parse TLS certificate from secret: %s err: %v", util.NamespacedName(secret), err)
+		return err
+	}
+	cr.EnsureStatus().SetTLS(tls.CreateStatus(secret, cert))
+
+	return nil
+}
+
+// reconcileTLSCertManager reconciles cert-manager Certificate and waits for the Secret issued by cert-manager
+func (w *worker) reconcileTLSCertManager(ctx context.Context, cr *api.ClickHouseInstallation) (*core.Secret, error) {
+	manager := tls.NewManager()
+	certificate := manager.CreateCertificate(cr)
+
+	cur, err := w.c.kube.Certificate().Get(ctx, certificate.GetNamespace(), certificate.GetName())
+	switch {
+	case err == nil:
+		certificate.SetResourceVersion(cur.GetResourceVersion())
+		_, err = w.c.kube.Certificate().Update(ctx, certificate)
+	case apiErrors.IsNotFound(err):
+		_, err = w.c.kube.Certificate().Create(ctx, certificate)
+		if err == nil {
+			w.a.V(1).
+				WithEvent(cr, common.EventActionCreate, common.EventReasonCreateCompleted).
+				WithStatusAction(cr).
+				M(cr).F().
+				Info("Create Certificate %s/%s", certificate.GetNamespace(), certificate.GetName())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Secret is issued by cert-manager asynchronously, wait for it
+	var secret *core.Secret
+	secretName := manager.GetSecretName(cr)
+	err = poller.New(ctx, fmt.Sprintf("%s/%s", cr.GetNamespace(), secretName)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			Get: func(_ctx context.Context) (any, error) {
+				return w.c.kube.Secret().Get(ctx, cr.GetNamespace(), secretName)
+			},
+			IsDone: func(_ctx context.Context, a any) bool {
+				secret = a.(*core.Secret)
+				_, err := tls.GetCertificate(secret)
+				return err == nil
+			},
+			ShouldContinue: func(_ctx context.Context, _ any, err error) bool {
+				return apiErrors.IsNotFound(err)
+			},
+		}).Poll()
+	return secret, err
+}
+
+// reconcileTLSCASecret issues certificate from the CA Secret in case there is no valid certificate issued already
+func (w *worker) reconcileTLSCASecret(ctx context.Context, cr *api.ClickHouseInstallation) (*core.Secret, error) {
+	manager := tls.NewManager()
+
+	cur, err := w.c.kube.Secret().Get(ctx, cr.GetNamespace(), manager.GetSecretName(cr))
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		cert, _ := tls.GetCertificate(cur)
+		if !manager.IsRenewalRequired(cr, cert, time.Now()) {
+			// Certificate in use is fine
+			return cur, nil
+		}
+	}
+
+	ca, err := w.c.kube.Secret().Get(ctx, cr.GetNamespace(), cr.GetSpecT().Configuration.GetTLS().GetCASecretName())
+	if err != nil {
+		return nil, err
+	}
+	secret, err := manager.CreateSecret(cr, ca)
+	if err != nil {
+		return nil, err
+	}
+
+	if cur != nil && cur.GetName() != "" {
+		secret.SetResourceVersion(cur.GetResourceVersion())
+		_, err = w.c.kube.Secret().Update(ctx, secret)
+	} else {
+		err = w.createSecret(ctx, cr, secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	w.a.V(1).
+		WithEvent(cr, common.EventActionUpdate, common.EventReasonUpdateCompleted).
+		WithStatusAction(cr).
+		M(cr).F().
+		Info("Issued TLS certificate %s/%s", secret.GetNamespace(), secret.GetName())
+	return secret, nil
+}
+
+// reconcileHostTLS ensures Secret with TLS certificate is in place for the host to mount.
+// Host would not start without the Secret, thus host reconcile fails instead of waiting for pod to become ready.
+func (w *worker) reconcileHostTLS(ctx context.Context, host *api.Host) error {
+	cr, ok := host.GetCR().(*api.ClickHouseInstallation)
+	if !ok || !cr.GetSpecT().Configuration.GetTLS().IsEnabled() {
+		return nil
+	}
+
+	secret, err := w.c.kube.Secret().Get(ctx, cr.GetNamespace(), tls.NewManager().GetSecretName(cr))
+	if err != nil {
+		return fmt.Errorf("unable to get TLS certificate secret for host %s. err: %v", host.GetName(), err)
+	}
+	if _, err := tls.GetCertificate(secret); err != nil {
+		return fmt.Errorf("TLS certificate secret %s has no valid certificate. err: %v", util.NamespacedName(secret), err)
+	}
+	return nil
+}
+
+// rotateTLS triggers reconcile of the CR in case its certificate has to be rotated
+func (w *worker) rotateTLS(ctx context.Context, cr *api.ClickHouseInstallation) {
+	if w.shouldRotateTLS(ctx, cr) {
+		w.c.enqueueReconcileForce(cr, "TLS certificate rotation")
+	}
+}
+
+// shouldRotateTLS checks whether certificate of the CR has to be rotated
+func (w *worker) shouldRotateTLS(ctx context.Context, cr *api.ClickHouseInstallation) bool {
+	switch {
+	case cr.IsStopped():
+		return false
+	case cr.EnsureStatus().GetStatus() != api.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return false
+	}
+
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to normalize CR for TLS rotation. err: %v", err)
+		return false
+	}
+	conf := normalized.GetSpecT().Configuration.GetTLS()
+	if !conf.IsEnabled() {
+		return false
+	}
+
+	manager := tls.NewManager()
+	secret, err := w.c.kube.Secret().Get(ctx, normalized.GetNamespace(), manager.GetSecretName(normalized))
+	if err != nil {
+		return apiErrors.IsNotFound(err)
+	}
+	cert, err := tls.GetCertificate(secret)
+	if err != nil {
+		return true
+	}
+
+	if conf.IsCASecret() && manager.IsRenewalRequired(normalized, cert, time.Now()) {
+		// Certificate issued by the operator is about to expire
+		return true
+	}
+
+	// Certificate may be renewed by cert-manager, hosts have to be restarted to pick it up
+	return util.CertificateFingerprint(cert) != cr.EnsureStatus().GetTLS().GetFingerprint()
+}
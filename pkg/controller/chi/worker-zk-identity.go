@@ -0,0 +1,252 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// Zookeeper identity is rotated in two steps:
+//  1. Before hosts are reconciled, ACL entry of the new identity is granted next to each ACL entry of the identity
+//     applied previously, so hosts restarted with the new identity and hosts still running with the previous one
+//     both have access to the replication metadata.
+//  2. After hosts are reconciled, ACL entries of the previous identity are revoked and the new identity
+//     is stored as the applied one.
+// Identities applied are kept in the Secret managed by the operator, since they are required to authenticate
+// in order to modify ACLs, when identity in the user's Secret is changed already.
+
+// reconcileZookeeperIdentity grants ACLs to the zookeeper identities of the CR and sets zookeeper identity status.
+// Fingerprint of the identity Secrets versions is used by the pod template, so identity change leads to rolling restart.
+func (w *worker) reconcileZookeeperIdentity(ctx context.Context, cr *api.ClickHouseInstallation) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	identities, versions, err := w.getZookeeperIdentities(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if len(identities) == 0 {
+		return w.updateZookeeperIdentityStatus(ctx, cr, nil)
+	}
+
+	applied, err := w.getZookeeperIdentitiesApplied(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	cr.WalkClusters(func(cluster api.ICluster) error {
+		previous, current := applied[cluster.GetName()], identities[cluster.GetName()]
+		if (previous == "") || (previous == current) {
+			return nil
+		}
+		zk := cluster.GetZookeeper()
+		conn := zookeeper.NewConnection(zk.Nodes, &zookeeper.ConnectionParams{Identities: []string{previous, current}})
+		defer conn.Close()
+		if _, err := zookeeper.NewACLManager(conn).Grant(ctx, zk.Root, previous, current); err != nil {
+			w.a.V(1).M(cr).F().Error("unable to grant ACL to the new zookeeper identity. Cluster: %s err: %v", cluster.GetName(), err)
+		}
+		return nil
+	})
+
+	return w.updateZookeeperIdentityStatus(ctx, cr, &api.ZookeeperIdentityStatus{
+		Fingerprint: zookeeperIdentitiesFingerprint(versions),
+	})
+}
+
+// updateZookeeperIdentityStatus updates .status.zookeeperIdentity of the CR, in case it is changed
+func (w *worker) updateZookeeperIdentityStatus(ctx context.Context, cr *api.ClickHouseInstallation, status *api.ZookeeperIdentityStatus) error {
+	current := cr.EnsureStatus().GetZookeeperIdentity()
+	if (current == nil) == (status == nil) && (current.GetFingerprint() == status.GetFingerprint()) {
+		return nil
+	}
+	cr.EnsureStatus().SetZookeeperIdentity(status)
+	return w.c.updateCRObjectStatus(ctx, cr, types.UpdateStatusOptions{
+		CopyStatusOptions: types.CopyStatusOptions{
+			ZookeeperIdentity: true,
+		},
+		TolerateAbsence: true,
+	})
+}
+
+// finalizeZookeeperIdentity revokes ACLs of the previous zookeeper identities and stores current identities as applied
+func (w *worker) finalizeZookeeperIdentity(ctx context.Context, cr *api.ClickHouseInstallation) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	identities, _, err := w.getZookeeperIdentities(ctx, cr)
+	if (err != nil) || (len(identities) == 0) {
+		return err
+	}
+	if cr.EnsureStatus().GetHostsFailedCount() > 0 {
+		// Failed hosts may still use previous identities, keep them granted till the next reconcile
+		return nil
+	}
+
+	applied, err := w.getZookeeperIdentitiesApplied(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	cr.WalkClusters(func(cluster api.ICluster) error {
+		previous, current := applied[cluster.GetName()], identities[cluster.GetName()]
+		if (previous == "") || (previous == current) {
+			return nil
+		}
+		zk := cluster.GetZookeeper()
+		conn := zookeeper.NewConnection(zk.Nodes, &zookeeper.ConnectionParams{Identities: []string{previous, current}})
+		defer conn.Close()
+		if _, err := zookeeper.NewACLManager(conn).Revoke(ctx, zk.Root, previous, current); err != nil {
+			w.a.V(1).M(cr).F().Error("unable to revoke ACL of the previous zookeeper identity. Cluster: %s err: %v", cluster.GetName(), err)
+			// Previous identity has to be kept as applied, revoke will be retried
+			identities[cluster.GetName()] = previous
+		}
+		return nil
+	})
+
+	if maps.Equal(identities, applied) {
+		return nil
+	}
+	return w.storeZookeeperIdentitiesApplied(ctx, cr, identities)
+}
+
+// getZookeeperIdentity gets zookeeper identity from the Secret referenced by zookeeper config
+func (w *worker) getZookeeperIdentity(ctx context.Context, namespace string, zk *api.ZookeeperConfig) (string, error) {
+	identity, _, err := w.getZookeeperIdentityVersioned(ctx, namespace, zk)
+	return identity, err
+}
+
+// getZookeeperIdentityVersioned gets zookeeper identity along with its version,
+// which changes along with the Secret and does not reveal the identity, unlike any hash of it
+func (w *worker) getZookeeperIdentityVersioned(ctx context.Context, namespace string, zk *api.ZookeeperConfig) (identity string, version string, err error) {
+	ref := zk.GetIdentitySecret()
+	secret, err := w.c.kube.Secret().Get(ctx, namespace, ref.Name)
+	if err != nil {
+		return "", "", err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok || (len(data) == 0) {
+		return "", "", fmt.Errorf("no zookeeper identity in Secret %s/%s key: %s", namespace, ref.Name, ref.Key)
+	}
+	return string(data), fmt.Sprintf("%s/%s@%s", secret.GetName(), ref.Key, secret.GetResourceVersion()), nil
+}
+
+// getZookeeperIdentities gets zookeeper identities, provided via Secrets, of the clusters of the CR,
+// along with versions of the identities, both by cluster name
+func (w *worker) getZookeeperIdentities(ctx context.Context, cr *api.ClickHouseInstallation) (identities, versions map[string]string, err error) {
+	identities = make(map[string]string)
+	versions = make(map[string]string)
+	cr.WalkClusters(func(cluster api.ICluster) error {
+		zk := cluster.GetZookeeper()
+		if (err != nil) || zk.IsEmpty() || !zk.HasIdentitySecret() {
+			return nil
+		}
+		identities[cluster.GetName()], versions[cluster.GetName()], err = w.getZookeeperIdentityVersioned(ctx, cr.GetNamespace(), zk)
+		return nil
+	})
+	return identities, versions, err
+}
+
+// getZookeeperIdentitiesApplied gets zookeeper identities ACLs are applied with, by cluster name
+func (w *worker) getZookeeperIdentitiesApplied(ctx context.Context, cr *api.ClickHouseInstallation) (map[string]string, error) {
+	identities := make(map[string]string)
+	secret, err := w.c.kube.Secret().Get(ctx, cr.GetNamespace(), w.c.namer.Name(interfaces.NameZookeeperIdentitySecret, cr))
+	if apiErrors.IsNotFound(err) {
+		return identities, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for cluster, identity := range secret.Data {
+		identities[cluster] = string(identity)
+	}
+	return identities, nil
+}
+
+// storeZookeeperIdentitiesApplied stores zookeeper identities ACLs are applied with
+func (w *worker) storeZookeeperIdentitiesApplied(ctx context.Context, cr *api.ClickHouseInstallation, identities map[string]string) error {
+	secret := w.task.Creator().CreateZookeeperIdentitySecret(w.c.namer.Name(interfaces.NameZookeeperIdentitySecret, cr), identities)
+	cur, err := w.c.kube.Secret().Get(ctx, secret.GetNamespace(), secret.GetName())
+	switch {
+	case err == nil:
+		secret.SetResourceVersion(cur.GetResourceVersion())
+		_, err = w.c.kube.Secret().Update(ctx, secret)
+	case apiErrors.IsNotFound(err):
+		err = w.createSecret(ctx, cr, secret)
+	}
+	return err
+}
+
+// zookeeperIdentitiesFingerprint builds SHA-256 fingerprint of the identities versions.
+// Fingerprint is published in the pod template and status, thus it is never built of the identities themselves.
+func zookeeperIdentitiesFingerprint(versions map[string]string) string {
+	clusters := util.MapGetSortedKeys(versions)
+	hash := sha256.New()
+	for _, cluster := range clusters {
+		hash.Write([]byte(cluster + "=" + versions[cluster] + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// rotateZookeeperIdentityCR triggers reconcile of the CR in case identity in the Secret is changed
+func (w *worker) rotateZookeeperIdentityCR(ctx context.Context, cr *api.ClickHouseInstallation) {
+	if w.shouldRotateZookeeperIdentity(ctx, cr) {
+		w.c.enqueueReconcileForce(cr, "zookeeper identity rotation")
+	}
+}
+
+// shouldRotateZookeeperIdentity checks whether zookeeper identities of the CR differ from the identities in use
+func (w *worker) shouldRotateZookeeperIdentity(ctx context.Context, cr *api.ClickHouseInstallation) bool {
+	switch {
+	case cr.IsStopped():
+		return false
+	case cr.EnsureStatus().GetStatus() != api.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return false
+	}
+
+	normalized, err := w.normalizer.CreateTemplated(cr.DeepCopy(), commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(cr).F().Error("unable to normalize CR for zookeeper identity rotation. err: %v", err)
+		return false
+	}
+	_, versions, err := w.getZookeeperIdentities(ctx, normalized)
+	if err != nil {
+		w.a.V(1).M(cr).F().Warning("unable to get zookeeper identities. err: %v", err)
+		return false
+	}
+	if len(versions) == 0 {
+		return false
+	}
+
+	return zookeeperIdentitiesFingerprint(versions) != cr.EnsureStatus().GetZookeeperIdentity().GetFingerprint()
+}
@@ -15,17 +15,43 @@
 package chi
 
 import (
+	"context"
+	"strings"
+
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
 )
 
-func reconcileZookeeperRootPath(cluster *api.Cluster) {
+// reconcileZookeeperRootPath ensures zookeeper root path of the cluster exists and the tree under it is protected by ACL, if requested
+func (w *worker) reconcileZookeeperRootPath(ctx context.Context, cluster *api.Cluster) {
 	if cluster.Zookeeper.IsEmpty() {
 		// Nothing to reconcile
 		return
 	}
-	conn := zookeeper.NewConnection(cluster.Zookeeper.Nodes)
-	path := zookeeper.NewPathManager(conn)
-	path.Ensure(cluster.Zookeeper.Root)
-	path.Close()
+
+	var identity string
+	if cluster.Zookeeper.HasIdentitySecret() {
+		var err error
+		if identity, err = w.getZookeeperIdentity(ctx, cluster.GetRuntime().GetCR().GetNamespace(), cluster.Zookeeper); err != nil {
+			w.a.V(1).M(cluster).F().Warning("unable to get zookeeper identity. Cluster: %s err: %v", cluster.GetName(), err)
+		}
+	}
+
+	params := &zookeeper.ConnectionParams{}
+	if identity != "" {
+		params.Identities = []string{identity}
+	}
+	conn := zookeeper.NewConnection(cluster.Zookeeper.Nodes, params)
+	defer conn.Close()
+
+	zookeeper.NewPathManager(conn).Ensure(cluster.Zookeeper.Root)
+
+	root := "/" + strings.Trim(strings.TrimSpace(cluster.Zookeeper.Root), "/")
+	if !cluster.Zookeeper.IsACLEnabled() || (identity == "") || (root == "/") {
+		// Root of the ensemble is never protected, since it is shared by all tenants
+		return
+	}
+	if _, err := zookeeper.NewACLManager(conn).Protect(ctx, root, identity); err != nil {
+		w.a.V(1).M(cluster).F().Error("unable to protect zookeeper tree: %s Cluster: %s err: %v", root, cluster.GetName(), err)
+	}
 }
@@ -0,0 +1,246 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller/domain"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// reconcileShardsAndHostsByZones reconciles shards and hosts zone by zone.
+// All hosts located in one zone are reconciled across shards, and the zone has to be healthy
+// before the next zone is started
+func (w *worker) reconcileShardsAndHostsByZones(ctx context.Context, shards []*api.ChiShard) error {
+	log.V(1).F().S().Info("reconcileShardsAndHostsByZones start")
+	defer log.V(1).F().E().Info("reconcileShardsAndHostsByZones end")
+
+	// Shards objects do not depend on zones
+	for _, shard := range shards {
+		if err := w.reconcileShard(ctx, shard); err != nil {
+			return err
+		}
+	}
+
+	zones, hosts, err := w.groupHostsByZones(ctx, shards)
+	if err != nil {
+		w.a.V(1).Warning("Unable to group hosts by zones: %v", err)
+		return err
+	}
+	for _, zone := range zones {
+		if util.IsContextDone(ctx) {
+			log.V(2).Info("task is done")
+			return nil
+		}
+
+		w.a.V(1).Info("Reconcile zone: '%s' hosts: %d", zone, len(hosts[zone]))
+		if err := w.reconcileZone(ctx, hosts[zone]); err != nil {
+			w.a.V(1).Warning("Skipping rest of zones due to an error in zone '%s': %v", zone, err)
+			return err
+		}
+		if err := w.waitZoneHealthy(ctx, hosts[zone]); err != nil {
+			w.a.V(1).Warning("Skipping rest of zones due to zone '%s' is not healthy: %v", zone, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupHostsByZones groups hosts of the shards by zones.
+// Hosts of each zone are grouped by shards, thus hosts of one shard are reconciled one by one.
+// Zones are sorted, hosts with unknown zone are reconciled the last
+func (w *worker) groupHostsByZones(ctx context.Context, shards []*api.ChiShard) ([]string, map[string][][]*api.Host, error) {
+	hosts := make(map[string][][]*api.Host)
+	for _, shard := range shards {
+		shardHosts := make(map[string][]*api.Host)
+		err := shard.WalkHostsAbortOnError(func(host *api.Host) error {
+			zone, err := w.getHostZone(ctx, host)
+			if err != nil {
+				return err
+			}
+			shardHosts[zone] = append(shardHosts[zone], host)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for zone := range shardHosts {
+			hosts[zone] = append(hosts[zone], shardHosts[zone])
+		}
+	}
+
+	var zones []string
+	for zone := range hosts {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		switch {
+		case zones[i] == "":
+			return false
+		case zones[j] == "":
+			return true
+		}
+		return zones[i] < zones[j]
+	})
+
+	return zones, hosts, nil
+}
+
+// getHostZone gets zone of the host.
+// Zone is taken from the pod template, in case it pins the host to the only zone.
+// Otherwise zone is taken from the pod - its node selector, required node affinity or zone label,
+// and only then from the label of the node the pod runs at, which requires cluster-scoped access to nodes.
+// Inability to get the node is reported as an error, as hosts can not be grouped by zones reliably
+func (w *worker) getHostZone(ctx context.Context, host *api.Host) (string, error) {
+	if template, ok := host.GetPodTemplate(); ok && (len(template.Zone.Values) == 1) {
+		return template.Zone.Values[0], nil
+	}
+
+	zoneKey := host.GetCR().GetReconciling().GetZoneKey()
+	pod, err := w.c.kube.Pod().Get(host)
+	if err != nil {
+		// New host, zone is not known yet
+		return "", nil
+	}
+	if zone := getPodZone(pod, zoneKey); zone != "" {
+		return zone, nil
+	}
+	if pod.Spec.NodeName == "" {
+		// Pod is not scheduled yet, zone is not known yet
+		return "", nil
+	}
+
+	node, err := w.c.kube.Node().Get(ctx, pod.Spec.NodeName)
+	switch {
+	case err == nil:
+		return node.GetLabels()[zoneKey], nil
+	case apiErrors.IsNotFound(err):
+		// Node is gone, zone is not known
+		return "", nil
+	case apiErrors.IsForbidden(err):
+		return "", fmt.Errorf("no access to nodes to get zone of the host %s. "+
+			"Either grant operator cluster-scoped 'get' on nodes or pin pods to zones via pod template zone, "+
+			"node affinity or '%s' pod label. err: %v", host.GetName(), zoneKey, err)
+	default:
+		return "", fmt.Errorf("unable to get node %s to get zone of the host %s. err: %v", pod.Spec.NodeName, host.GetName(), err)
+	}
+}
+
+// getPodZone gets zone of the pod in case it is known from the pod itself,
+// which is the case for pods with node selector or required node affinity pinning them to the only zone
+// and for pods labeled with topology labels of the node
+func getPodZone(pod *core.Pod, zoneKey string) string {
+	if zone := pod.Spec.NodeSelector[zoneKey]; zone != "" {
+		return zone
+	}
+	if zone := getNodeAffinityZone(pod.Spec.Affinity, zoneKey); zone != "" {
+		return zone
+	}
+	return pod.GetLabels()[zoneKey]
+}
+
+// getNodeAffinityZone gets the only zone required node affinity allows, if any
+func getNodeAffinityZone(affinity *core.Affinity, zoneKey string) string {
+	if (affinity == nil) || (affinity.NodeAffinity == nil) || (affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil) {
+		return ""
+	}
+	zone := ""
+	// Terms are ORed, so each term has to pin the same zone
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termZone := ""
+		for _, expression := range term.MatchExpressions {
+			if (expression.Key == zoneKey) && (expression.Operator == core.NodeSelectorOpIn) && (len(expression.Values) == 1) {
+				termZone = expression.Values[0]
+			}
+		}
+		switch {
+		case termZone == "":
+			return ""
+		case (zone != "") && (zone != termZone):
+			return ""
+		}
+		zone = termZone
+	}
+	return zone
+}
+
+// reconcileZone reconciles hosts of the zone. Shards are reconciled concurrently, hosts of a shard - one by one
+func (w *worker) reconcileZone(ctx context.Context, shards [][]*api.Host) error {
+	workersNum := int(math.Max(float64(chop.Config().Reconcile.Runtime.ReconcileShardsThreadsNumber), 1))
+	for start := 0; start < len(shards); start += workersNum {
+		end := start + workersNum
+		if end > len(shards) {
+			end = len(shards)
+		}
+		concurrentlyProcessedShards := shards[start:end]
+
+		// Processing error protected with mutex
+		var err error
+		var errLock sync.Mutex
+
+		wg := sync.WaitGroup{}
+		wg.Add(len(concurrentlyProcessedShards))
+		for j := range concurrentlyProcessedShards {
+			hosts := concurrentlyProcessedShards[j]
+			go func() {
+				defer wg.Done()
+				for _, host := range hosts {
+					if e := w.reconcileHost(ctx, host); e != nil {
+						errLock.Lock()
+						err = e
+						errLock.Unlock()
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitZoneHealthy is a health gate between zones - waits for all running hosts of the zone to be back in cluster
+// and to catch up with other replicas, thus the next zone is not taken down while replicas of this zone are behind
+func (w *worker) waitZoneHealthy(ctx context.Context, shards [][]*api.Host) error {
+	for _, hosts := range shards {
+		for _, host := range hosts {
+			if host.IsStopped() || host.IsExternal() {
+				continue
+			}
+			if err := domain.PollHost(ctx, host, w.ensureClusterSchemer(host).IsHostInCluster); err != nil {
+				return err
+			}
+			if err := w.waitHostCaughtUp(ctx, host); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
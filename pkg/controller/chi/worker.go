@@ -23,6 +23,7 @@ import (
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
 	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
@@ -97,6 +98,8 @@ func (c *Controller) newWorker(q queue.PriorityQueue, sys bool) *worker {
 					Name:      name,
 				},
 			})
+		}, func(namespace, name string) (*apiChk.ClickHouseKeeperInstallation, error) {
+			return c.kube.Keeper().Get(context.TODO(), namespace, name)
 		}),
 		start: start,
 		task:  nil,
@@ -111,6 +114,7 @@ func configGeneratorOptions(cr *api.ClickHouseInstallation) *config.GeneratorOpt
 		Settings:       cr.GetSpecT().Configuration.Settings,
 		Files:          cr.GetSpecT().Configuration.Files,
 		DistributedDDL: cr.GetSpecT().Defaults.DistributedDDL,
+		TLS:            cr.GetSpecT().Configuration.GetTLS(),
 	}
 }
 
@@ -123,6 +127,7 @@ func (w *worker) newTask(cr *api.ClickHouseInstallation) {
 			managers.NewTagManager(managers.TagManagerTypeClickHouse, cr),
 			managers.NewProbeManager(managers.ProbeManagerTypeClickHouse),
 			managers.NewServiceManager(managers.ServiceManagerTypeClickHouse),
+			managers.NewIngressManager(managers.IngressManagerTypeClickHouse),
 			managers.NewVolumeManager(managers.VolumeManagerTypeClickHouse),
 			managers.NewConfigMapManager(managers.ConfigMapManagerTypeClickHouse),
 			managers.NewNameManager(managers.NameManagerTypeClickHouse),
@@ -199,8 +204,8 @@ func (w *worker) shouldForceRestartHost(host *api.Host) bool {
 	return false
 }
 
-// normalize
-func (w *worker) normalize(c *api.ClickHouseInstallation) *api.ClickHouseInstallation {
+// normalize normalizes CR. Normalized CR is returned along with the errors CR is found to have
+func (w *worker) normalize(c *api.ClickHouseInstallation) (*api.ClickHouseInstallation, error) {
 	chi, err := w.normalizer.CreateTemplated(c, commonNormalizer.NewOptions())
 	if err != nil {
 		w.a.WithEvent(chi, common.EventActionReconcile, common.EventReasonReconcileFailed).
@@ -222,7 +227,7 @@ func (w *worker) normalize(c *api.ClickHouseInstallation) *api.ClickHouseInstall
 			Error("FAILED to normalize CHI 2: %v", err)
 	}
 
-	return chi
+	return chi, err
 }
 
 // ensureFinalizer
@@ -328,7 +333,7 @@ func (w *worker) updateCHI(ctx context.Context, old, new *api.ClickHouseInstalla
 		return nil
 	}
 
-	if w.isCHIProcessedOnTheSameIP(new) {
+	if !common.IsReconcileForced(ctx) && w.isCHIProcessedOnTheSameIP(new) {
 		// First minute after restart do not reconcile already reconciled generations
 		w.a.V(1).M(new).F().Info("Will not reconcile known generation after restart. Generation %d", new.Generation)
 		return nil
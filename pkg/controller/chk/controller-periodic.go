@@ -0,0 +1,53 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chk
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// Start runs periodic tasks over all CRs on its own, outside of reconcile, thus status updates
+// made by periodic tasks do not requeue reconcile. Implements manager.Runnable
+func (c *Controller) Start(ctx context.Context) error {
+	c.new()
+	wait.UntilWithContext(ctx, c.runPeriodic, keeperStatusPeriod)
+	return nil
+}
+
+// runPeriodic refreshes keeper status and takes scheduled backups of all CRs
+func (c *Controller) runPeriodic(ctx context.Context) {
+	list := &apiChk.ClickHouseKeeperInstallationList{}
+	if err := c.Client.List(ctx, list); err != nil {
+		log.V(1).F().Error("unable to list CHKs for periodic tasks. err: %v", err)
+		return
+	}
+
+	w := c.newWorker()
+	for i := range list.Items {
+		if util.IsContextDone(ctx) {
+			log.V(2).Info("task is done")
+			return
+		}
+		chk := &list.Items[i]
+		w.updateKeeperStatus(ctx, chk)
+		w.backupKeeper(ctx, chk)
+	}
+}
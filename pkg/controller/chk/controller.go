@@ -16,13 +16,16 @@ package chk
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
 	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
 	"github.com/altinity/clickhouse-operator/pkg/controller/chk/kube"
 	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/metrics/clickhouse"
 	"github.com/altinity/clickhouse-operator/pkg/model/managers"
 	"github.com/altinity/clickhouse-operator/pkg/util"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -38,6 +41,7 @@ type Controller struct {
 	client.Client
 	Scheme *apiMachinery.Scheme
 
+	once  sync.Once
 	namer interfaces.INameManager
 	kube  interfaces.IKube
 	//labeler    *Labeler
@@ -45,11 +49,13 @@ type Controller struct {
 }
 
 func (c *Controller) new() {
-	c.namer = managers.NewNameManager(managers.NameManagerTypeKeeper)
-	c.kube = kube.NewAdapter(c.Client, c.namer)
-	//labeler:                 NewLabeler(kube),
-	//pvcDeleter :=              volume.NewPVCDeleter(managers.NewNameManager(managers.NameManagerTypeKeeper))
-
+	// Reconcile and periodic tasks run concurrently, dependencies are created once
+	c.once.Do(func() {
+		c.namer = managers.NewNameManager(managers.NameManagerTypeKeeper)
+		c.kube = kube.NewAdapter(c.Client, c.namer)
+		//labeler:                 NewLabeler(kube),
+		//pvcDeleter :=              volume.NewPVCDeleter(managers.NewNameManager(managers.NameManagerTypeKeeper))
+	})
 }
 
 func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -66,6 +72,7 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			// Owned objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			c.deleteWatch(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		// Return and requeue
@@ -75,7 +82,10 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	c.new()
 	w := c.newWorker()
 
-	w.reconcileCR(context.TODO(), nil, new)
+	if err := w.reconcileCR(context.TODO(), nil, new); err != nil {
+		// Return and requeue failed reconcile
+		return ctrl.Result{}, err
+	}
 
 	//// Fetch the ClickHouseKeeper instance
 	//dummy := &apiChk.ClickHouseKeeperInstallation{}
@@ -91,6 +101,7 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	//	return ctrl.Result{}, err
 	//}
 
+	// Keeper status is kept up-to-date by periodic tasks, see Start
 	return ctrl.Result{}, nil
 }
 
@@ -154,3 +165,46 @@ func (c *Controller) poll(ctx context.Context, cr api.ICustomResource, f func(c
 		}
 	}
 }
+
+// getTemplates gets all ClickHouseKeeperInstallationTemplates available to be applied to CHKs
+func (c *Controller) getTemplates() []*apiChk.ClickHouseKeeperInstallation {
+	templates, err := c.kube.Keeper().ListTemplates(context.TODO())
+	if err != nil {
+		log.V(1).F().Warning("unable to list CHK templates. err: %v", err)
+		return nil
+	}
+	return templates
+}
+
+// updateWatch informs metrics exporter about the CR
+func (c *Controller) updateWatch(chk *apiChk.ClickHouseKeeperInstallation) {
+	watched := metrics.NewWatchedCHI(chk)
+	go c.updateWatchAsync(watched)
+}
+
+// updateWatchAsync
+func (c *Controller) updateWatchAsync(chk *metrics.WatchedCHI) {
+	if err := clickhouse.InformMetricsExporterAboutWatchedCHK(chk); err != nil {
+		log.V(1).F().Info("FAIL update watch (%s/%s): %q", chk.Namespace, chk.Name, err)
+	} else {
+		log.V(1).Info("OK update watch (%s/%s): %s", chk.Namespace, chk.Name, chk)
+	}
+}
+
+// deleteWatch informs metrics exporter to forget the CR
+func (c *Controller) deleteWatch(namespace, name string) {
+	watched := &metrics.WatchedCHI{
+		Namespace: namespace,
+		Name:      name,
+	}
+	go c.deleteWatchAsync(watched)
+}
+
+// deleteWatchAsync
+func (c *Controller) deleteWatchAsync(chk *metrics.WatchedCHI) {
+	if err := clickhouse.InformMetricsExporterToDeleteWatchedCHK(chk); err != nil {
+		log.V(1).F().Info("FAIL delete watch (%s/%s): %q", chk.Namespace, chk.Name, err)
+	} else {
+		log.V(1).Info("OK delete watch (%s/%s)", chk.Namespace, chk.Name)
+	}
+}
@@ -29,32 +29,44 @@ type Adapter struct {
 
 	// Set of k8s components
 
-	configMap  *ConfigMap
-	deployment *Deployment
-	event      *Event
-	pdb        *PDB
-	pod        *Pod
-	pvc        *storage.PVC
-	replicaSet *ReplicaSet
-	secret     *Secret
-	service    *Service
-	sts        *STS
+	certificate   *Certificate
+	configMap     *ConfigMap
+	deployment    *Deployment
+	event         *Event
+	ingress       *Ingress
+	keeper        *Keeper
+	networkPolicy *NetworkPolicy
+	node          *Node
+	pdb           *PDB
+	pod           *Pod
+	pvc           *storage.PVC
+	replicaSet    *ReplicaSet
+	route         *Route
+	secret        *Secret
+	service       *Service
+	sts           *STS
 }
 
 func NewAdapter(kubeClient client.Client, namer interfaces.INameManager) *Adapter {
 	return &Adapter{
 		cr: NewCR(kubeClient),
 
-		configMap:  NewConfigMap(kubeClient),
-		deployment: NewDeployment(kubeClient),
-		event:      NewEvent(kubeClient),
-		pdb:        NewPDB(kubeClient),
-		pod:        NewPod(kubeClient, namer),
-		pvc:        storage.NewStoragePVC(NewPVC(kubeClient)),
-		replicaSet: NewReplicaSet(kubeClient),
-		secret:     NewSecret(kubeClient, namer),
-		service:    NewService(kubeClient, namer),
-		sts:        NewSTS(kubeClient, namer),
+		certificate:   NewCertificate(kubeClient),
+		configMap:     NewConfigMap(kubeClient),
+		deployment:    NewDeployment(kubeClient),
+		event:         NewEvent(kubeClient),
+		ingress:       NewIngress(kubeClient),
+		keeper:        NewKeeper(kubeClient),
+		networkPolicy: NewNetworkPolicy(kubeClient),
+		node:          NewNode(kubeClient),
+		pdb:           NewPDB(kubeClient),
+		pod:           NewPod(kubeClient, namer),
+		pvc:           storage.NewStoragePVC(NewPVC(kubeClient)),
+		replicaSet:    NewReplicaSet(kubeClient),
+		route:         NewRoute(kubeClient),
+		secret:        NewSecret(kubeClient, namer),
+		service:       NewService(kubeClient, namer),
+		sts:           NewSTS(kubeClient, namer),
 	}
 }
 
@@ -63,6 +75,11 @@ func (k *Adapter) CR() interfaces.IKubeCR {
 	return k.cr
 }
 
+// Certificate is a getter
+func (k *Adapter) Certificate() interfaces.IKubeCertificate {
+	return k.certificate
+}
+
 // ConfigMap is a getter
 func (k *Adapter) ConfigMap() interfaces.IKubeConfigMap {
 	return k.configMap
@@ -78,6 +95,26 @@ func (k *Adapter) Event() interfaces.IKubeEvent {
 	return k.event
 }
 
+// Ingress is a getter
+func (k *Adapter) Ingress() interfaces.IKubeIngress {
+	return k.ingress
+}
+
+// Keeper is a getter
+func (k *Adapter) Keeper() interfaces.IKubeKeeper {
+	return k.keeper
+}
+
+// NetworkPolicy is a getter
+func (k *Adapter) NetworkPolicy() interfaces.IKubeNetworkPolicy {
+	return k.networkPolicy
+}
+
+// Node is a getter
+func (k *Adapter) Node() interfaces.IKubeNode {
+	return k.node
+}
+
 // PDB is a getter
 func (k *Adapter) PDB() interfaces.IKubePDB {
 	return k.pdb
@@ -98,6 +135,11 @@ func (k *Adapter) ReplicaSet() interfaces.IKubeReplicaSet {
 	return k.replicaSet
 }
 
+// Route is a getter
+func (k *Adapter) Route() interfaces.IKubeRoute {
+	return k.route
+}
+
 // Secret is a getter
 func (k *Adapter) Secret() interfaces.IKubeSecret {
 	return k.secret
@@ -0,0 +1,68 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+)
+
+// Certificate manages cert-manager Certificates. cert-manager is not a part of k8s core API, so unstructured objects are used
+type Certificate struct {
+	kubeClient client.Client
+}
+
+func NewCertificate(kubeClient client.Client) *Certificate {
+	return &Certificate{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Certificate) Create(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	err := c.kubeClient.Create(ctx, certificate)
+	return certificate, err
+}
+
+func (c *Certificate) Get(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(k8s.CertificateGroupVersionKind)
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, certificate)
+	if err == nil {
+		return certificate, nil
+	} else {
+		return nil, err
+	}
+}
+
+func (c *Certificate) Update(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	err := c.kubeClient.Update(ctx, certificate)
+	return certificate, err
+}
+
+func (c *Certificate) Delete(ctx context.Context, namespace, name string) error {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(k8s.CertificateGroupVersionKind)
+	certificate.SetNamespace(namespace)
+	certificate.SetName(name)
+	return c.kubeClient.Delete(ctx, certificate)
+}
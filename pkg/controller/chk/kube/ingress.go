@@ -0,0 +1,87 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Ingress struct {
+	kubeClient client.Client
+}
+
+func NewIngress(kubeClient client.Client) *Ingress {
+	return &Ingress{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Ingress) Create(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
+	err := c.kubeClient.Create(ctx, ingress)
+	return ingress, err
+}
+
+func (c *Ingress) Get(ctx context.Context, namespace, name string) (*networking.Ingress, error) {
+	ingress := &networking.Ingress{}
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, ingress)
+	if err == nil {
+		return ingress, nil
+	} else {
+		return nil, err
+	}
+}
+
+func (c *Ingress) Update(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error) {
+	err := c.kubeClient.Update(ctx, ingress)
+	return ingress, err
+}
+
+func (c *Ingress) Delete(ctx context.Context, namespace, name string) error {
+	ingress := &networking.Ingress{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	return c.kubeClient.Delete(ctx, ingress)
+}
+
+func (c *Ingress) List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.Ingress, error) {
+	list := &networking.IngressList{}
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	err = c.kubeClient.List(ctx, list, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
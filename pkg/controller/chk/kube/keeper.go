@@ -0,0 +1,68 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+)
+
+type Keeper struct {
+	kubeClient client.Client
+}
+
+func NewKeeper(kubeClient client.Client) *Keeper {
+	return &Keeper{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Keeper) Get(ctx context.Context, namespace, name string) (*apiChk.ClickHouseKeeperInstallation, error) {
+	chk := &apiChk.ClickHouseKeeperInstallation{}
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, chk)
+	if err == nil {
+		return chk, nil
+	} else {
+		return nil, err
+	}
+}
+
+// Create creates ClickHouseKeeperInstallation
+func (c *Keeper) Create(ctx context.Context, chk *apiChk.ClickHouseKeeperInstallation) (*apiChk.ClickHouseKeeperInstallation, error) {
+	if err := c.kubeClient.Create(ctx, chk); err != nil {
+		return nil, err
+	}
+	return chk, nil
+}
+
+// ListTemplates lists ClickHouseKeeperInstallationTemplates as plain CHKs
+func (c *Keeper) ListTemplates(ctx context.Context) ([]*apiChk.ClickHouseKeeperInstallation, error) {
+	list := &apiChk.ClickHouseKeeperInstallationTemplateList{}
+	if err := c.kubeClient.List(ctx, list); err != nil {
+		return nil, err
+	}
+	var templates []*apiChk.ClickHouseKeeperInstallation
+	for i := range list.Items {
+		templates = append(templates, (*apiChk.ClickHouseKeeperInstallation)(&list.Items[i]))
+	}
+	return templates, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"k8s.io/apimachinery/pkg/labels"
+
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type NetworkPolicy struct {
+	kubeClient client.Client
+}
+
+func NewNetworkPolicy(kubeClient client.Client) *NetworkPolicy {
+	return &NetworkPolicy{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *NetworkPolicy) Create(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error) {
+	err := c.kubeClient.Create(ctx, networkPolicy)
+	return networkPolicy, err
+}
+
+func (c *NetworkPolicy) Get(ctx context.Context, namespace, name string) (*networking.NetworkPolicy, error) {
+	networkPolicy := &networking.NetworkPolicy{}
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, networkPolicy)
+	if err == nil {
+		return networkPolicy, nil
+	} else {
+		return nil, err
+	}
+}
+
+func (c *NetworkPolicy) Update(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error) {
+	err := c.kubeClient.Update(ctx, networkPolicy)
+	return networkPolicy, err
+}
+
+func (c *NetworkPolicy) Delete(ctx context.Context, namespace, name string) error {
+	networkPolicy := &networking.NetworkPolicy{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	return c.kubeClient.Delete(ctx, networkPolicy)
+}
+
+func (c *NetworkPolicy) List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.NetworkPolicy, error) {
+	list := &networking.NetworkPolicyList{}
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	err = c.kubeClient.List(ctx, list, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
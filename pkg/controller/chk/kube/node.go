@@ -0,0 +1,45 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Node struct {
+	kubeClient client.Client
+}
+
+func NewNode(kubeClient client.Client) *Node {
+	return &Node{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Node) Get(ctx context.Context, name string) (*core.Node, error) {
+	node := &core.Node{}
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Name: name,
+	}, node)
+	if err == nil {
+		return node, nil
+	} else {
+		return nil, err
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+)
+
+// Route manages Gateway API routes. Gateway API is not a part of k8s core API, so unstructured objects are used
+type Route struct {
+	kubeClient client.Client
+}
+
+func NewRoute(kubeClient client.Client) *Route {
+	return &Route{
+		kubeClient: kubeClient,
+	}
+}
+
+func (c *Route) Create(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	err := c.kubeClient.Create(ctx, route)
+	return route, err
+}
+
+func (c *Route) Get(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(k8s.RouteGroupVersionKind(kind))
+	err := c.kubeClient.Get(ctx, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}, route)
+	if err == nil {
+		return route, nil
+	} else {
+		return nil, err
+	}
+}
+
+func (c *Route) Update(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	err := c.kubeClient.Update(ctx, route)
+	return route, err
+}
+
+func (c *Route) Delete(ctx context.Context, kind, namespace, name string) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(k8s.RouteGroupVersionKind(kind))
+	route.SetNamespace(namespace)
+	route.SetName(name)
+	return c.kubeClient.Delete(ctx, route)
+}
+
+func (c *Route) List(ctx context.Context, kind, namespace string, opts meta.ListOptions) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(k8s.RouteGroupVersionResource(kind).GroupVersion().WithKind(kind + "List"))
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	err = c.kubeClient.List(ctx, list, &client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlUtil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chk/creator"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// backupStartingDeadline specifies how late backup can be started after the scheduled moment.
+// Backup checks are run with keeper status refresh, thus deadline has to cover a couple of refresh periods.
+const backupStartingDeadline = 3 * keeperStatusPeriod
+
+// backupKeeper takes backup of the keeper data from the raft leader in case backup is due according to the schedule
+func (w *worker) backupKeeper(ctx context.Context, _chk *apiChk.ClickHouseKeeperInstallation) {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return
+	}
+
+	chk, err := w.createCRFromObjectMeta(_chk, true, commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(_chk).F().Warning("unable to get CR to backup keeper. err: %v", err)
+		return
+	}
+
+	backup := chk.GetSpecT().GetBackup()
+	switch {
+	case !backup.HasSchedule():
+		return
+	case chk.IsStopped(), chk.IsTroubleshoot():
+		return
+	case chk.EnsureStatus().GetStatus() != apiChk.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return
+	}
+
+	schedule, err := util.ParseCron(backup.GetSchedule())
+	if err != nil {
+		w.a.V(1).M(chk).F().Warning("unable to parse backup schedule. err: %v", err)
+		return
+	}
+	now := time.Now().UTC()
+	scheduled := schedule.Prev(now)
+	if scheduled.IsZero() || now.Sub(scheduled) > backupStartingDeadline {
+		// Backup is not due
+		return
+	}
+
+	// Backup name is derived from the scheduled moment, so each scheduled backup is taken once
+	name := creator.BackupName(chk, scheduled)
+	if err := w.createBackupJob(ctx, chk, name); err != nil {
+		w.a.V(1).M(chk).F().Warning("unable to start keeper backup: %s err: %v", name, err)
+	}
+}
+
+// createBackupJob creates job, which takes backup from the raft leader
+func (w *worker) createBackupJob(ctx context.Context, chk *apiChk.ClickHouseKeeperInstallation, name string) error {
+	var hosts []*api.Host
+	chk.WalkHosts(func(host *api.Host) error {
+		hosts = append(hosts, host)
+		return nil
+	})
+	leader := w.findRaftLeader(ctx, hosts)
+	if leader == nil {
+		return fmt.Errorf("no raft leader found")
+	}
+
+	volumeClaimTemplate, ok := chk.GetVolumeClaimTemplate(leader.Templates.GetDataVolumeClaimTemplate())
+	if !ok {
+		return fmt.Errorf("no data volume claim template specified")
+	}
+	pod, err := w.c.kube.Pod().Get(leader)
+	if err != nil {
+		return err
+	}
+
+	job := creator.CreateBackupJob(
+		chk,
+		name,
+		pod.Spec.NodeName,
+		w.c.namer.Name(interfaces.NamePVCNameByVolumeClaimTemplate, leader, volumeClaimTemplate),
+	)
+	if err := ctrlUtil.SetControllerReference(chk, job, w.c.Scheme); err != nil {
+		return err
+	}
+
+	err = w.c.Client.Create(ctx, job)
+	switch {
+	case err == nil:
+		w.a.V(1).M(chk).F().Info("keeper backup started: %s from host: %s job: %s", name, leader.GetName(), job.GetName())
+		return nil
+	case apiErrors.IsAlreadyExists(err):
+		// Backup is already taken
+		return nil
+	default:
+		return err
+	}
+}
@@ -106,6 +106,16 @@ func (w *worker) reconcileCR(ctx context.Context, old, new *apiChk.ClickHouseKee
 			log.V(2).Info("task is done")
 			return nil
 		}
+		if err := w.removeHostsFromRaftMembers(ctx, new); err != nil {
+			// Removed hosts may still be voting members, deleting them may break the quorum.
+			// Keep them and fail the reconcile, so it is retried
+			w.a.WithEvent(new, common.EventActionReconcile, common.EventReasonReconcileFailed).
+				WithStatusError(new).
+				M(new).F().
+				Error("FAILED to remove hosts from raft members of CR %s, removed hosts are kept, err: %v", util.NamespaceNameString(new), err)
+			w.markReconcileCompletedUnsuccessfully(ctx, new, err)
+			return err
+		}
 		w.clean(ctx, new)
 		w.waitForIPAddresses(ctx, new)
 		w.finalizeReconcileAndMarkCompleted(ctx, new)
@@ -130,7 +140,7 @@ func (w *worker) reconcile(ctx context.Context, cr *apiChk.ClickHouseKeeperInsta
 		return nil
 	})
 
-	if counters.AddOnly() {
+	if counters.AddOnly() || w.isRaftQuorumUnavailable(cr) {
 		w.a.V(1).M(cr).Info("Enabling full fan-out mode. CHI: %s", util.NamespaceNameString(cr))
 		ctx = context.WithValue(ctx, common.ReconcileShardsAndHostsOptionsCtxKey, &common.ReconcileShardsAndHostsOptions{
 			FullFanOut: true,
@@ -476,6 +486,9 @@ func (w *worker) reconcileShardWithHosts(ctx context.Context, shard api.IShard)
 	if err := w.reconcileShard(ctx, shard); err != nil {
 		return err
 	}
+	if w.shouldManageRaftQuorum(ctx) {
+		return w.reconcileHostsQuorumAware(ctx, shard)
+	}
 	return shard.WalkHostsAbortOnError(func(host *api.Host) error {
 		return w.reconcileHost(ctx, host)
 	})
@@ -627,5 +640,13 @@ func (w *worker) reconcileHostBootstrap(ctx context.Context, host *api.Host) err
 		return err
 	}
 
+	if err := w.addHostToRaftMembers(ctx, host); err != nil {
+		metrics.HostReconcilesErrors(ctx, host.GetCR())
+		w.a.V(1).
+			M(host).F().
+			Warning("Reconcile Host interrupted with an error 5. Host: %s Err: %v", host.GetName(), err)
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,109 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// keeperStatusPeriod specifies how often keeper status is refreshed
+const keeperStatusPeriod = 1 * time.Minute
+
+// updateKeeperStatus polls each keeper and publishes health of the keeper cluster in the CR status
+func (w *worker) updateKeeperStatus(ctx context.Context, _chk *apiChk.ClickHouseKeeperInstallation) {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return
+	}
+
+	chk, err := w.createCRFromObjectMeta(_chk, true, commonNormalizer.NewOptions())
+	if err != nil {
+		w.a.V(1).M(_chk).F().Warning("unable to get CR to update keeper status. err: %v", err)
+		return
+	}
+
+	switch {
+	case chk.IsStopped(), chk.IsTroubleshoot():
+		return
+	case chk.EnsureStatus().GetStatus() != apiChk.StatusCompleted:
+		// Do not interfere with reconcile in progress
+		return
+	}
+
+	chk.EnsureStatus().SetKeeper(w.getKeeperStatus(ctx, chk))
+	_ = w.c.updateCRObjectStatus(ctx, chk, types.UpdateStatusOptions{
+		TolerateAbsence: true,
+		CopyStatusOptions: types.CopyStatusOptions{
+			Keeper: true,
+		},
+	})
+	w.c.updateWatch(chk)
+}
+
+// getKeeperStatus polls all keepers of the CR with four letter word commands
+func (w *worker) getKeeperStatus(ctx context.Context, chk *apiChk.ClickHouseKeeperInstallation) *apiChk.KeeperStatus {
+	var hosts []*api.Host
+	chk.WalkHosts(func(host *api.Host) error {
+		hosts = append(hosts, host)
+		return nil
+	})
+
+	stats := make([]*zookeeper.ServerStats, len(hosts))
+	wg := sync.WaitGroup{}
+	wg.Add(len(hosts))
+	for i := range hosts {
+		go func(i int) {
+			defer wg.Done()
+			s, err := zookeeper.GetServerStats(ctx, w.getRaftMemberAddress(hosts[i]))
+			if err != nil {
+				w.a.V(1).M(hosts[i]).F().Warning("unable to get keeper stats. Host: %s err: %v", hosts[i].GetName(), err)
+			}
+			stats[i] = s
+		}(i)
+	}
+	wg.Wait()
+
+	status := &apiChk.KeeperStatus{
+		QuorumHealthy: zookeeper.IsQuorumHealthy(stats),
+		Updated:       time.Now().Format(time.RFC3339),
+	}
+	for i, host := range hosts {
+		hostStatus := &apiChk.KeeperHostStatus{
+			Name: host.GetName(),
+		}
+		if s := stats[i]; s != nil {
+			hostStatus.OK = s.OK
+			hostStatus.Role = s.State
+			hostStatus.Zxid = s.Zxid
+			hostStatus.OutstandingRequests = s.OutstandingRequests
+			hostStatus.ZnodeCount = s.ZnodeCount
+		}
+		if hostStatus.Role == zookeeper.ServerStateLeader {
+			status.Leader = host.GetName()
+		}
+		status.Hosts = append(status.Hosts, hostStatus)
+	}
+	return status
+}
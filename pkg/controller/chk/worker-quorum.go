@@ -0,0 +1,144 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chk
+
+import (
+	"context"
+	"strconv"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// reconcileHostsQuorumAware reconciles hosts of the shard keeping raft quorum in place.
+// Followers are reconciled first and the leader goes last, after leadership is transferred to another member.
+// Each host has to be back and all followers have to be synced before the next host is touched.
+func (w *worker) reconcileHostsQuorumAware(ctx context.Context, shard api.IShard) error {
+	var hosts []*api.Host
+	shard.WalkHosts(func(host *api.Host) error {
+		hosts = append(hosts, host)
+		return nil
+	})
+
+	leader := w.findRaftLeader(ctx, hosts)
+	for _, host := range w.orderHostsLeaderLast(hosts, leader) {
+		if util.IsContextDone(ctx) {
+			log.V(2).Info("task is done")
+			return nil
+		}
+
+		if (host == leader) && !host.GetReconcileAttributes().IsAdd() {
+			w.transferRaftLeadership(ctx, host)
+		}
+		if err := w.reconcileHost(ctx, host); err != nil {
+			return err
+		}
+		if err := w.waitRaftQuorumHealthy(ctx, host); err != nil {
+			w.a.V(1).M(host).F().Warning("raft quorum is not healthy after host reconcile. Host: %s Err: %v", host.GetName(), err)
+			return err
+		}
+	}
+	return nil
+}
+
+// findRaftLeader finds raft leader among the hosts
+func (w *worker) findRaftLeader(ctx context.Context, hosts []*api.Host) *api.Host {
+	for _, host := range hosts {
+		if host.IsStopped() || host.GetReconcileAttributes().IsAdd() {
+			continue
+		}
+		state := w.getRaftMemberState(ctx, host)
+		w.a.V(2).M(host).F().Info("raft member state. Host: %s state: %s", host.GetName(), state)
+		if state == zookeeper.ServerStateLeader {
+			return host
+		}
+	}
+	return nil
+}
+
+// orderHostsLeaderLast orders hosts so followers go first and the leader goes last
+func (w *worker) orderHostsLeaderLast(hosts []*api.Host, leader *api.Host) []*api.Host {
+	ordered := make([]*api.Host, 0, len(hosts))
+	for _, host := range hosts {
+		if host != leader {
+			ordered = append(ordered, host)
+		}
+	}
+	if leader != nil {
+		ordered = append(ordered, leader)
+	}
+	return ordered
+}
+
+// transferRaftLeadership asks the leader to yield leadership and waits for it to become a follower.
+// Failure to transfer leadership is not fatal - the cluster re-elects the leader on its own once the host restarts.
+func (w *worker) transferRaftLeadership(ctx context.Context, leader *api.Host) {
+	if leader.GetCR().HostsCount() < 2 {
+		// No one to transfer leadership to
+		return
+	}
+
+	w.a.V(1).M(leader).F().Info("transfer raft leadership from host: %s", leader.GetName())
+	if err := zookeeper.YieldLeadership(ctx, w.getRaftMemberAddress(leader)); err != nil {
+		w.a.V(1).M(leader).F().Warning("unable to yield raft leadership. Host: %s Err: %v", leader.GetName(), err)
+		return
+	}
+
+	err := w.pollRaft(ctx, leader.GetName(), func(ctx context.Context) bool {
+		return w.getRaftMemberState(ctx, leader) == zookeeper.ServerStateFollower
+	})
+	if err != nil {
+		w.a.V(1).M(leader).F().Warning("raft leadership is not transferred. Host: %s Err: %v", leader.GetName(), err)
+	}
+}
+
+// waitRaftQuorumHealthy waits for the host to be back in raft cluster and for the followers to be synced with the leader
+func (w *worker) waitRaftQuorumHealthy(ctx context.Context, host *api.Host) error {
+	if host.IsStopped() {
+		return nil
+	}
+
+	return w.pollRaft(ctx, host.GetName(), func(ctx context.Context) bool {
+		switch w.getRaftMemberState(ctx, host) {
+		case zookeeper.ServerStateLeader, zookeeper.ServerStateFollower, zookeeper.ServerStateStandalone:
+		default:
+			w.a.V(1).M(host).F().Info("raft member is not back yet. Host: %s", host.GetName())
+			return false
+		}
+		return w.isRaftFollowersSynced(ctx, host.GetCR())
+	})
+}
+
+// isRaftFollowersSynced checks whether all followers are synced, as reported by the leader
+func (w *worker) isRaftFollowersSynced(ctx context.Context, cr api.ICustomResource) bool {
+	synced := false
+	cr.WalkHosts(func(host *api.Host) error {
+		if host.IsStopped() || host.GetReconcileAttributes().IsAdd() {
+			return nil
+		}
+		mntr, err := zookeeper.Mntr(ctx, w.getRaftMemberAddress(host))
+		if err != nil || (mntr["zk_server_state"] != zookeeper.ServerStateLeader) {
+			return nil
+		}
+		followers, _ := strconv.Atoi(mntr["zk_followers"])
+		syncedFollowers, _ := strconv.Atoi(mntr["zk_synced_followers"])
+		w.a.V(1).M(host).F().Info("raft leader: %s followers: %d synced followers: %d", host.GetName(), followers, syncedFollowers)
+		synced = syncedFollowers >= followers
+		return nil
+	})
+	return synced
+}
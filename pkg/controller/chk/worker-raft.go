@@ -0,0 +1,269 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common"
+	"github.com/altinity/clickhouse-operator/pkg/controller/common/poller"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chk/config"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// shouldManageRaftQuorum determines whether raft membership and quorum are to be managed by the operator.
+// Full fan-out means the whole cluster is brand new and static raft configuration is enough.
+func (w *worker) shouldManageRaftQuorum(ctx context.Context) bool {
+	if opts, ok := ctx.Value(common.ReconcileShardsAndHostsOptionsCtxKey).(*common.ReconcileShardsAndHostsOptions); ok {
+		return !opts.FullFanOut
+	}
+	return true
+}
+
+// isRaftQuorumUnavailable checks whether raft quorum is not expected to be available neither before nor during reconcile.
+// Stopped CR has no keeper running and troubleshoot mode has keeper process replaced with sleep,
+// so hosts are to be reconciled all together, same as on the way back from any of these modes.
+func (w *worker) isRaftQuorumUnavailable(cr *apiChk.ClickHouseKeeperInstallation) bool {
+	switch {
+	case cr.IsStopped(), cr.IsTroubleshoot():
+		return true
+	case cr.HasAncestor() && (cr.GetAncestorT().IsStopped() || cr.GetAncestorT().IsTroubleshoot()):
+		return true
+	}
+	return false
+}
+
+// addHostToRaftMembers adds host into raft cluster with keeper 'reconfig' command and waits for the new member to sync
+func (w *worker) addHostToRaftMembers(ctx context.Context, host *api.Host) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	if !w.shouldManageRaftQuorum(ctx) || !w.shouldIncludeHost(host) {
+		return nil
+	}
+
+	// Members to talk to are all hosts which are already up and running, except the one being added
+	var nodes api.ZookeeperNodes
+	host.GetCR().WalkHosts(func(h *api.Host) error {
+		if (h != host) && !h.IsStopped() && !h.GetReconcileAttributes().IsAdd() {
+			nodes = append(nodes, w.getRaftMemberNode(h))
+		}
+		return nil
+	})
+	if len(nodes) == 0 {
+		// Single-member cluster, nothing to reconfigure
+		return nil
+	}
+
+	conn := zookeeper.NewConnection(nodes)
+	defer conn.Close()
+
+	servers, err := conn.GetKeeperServers(ctx)
+	if err != nil {
+		w.a.V(1).M(host).F().Warning("unable to get raft members. Host: %s Err: %v", host.GetName(), err)
+		return err
+	}
+
+	member := w.newRaftMember(host)
+	if _, found := servers[member.ID]; !found {
+		w.a.V(1).M(host).F().Info("add raft member: %s", member.Raw)
+		if _, err := conn.IncrementalReconfig(ctx, []string{member.Raw}, nil); err != nil {
+			w.a.V(1).M(host).F().Error("unable to add raft member: %s Err: %v", member.Raw, err)
+			return err
+		}
+	}
+
+	// Wait for the leader to commit the new configuration and for the new member to catch up with it
+	return w.pollRaft(ctx, host.GetName(), func(ctx context.Context) bool {
+		servers, err := conn.GetKeeperServers(ctx)
+		if err != nil {
+			return false
+		}
+		if _, found := servers[member.ID]; !found {
+			w.a.V(1).M(host).F().Info("raft member is not acknowledged yet: %s", member.Raw)
+			return false
+		}
+		state := w.getRaftMemberState(ctx, host)
+		w.a.V(1).M(host).F().Info("raft member: %s state: %s", member.Raw, state)
+		return (state == zookeeper.ServerStateFollower) || (state == zookeeper.ServerStateLeader)
+	})
+}
+
+// removeHostsFromRaftMembers removes raft members which are no longer specified in the CR.
+// Members are removed one by one, followers go first and leader goes last, so the quorum is kept all the time.
+func (w *worker) removeHostsFromRaftMembers(ctx context.Context, cr *apiChk.ClickHouseKeeperInstallation) error {
+	if util.IsContextDone(ctx) {
+		log.V(2).Info("task is done")
+		return nil
+	}
+
+	if cr.IsTroubleshoot() {
+		// Keeper process is not running, no one to talk to
+		return nil
+	}
+
+	// Members which remain in the cluster
+	var nodes api.ZookeeperNodes
+	remain := make(map[int]bool)
+	cr.WalkHosts(func(host *api.Host) error {
+		remain[config.GetServerId(host)] = true
+		if !host.IsStopped() {
+			nodes = append(nodes, w.getRaftMemberNode(host))
+		}
+		return nil
+	})
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	conn := zookeeper.NewConnection(nodes)
+	defer conn.Close()
+
+	servers, err := conn.GetKeeperServers(ctx)
+	if err != nil {
+		w.a.V(1).M(cr).F().Warning("unable to get raft members. Err: %v", err)
+		return err
+	}
+
+	// Members which have to leave the cluster
+	var leaving []*zookeeper.KeeperServer
+	for id, server := range servers {
+		if !remain[id] {
+			leaving = append(leaving, server)
+		}
+	}
+	if len(leaving) == 0 {
+		return nil
+	}
+
+	// Followers first, leader last
+	leaders := make(map[int]bool)
+	for _, server := range leaving {
+		address := net.JoinHostPort(w.getLeavingRaftMemberHostname(cr, server), strconv.Itoa(int(w.getLeavingRaftMemberZKPort(cr, server))))
+		leaders[server.ID] = zookeeper.GetServerState(ctx, address) == zookeeper.ServerStateLeader
+	}
+	sort.SliceStable(leaving, func(i, j int) bool {
+		if leaders[leaving[i].ID] != leaders[leaving[j].ID] {
+			return leaders[leaving[j].ID]
+		}
+		return leaving[i].ID > leaving[j].ID
+	})
+
+	for _, server := range leaving {
+		if len(servers) <= 1 {
+			w.a.V(1).M(cr).F().Warning("refuse to remove the last raft member: %s", server.Raw)
+			return fmt.Errorf("refuse to remove the last raft member: %s", server.Raw)
+		}
+
+		w.a.V(1).M(cr).F().Info("remove raft member: %s", server.Raw)
+		if _, err := conn.IncrementalReconfig(ctx, nil, []string{strconv.Itoa(server.ID)}); err != nil {
+			w.a.V(1).M(cr).F().Error("unable to remove raft member: %s Err: %v", server.Raw, err)
+			return err
+		}
+
+		// Wait for the leader to commit the new configuration before proceeding with the next member
+		err := w.pollRaft(ctx, cr.GetName(), func(ctx context.Context) bool {
+			var err error
+			servers, err = conn.GetKeeperServers(ctx)
+			if err != nil {
+				return false
+			}
+			_, found := servers[server.ID]
+			return !found
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newRaftMember creates raft member description of the host
+func (w *worker) newRaftMember(host *api.Host) *zookeeper.KeeperServer {
+	return zookeeper.NewKeeperServer(
+		config.GetServerId(host),
+		w.c.namer.Name(interfaces.NameInstanceHostname, host),
+		int(host.RaftPort.Value()),
+	)
+}
+
+// getRaftMemberNode creates zookeeper node to connect to the host
+func (w *worker) getRaftMemberNode(host *api.Host) api.ZookeeperNode {
+	return api.ZookeeperNode{
+		Host: w.c.namer.Name(interfaces.NameFQDN, host),
+		Port: types.NewInt32(host.ZKPort.Value()),
+	}
+}
+
+// getRaftMemberState gets state of the host as reported by four letter word commands
+func (w *worker) getRaftMemberState(ctx context.Context, host *api.Host) string {
+	return zookeeper.GetServerState(ctx, w.getRaftMemberAddress(host))
+}
+
+// getRaftMemberAddress gets client address of the host to send four letter word commands to
+func (w *worker) getRaftMemberAddress(host *api.Host) string {
+	return net.JoinHostPort(w.c.namer.Name(interfaces.NameFQDN, host), strconv.Itoa(int(host.ZKPort.Value())))
+}
+
+// getLeavingRaftMemberHostname gets hostname of the leaving member, looking it up in the ancestor CR
+func (w *worker) getLeavingRaftMemberHostname(cr *apiChk.ClickHouseKeeperInstallation, server *zookeeper.KeeperServer) string {
+	if host := w.findAncestorHostByServerId(cr, server.ID); host != nil {
+		return w.c.namer.Name(interfaces.NameFQDN, host)
+	}
+	return server.Hostname
+}
+
+// getLeavingRaftMemberZKPort gets client port of the leaving member, looking it up in the ancestor CR
+func (w *worker) getLeavingRaftMemberZKPort(cr *apiChk.ClickHouseKeeperInstallation, server *zookeeper.KeeperServer) int32 {
+	if host := w.findAncestorHostByServerId(cr, server.ID); host != nil {
+		return host.ZKPort.Value()
+	}
+	return api.KpDefaultZKPortNumber
+}
+
+func (w *worker) findAncestorHostByServerId(cr *apiChk.ClickHouseKeeperInstallation, id int) (found *api.Host) {
+	cr.GetAncestorT().WalkHosts(func(host *api.Host) error {
+		if config.GetServerId(host) == id {
+			found = host
+		}
+		return nil
+	})
+	return found
+}
+
+// pollRaft polls raft cluster till isDone reports completion
+func (w *worker) pollRaft(ctx context.Context, name string, isDone func(ctx context.Context) bool) error {
+	return poller.New(ctx, fmt.Sprintf("raft/%s", name)).
+		WithOptions(poller.NewOptions().FromConfig(chop.Config())).
+		WithMain(&poller.Functions{
+			IsDone: func(_ctx context.Context, _ any) bool {
+				return isDone(_ctx)
+			},
+		}).Poll()
+}
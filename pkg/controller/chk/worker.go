@@ -74,7 +74,7 @@ func (c *Controller) newWorker() *worker {
 		c: c,
 		a: announcer,
 
-		normalizer: normalizer.New(),
+		normalizer: normalizer.New(c.getTemplates),
 		start:      start,
 		task:       nil,
 	}
@@ -96,6 +96,8 @@ func (w *worker) newTask(cr *apiChk.ClickHouseKeeperInstallation) {
 			managers.NewTagManager(managers.TagManagerTypeKeeper, cr),
 			managers.NewProbeManager(managers.ProbeManagerTypeKeeper),
 			managers.NewServiceManager(managers.ServiceManagerTypeKeeper),
+			// Keeper is not exposed outside of k8s cluster
+			nil,
 			managers.NewVolumeManager(managers.VolumeManagerTypeKeeper),
 			managers.NewConfigMapManager(managers.ConfigMapManagerTypeKeeper),
 			managers.NewNameManager(managers.NameManagerTypeKeeper),
@@ -167,7 +169,7 @@ func (w *worker) shouldForceRestartHost(host *api.Host) bool {
 
 // normalize
 func (w *worker) normalize(c *apiChk.ClickHouseKeeperInstallation) *apiChk.ClickHouseKeeperInstallation {
-	chk, err := normalizer.New().CreateTemplated(c, commonNormalizer.NewOptions())
+	chk, err := normalizer.New(w.c.getTemplates).CreateTemplated(c, commonNormalizer.NewOptions())
 	if err != nil {
 		w.a.WithEvent(chk, common.EventActionReconcile, common.EventReasonReconcileFailed).
 			WithStatusError(chk).
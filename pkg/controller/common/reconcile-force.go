@@ -0,0 +1,35 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import "context"
+
+// ReconcileForceCtxKeyType specifies type for ReconcileForceCtxKey
+type ReconcileForceCtxKeyType string
+
+// ReconcileForceCtxKey specifies name of the key to be used to mark reconcile as forced
+const ReconcileForceCtxKey ReconcileForceCtxKeyType = "ReconcileForce"
+
+// WithReconcileForce marks reconcile as forced.
+// Forced reconcile runs even in case CR has nothing changed since the last completed reconcile.
+func WithReconcileForce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ReconcileForceCtxKey, true)
+}
+
+// IsReconcileForced checks whether reconcile is forced
+func IsReconcileForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(ReconcileForceCtxKey).(bool)
+	return forced
+}
@@ -28,6 +28,8 @@ const (
 
 	AnnotatePDB AnnotateType = "annotate pdb"
 
+	AnnotateNetworkPolicy AnnotateType = "annotate network policy"
+
 	AnnotateSTS AnnotateType = "annotate STS"
 
 	AnnotatePodTemplate AnnotateType = "annotate PodTemplate"
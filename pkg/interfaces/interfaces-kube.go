@@ -19,17 +19,23 @@ import (
 
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	policy "k8s.io/api/policy/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
 )
 
 type IKube interface {
 	CR() IKubeCR
+	Certificate() IKubeCertificate
 	ConfigMap() IKubeConfigMap
 	Deployment() IKubeDeployment
+	NetworkPolicy() IKubeNetworkPolicy
+	Node() IKubeNode
 	PDB() IKubePDB
 	Event() IKubeEvent
 	Pod() IKubePod
@@ -37,9 +43,19 @@ type IKube interface {
 	ReplicaSet() IKubeReplicaSet
 	Secret() IKubeSecret
 	Service() IKubeService
+	Ingress() IKubeIngress
+	Keeper() IKubeKeeper
+	Route() IKubeRoute
 	STS() IKubeSTS
 }
 
+type IKubeCertificate interface {
+	Get(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error)
+	Create(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	Update(ctx context.Context, certificate *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	Delete(ctx context.Context, namespace, name string) error
+}
+
 type IKubeConfigMap interface {
 	Create(ctx context.Context, cm *core.ConfigMap) (*core.ConfigMap, error)
 	Get(ctx context.Context, namespace, name string) (*core.ConfigMap, error)
@@ -57,6 +73,14 @@ type IKubeEvent interface {
 	Create(ctx context.Context, event *core.Event) (*core.Event, error)
 }
 
+type IKubeNetworkPolicy interface {
+	Create(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error)
+	Get(ctx context.Context, namespace, name string) (*networking.NetworkPolicy, error)
+	Update(ctx context.Context, networkPolicy *networking.NetworkPolicy) (*networking.NetworkPolicy, error)
+	Delete(ctx context.Context, namespace, name string) error
+	List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.NetworkPolicy, error)
+}
+
 type IKubePDB interface {
 	Create(ctx context.Context, pdb *policy.PodDisruptionBudget) (*policy.PodDisruptionBudget, error)
 	Get(ctx context.Context, namespace, name string) (*policy.PodDisruptionBudget, error)
@@ -65,6 +89,16 @@ type IKubePDB interface {
 	List(ctx context.Context, namespace string, opts meta.ListOptions) ([]policy.PodDisruptionBudget, error)
 }
 
+type IKubeKeeper interface {
+	Get(ctx context.Context, namespace, name string) (*apiChk.ClickHouseKeeperInstallation, error)
+	Create(ctx context.Context, chk *apiChk.ClickHouseKeeperInstallation) (*apiChk.ClickHouseKeeperInstallation, error)
+	ListTemplates(ctx context.Context) ([]*apiChk.ClickHouseKeeperInstallation, error)
+}
+
+type IKubeNode interface {
+	Get(ctx context.Context, name string) (*core.Node, error)
+}
+
 type IKubePod interface {
 	Get(params ...any) (*core.Pod, error)
 	GetAll(obj any) []*core.Pod
@@ -111,6 +145,22 @@ type IKubeService interface {
 	List(ctx context.Context, namespace string, opts meta.ListOptions) ([]core.Service, error)
 }
 
+type IKubeIngress interface {
+	Get(ctx context.Context, namespace, name string) (*networking.Ingress, error)
+	Create(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error)
+	Update(ctx context.Context, ingress *networking.Ingress) (*networking.Ingress, error)
+	Delete(ctx context.Context, namespace, name string) error
+	List(ctx context.Context, namespace string, opts meta.ListOptions) ([]networking.Ingress, error)
+}
+
+type IKubeRoute interface {
+	Get(ctx context.Context, kind, namespace, name string) (*unstructured.Unstructured, error)
+	Create(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	Update(ctx context.Context, route *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	Delete(ctx context.Context, kind, namespace, name string) error
+	List(ctx context.Context, kind, namespace string, opts meta.ListOptions) ([]unstructured.Unstructured, error)
+}
+
 type IKubeSTS interface {
 	Get(ctx context.Context, params ...any) (*apps.StatefulSet, error)
 	Create(ctx context.Context, statefulSet *apps.StatefulSet) (*apps.StatefulSet, error)
@@ -17,8 +17,10 @@ package interfaces
 import (
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
 	policy "k8s.io/api/policy/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 )
@@ -74,6 +76,7 @@ type IContainerManager interface {
 	GetAppContainer(statefulSet *apps.StatefulSet) (*core.Container, bool)
 	EnsureAppContainer(statefulSet *apps.StatefulSet, host *api.Host)
 	EnsureLogContainer(statefulSet *apps.StatefulSet)
+	SetupTroubleshootCommand(container *core.Container)
 }
 
 type IProbeManager interface {
@@ -86,9 +89,17 @@ type IServiceManager interface {
 	SetTagger(tagger ITagger)
 }
 
+type IIngressManager interface {
+	CreateIngress(what IngressType, params ...any) *networking.Ingress
+	CreateRoute(what IngressType, params ...any) *unstructured.Unstructured
+	SetCR(cr api.ICustomResource)
+	SetTagger(tagger ITagger)
+}
+
 type ICreator interface {
 	CreateConfigMap(what ConfigMapType, params ...any) *core.ConfigMap
 	CreatePodDisruptionBudget(cluster api.ICluster) *policy.PodDisruptionBudget
+	CreateNetworkPolicy(policy *api.ChiNetworkPolicy) *networking.NetworkPolicy
 	CreatePVC(
 		name string,
 		namespace string,
@@ -101,7 +112,10 @@ type ICreator interface {
 		template *api.VolumeClaimTemplate,
 	) *core.PersistentVolumeClaim
 	CreateClusterSecret(name string) *core.Secret
+	CreateZookeeperIdentitySecret(name string, identities map[string]string) *core.Secret
 	CreateService(what ServiceType, params ...any) *core.Service
+	CreateIngress(what IngressType, params ...any) *networking.Ingress
+	CreateRoute(what IngressType, params ...any) *unstructured.Unstructured
 	CreateStatefulSet(host *api.Host, shutdown bool) *apps.StatefulSet
 }
 
@@ -34,6 +34,8 @@ const (
 
 	LabelPDB LabelType = "Label pdb"
 
+	LabelNetworkPolicy LabelType = "Label network policy"
+
 	LabelSTS LabelType = "Label STS"
 
 	LabelPodTemplate LabelType = "Label PodTemplate"
@@ -40,4 +40,10 @@ const (
 	NamePod                          NameType = "NamePod"
 	NamePVCNameByVolumeClaimTemplate NameType = "NamePVCNameByVolumeClaimTemplate"
 	NameClusterAutoSecret            NameType = "NameClusterAutoSecret"
+	NameIngress                      NameType = "NameIngress"
+	NameRoute                        NameType = "NameRoute"
+	NameServiceFQDN                  NameType = "NameServiceFQDN"
+	NameTLSSecret                    NameType = "NameTLSSecret"
+	NameNetworkPolicy                NameType = "NameNetworkPolicy"
+	NameZookeeperIdentitySecret      NameType = "NameZookeeperIdentitySecret"
 )
@@ -17,8 +17,11 @@ package clickhouse
 import (
 	"context"
 	"database/sql"
+	"fmt"
+
 	"github.com/MakeNowJust/heredoc"
 
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/model/clickhouse"
 	"github.com/altinity/clickhouse-operator/pkg/util"
 )
@@ -28,10 +31,54 @@ const (
 		SELECT
 			database,
 			table,
-			toString(is_session_expired) AS is_session_expired
+			toString(is_session_expired)                       AS is_session_expired,
+			toString(is_readonly)                              AS is_readonly,
+			toString(absolute_delay)                           AS absolute_delay,
+			toString(queue_size)                               AS queue_size,
+			toString(inserts_in_queue)                         AS inserts_in_queue,
+			toString(merges_in_queue)                          AS merges_in_queue,
+			toString(greatest(log_max_index - log_pointer, 0)) AS log_entries_behind,
+			toString(active_replicas)                          AS active_replicas,
+			toString(total_replicas)                           AS total_replicas
 		FROM system.replicas
 	`
 
+	queryReplicationQueueSQL = `
+		SELECT
+			database,
+			table,
+			toString(count())                                     AS entries,
+			toString(dateDiff('second', min(create_time), now())) AS oldest_entry_age,
+			toString(countIf(num_tries > 1))                      AS entries_retried,
+			toString(countIf(last_exception != ''))               AS entries_failed
+		FROM system.replication_queue
+		GROUP BY database, table
+	`
+
+	// queryLogSQL aggregates query_log entries by query kind, user, exception code and duration bucket.
+	// Entries are read from the period (since, till], where till is delayed in order to let query_log be flushed.
+	// Bound parameters are: delay in seconds, since as unix timestamp, max window in seconds, buckets upper bounds.
+	// Bucket index 0 means duration is above all upper bounds.
+	queryLogSQL = `
+		WITH
+			now() - toIntervalSecond(?)                                   AS query_log_till,
+			greatest(toDateTime(?), query_log_till - toIntervalSecond(?)) AS query_log_since
+		SELECT
+			toString(toUnixTimestamp(query_log_till))                               AS till,
+			toString(query_kind)                                                    AS kind,
+			user,
+			toString(exception_code)                                                AS exception_code,
+			toString(arrayFirstIndex(b -> (query_duration_ms <= (b * 1000)), ?))    AS bucket,
+			toString(count())                                                       AS queries,
+			toString(sum(query_duration_ms) / 1000)                                 AS duration
+		FROM system.query_log
+		WHERE (event_date >= toDate(query_log_since))
+			AND (event_time > query_log_since)
+			AND (event_time <= query_log_till)
+			AND (type != 'QueryStart')
+		GROUP BY kind, user, exception_code, bucket
+	`
+
 	queryMetricsSQL = `
     	SELECT
         	concat('metric.', metric) AS metric,
@@ -194,9 +241,31 @@ func (f *ClickHouseMetricsFetcher) getClickHouseQuerySystemReplicas(ctx context.
 		ctx,
 		querySystemReplicasSQL,
 		func(rows *sql.Rows, data *Table) error {
-			var database, table, isSessionExpired string
-			if err := rows.Scan(&database, &table, &isSessionExpired); err == nil {
-				*data = append(*data, []string{database, table, isSessionExpired})
+			var database, table, isSessionExpired, isReadonly, absoluteDelay, queueSize, insertsInQueue, mergesInQueue,
+				logEntriesBehind, activeReplicas, totalReplicas string
+			if err := rows.Scan(
+				&database, &table, &isSessionExpired, &isReadonly, &absoluteDelay, &queueSize, &insertsInQueue, &mergesInQueue,
+				&logEntriesBehind, &activeReplicas, &totalReplicas,
+			); err == nil {
+				*data = append(*data, []string{
+					database, table, isSessionExpired, isReadonly, absoluteDelay, queueSize, insertsInQueue, mergesInQueue,
+					logEntriesBehind, activeReplicas, totalReplicas,
+				})
+			}
+			return nil
+		},
+	)
+}
+
+// getClickHouseQueryReplicationQueue requests replication queue information from ClickHouse
+func (f *ClickHouseMetricsFetcher) getClickHouseQueryReplicationQueue(ctx context.Context) (Table, error) {
+	return f.clickHouseQueryScanRows(
+		ctx,
+		queryReplicationQueueSQL,
+		func(rows *sql.Rows, data *Table) error {
+			var database, table, entries, oldestEntryAge, entriesRetried, entriesFailed string
+			if err := rows.Scan(&database, &table, &entries, &oldestEntryAge, &entriesRetried, &entriesFailed); err == nil {
+				*data = append(*data, []string{database, table, entries, oldestEntryAge, entriesRetried, entriesFailed})
 			}
 			return nil
 		},
@@ -248,6 +317,96 @@ func (f *ClickHouseMetricsFetcher) getClickHouseQueryDetachedParts(ctx context.C
 	)
 }
 
+// getClickHouseQueryLog requests query durations aggregated into buckets from ClickHouse
+// Expected data structure: till, kind, user, exception code, bucket, queries, duration
+func (f *ClickHouseMetricsFetcher) getClickHouseQueryLog(
+	ctx context.Context,
+	since int64,
+	config *api.OperatorConfigQueryLogMetrics,
+) (Table, error) {
+	return f.clickHouseQueryScanRows(
+		ctx,
+		queryLogSQL,
+		func(rows *sql.Rows, data *Table) error {
+			var till, kind, user, exceptionCode, bucket, queries, duration string
+			if err := rows.Scan(&till, &kind, &user, &exceptionCode, &bucket, &queries, &duration); err == nil {
+				*data = append(*data, []string{till, kind, user, exceptionCode, bucket, queries, duration})
+			}
+			return nil
+		},
+		int64(config.Delay.Seconds()),
+		since,
+		int64(config.Window.Seconds()),
+		clickhouse.Array(config.Buckets),
+	)
+}
+
+// getClickHouseQueryCustomMetric requests user-defined metric from ClickHouse
+// Each row of the result consists of the value followed by the label values in the order of label columns
+func (f *ClickHouseMetricsFetcher) getClickHouseQueryCustomMetric(ctx context.Context, metric *api.OperatorConfigCustomMetric) (Table, error) {
+	if metric.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, metric.Timeout)
+		defer cancel()
+	}
+
+	var indexes []int
+	return f.clickHouseQueryScanRows(
+		ctx,
+		metric.SQL,
+		func(rows *sql.Rows, data *Table) error {
+			columns, err := rows.Columns()
+			if err != nil {
+				return err
+			}
+			if indexes == nil {
+				// Positions of the value and label columns are the same for all rows
+				if indexes, err = customMetricColumnIndexes(metric, columns); err != nil {
+					return err
+				}
+			}
+
+			values := make([]sql.NullString, len(columns))
+			dest := make([]any, len(columns))
+			for i := range values {
+				dest[i] = &values[i]
+			}
+			if err := rows.Scan(dest...); err != nil {
+				return err
+			}
+
+			row := make([]string, 0, len(indexes))
+			for _, i := range indexes {
+				row = append(row, values[i].String)
+			}
+			*data = append(*data, row)
+			return nil
+		},
+	)
+}
+
+// customMetricColumnIndexes finds positions of the value and label columns of the custom metric
+func customMetricColumnIndexes(metric *api.OperatorConfigCustomMetric, columns []string) ([]int, error) {
+	find := func(column string) (int, error) {
+		for i := range columns {
+			if columns[i] == column {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("no column %s in result of the custom metric %s", column, metric.Name)
+	}
+
+	var indexes []int
+	for _, column := range append([]string{metric.ValueColumn}, metric.LabelColumns...) {
+		index, err := find(column)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
 // ScanFunction defines function to scan rows
 type ScanFunction func(rows *sql.Rows, data *Table) error
 
@@ -258,16 +417,17 @@ func newTable() Table {
 	return make(Table, 0)
 }
 
-// clickHouseQueryScanRows scan all rows by external scan function
+// clickHouseQueryScanRows scan all rows by external scan function. Optional args are bound to placeholders of the query
 func (f *ClickHouseMetricsFetcher) clickHouseQueryScanRows(
 	ctx context.Context,
 	sql string,
 	scan ScanFunction,
+	args ...any,
 ) (Table, error) {
 	if util.IsContextDone(ctx) {
 		return nil, ctx.Err()
 	}
-	query, err := f.connection().QueryContext(ctx, heredoc.Doc(sql))
+	query, err := f.connection().QueryContext(ctx, heredoc.Doc(sql), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -277,7 +437,9 @@ func (f *ClickHouseMetricsFetcher) clickHouseQueryScanRows(
 		if util.IsContextDone(ctx) {
 			return nil, ctx.Err()
 		}
-		_ = scan(query.Rows, &data)
+		if err := scan(query.Rows, &data); err != nil {
+			return nil, err
+		}
 	}
 	return data, nil
 }
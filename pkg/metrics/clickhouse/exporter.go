@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	log "github.com/golang/glog"
@@ -45,19 +46,36 @@ type Exporter struct {
 
 	// chInstallations maps CHI name to list of hostnames (of string type) of this installation
 	chInstallations chInstallationsIndex
+	// keeperInstallations maps CHK name to list of hostnames (of string type) of this installation
+	keeperInstallations chInstallationsIndex
 
 	mutex               sync.RWMutex
 	toRemoveFromWatched sync.Map
+
+	// customMetricsErrors counts failed queries of custom metrics, by customMetricErrorsKey
+	customMetricsErrors sync.Map
+	// queryLogs accumulates query durations read from query_log, by host
+	queryLogs sync.Map
+
+	// scraper scrapes hosts in background, in case background scraping is enabled
+	scraper *hostScraper
 }
 
 // Type compatibility
 var _ prometheus.Collector = &Exporter{}
 
+// customMetricErrorsKey identifies counter of failed queries of the custom metric on the host
+type customMetricErrorsKey struct {
+	host   string
+	metric string
+}
+
 // NewExporter returns a new instance of Exporter type
 func NewExporter(collectorTimeout time.Duration) *Exporter {
 	return &Exporter{
-		chInstallations:  make(map[string]*metrics.WatchedCHI),
-		collectorTimeout: collectorTimeout,
+		chInstallations:     make(map[string]*metrics.WatchedCHI),
+		keeperInstallations: make(map[string]*metrics.WatchedCHI),
+		collectorTimeout:    collectorTimeout,
 	}
 }
 
@@ -94,16 +112,37 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	log.V(1).Infof("Launching host collectors [%s]", time.Now().Sub(start))
 
 	var wg = sync.WaitGroup{}
-	e.chInstallations.walk(func(chi *metrics.WatchedCHI, _ *metrics.WatchedCluster, host *metrics.WatchedHost) {
+	if e.scraper != nil {
+		// Hosts are scraped in background, serve cached results
+		e.scraper.collect(ch)
+	} else {
+		e.chInstallations.walk(func(chi *metrics.WatchedCHI, _ *metrics.WatchedCluster, host *metrics.WatchedHost) {
+			wg.Add(1)
+			go func(ctx context.Context, chi *metrics.WatchedCHI, host *metrics.WatchedHost, ch chan<- prometheus.Metric) {
+				defer wg.Done()
+				e.collectHostMetrics(ctx, chi, host, ch)
+			}(ctx, chi, host, ch)
+		})
+	}
+	for _, chk := range e.keeperInstallations.slice() {
 		wg.Add(1)
-		go func(ctx context.Context, chi *metrics.WatchedCHI, host *metrics.WatchedHost, ch chan<- prometheus.Metric) {
+		go func(ctx context.Context, chk *metrics.WatchedCHI, ch chan<- prometheus.Metric) {
 			defer wg.Done()
-			e.collectHostMetrics(ctx, chi, host, ch)
-		}(ctx, chi, host, ch)
-	})
+			e.collectKeeperMetrics(ctx, chk, ch)
+		}(ctx, chk, ch)
+	}
 	wg.Wait()
 }
 
+// StartBackgroundScrape starts background scraping of the watched hosts with the specified interval.
+// Collect serves cached results afterwards.
+func (e *Exporter) StartBackgroundScrape(ctx context.Context, interval time.Duration) {
+	e.mutex.Lock()
+	e.scraper = newHostScraper(e, interval, e.collectorTimeout)
+	e.mutex.Unlock()
+	go e.scraper.run(ctx)
+}
+
 // Describe implements prometheus.Collector Describe method
 func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	prometheus.DescribeByCollect(e, ch)
@@ -127,15 +166,42 @@ func (e *Exporter) cleanup() {
 		}
 		return true
 	})
+	e.cleanupCustomMetricsErrors()
 	log.V(2).Info("Completed cleanup")
 }
 
+// cleanupCustomMetricsErrors forgets failed queries counters of custom metrics no longer configured,
+// thus counters do not accumulate over reloads of the config
+func (e *Exporter) cleanupCustomMetricsErrors() {
+	configured := make(map[string]bool)
+	for i := range chop.Config().ClickHouse.Metrics.Custom {
+		configured[chop.Config().ClickHouse.Metrics.Custom[i].Name] = true
+	}
+	e.customMetricsErrors.Range(func(key, _ interface{}) bool {
+		if !configured[key.(customMetricErrorsKey).metric] {
+			e.customMetricsErrors.Delete(key)
+		}
+		return true
+	})
+}
+
 // removeFromWatched deletes record from Exporter.chInstallation map identified by chiName key
 func (e *Exporter) removeFromWatched(chi *metrics.WatchedCHI) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 	log.V(1).Infof("Remove ClickHouseInstallation (%s/%s)", chi.Namespace, chi.Name)
 	e.chInstallations.remove(chi.IndexKey())
+	hosts := make(map[string]bool)
+	chi.WalkHosts(func(_ *metrics.WatchedCHI, _ *metrics.WatchedCluster, host *metrics.WatchedHost) {
+		e.queryLogs.Delete(host.Hostname)
+		hosts[host.Hostname] = true
+	})
+	e.customMetricsErrors.Range(func(key, _ interface{}) bool {
+		if hosts[key.(customMetricErrorsKey).host] {
+			e.customMetricsErrors.Delete(key)
+		}
+		return true
+	})
 }
 
 // updateWatched updates Exporter.chInstallation map with values from chInstances slice
@@ -170,13 +236,14 @@ func (e *Exporter) newHostFetcher(host *metrics.WatchedHost) *ClickHouseMetricsF
 	return NewClickHouseFetcher(clusterConnectionParams.NewEndpointConnectionParams(host.Hostname))
 }
 
-// collectHostMetrics collects metrics from one host and writes them into chan
-func (e *Exporter) collectHostMetrics(ctx context.Context, chi *metrics.WatchedCHI, host *metrics.WatchedHost, c chan<- prometheus.Metric) {
+// collectHostMetrics collects metrics from one host and writes them into chan.
+// Returns whether host responded, i.e. any of the fetches succeeded.
+func (e *Exporter) collectHostMetrics(ctx context.Context, chi *metrics.WatchedCHI, host *metrics.WatchedHost, c chan<- prometheus.Metric) bool {
 	fetcher := e.newHostFetcher(host)
 	writer := NewCHIPrometheusWriter(c, chi, host)
 
 	wg := sync.WaitGroup{}
-	wg.Add(6)
+	wg.Add(7)
 	go func(ctx context.Context, host *metrics.WatchedHost, fetcher *ClickHouseMetricsFetcher, writer *CHIPrometheusWriter) {
 		e.collectHostSystemMetrics(ctx, host, fetcher, writer)
 		wg.Done()
@@ -189,6 +256,10 @@ func (e *Exporter) collectHostMetrics(ctx context.Context, chi *metrics.WatchedC
 		e.collectHostSystemReplicasMetrics(ctx, host, fetcher, writer)
 		wg.Done()
 	}(ctx, host, fetcher, writer)
+	go func(ctx context.Context, host *metrics.WatchedHost, fetcher *ClickHouseMetricsFetcher, writer *CHIPrometheusWriter) {
+		e.collectHostReplicationQueueMetrics(ctx, host, fetcher, writer)
+		wg.Done()
+	}(ctx, host, fetcher, writer)
 	go func(ctx context.Context, host *metrics.WatchedHost, fetcher *ClickHouseMetricsFetcher, writer *CHIPrometheusWriter) {
 		e.collectHostMutationsMetrics(ctx, host, fetcher, writer)
 		wg.Done()
@@ -201,7 +272,26 @@ func (e *Exporter) collectHostMetrics(ctx context.Context, chi *metrics.WatchedC
 		e.collectHostDetachedPartsMetrics(ctx, host, fetcher, writer)
 		wg.Done()
 	}(ctx, host, fetcher, writer)
+	if chop.Config().ClickHouse.Metrics.QueryLog.IsEnabled() {
+		wg.Add(1)
+		go func(ctx context.Context, host *metrics.WatchedHost, fetcher *ClickHouseMetricsFetcher, writer *CHIPrometheusWriter) {
+			e.collectHostQueryLogMetrics(ctx, host, fetcher, writer)
+			wg.Done()
+		}(ctx, host, fetcher, writer)
+	}
+	for i := range chop.Config().ClickHouse.Metrics.Custom {
+		metric := &chop.Config().ClickHouse.Metrics.Custom[i]
+		if !metric.Selector.Matches(chi.GetNamespace(), chi.GetName(), chi.GetLabels()) {
+			continue
+		}
+		wg.Add(1)
+		go func(ctx context.Context, host *metrics.WatchedHost, metric *api.OperatorConfigCustomMetric, fetcher *ClickHouseMetricsFetcher, writer *CHIPrometheusWriter) {
+			e.collectHostCustomMetric(ctx, host, metric, fetcher, writer)
+			wg.Done()
+		}(ctx, host, metric, fetcher, writer)
+	}
 	wg.Wait()
+	return writer.HasOKFetches()
 }
 
 func (e *Exporter) collectHostSystemMetrics(
@@ -270,6 +360,27 @@ func (e *Exporter) collectHostSystemReplicasMetrics(
 	}
 }
 
+func (e *Exporter) collectHostReplicationQueueMetrics(
+	ctx context.Context,
+	host *metrics.WatchedHost,
+	fetcher *ClickHouseMetricsFetcher,
+	writer *CHIPrometheusWriter,
+) {
+	log.V(1).Infof("Querying replication queue for host %s", host.Hostname)
+	start := time.Now()
+	replicationQueue, err := fetcher.getClickHouseQueryReplicationQueue(ctx)
+	elapsed := time.Now().Sub(start)
+	if err == nil {
+		log.V(1).Infof("Extracted [%s] %d replication queue tables for host %s", elapsed, len(replicationQueue), host.Hostname)
+		writer.WriteReplicationQueue(replicationQueue)
+		writer.WriteOKFetch("system.replication_queue")
+	} else {
+		// In case of an error fetching data from clickhouse store CHI name in e.cleanup
+		log.Warningf("Error [%s] querying system.replication_queue for host %s err: %s", elapsed, host.Hostname, err)
+		writer.WriteErrorFetch("system.replication_queue")
+	}
+}
+
 func (e *Exporter) collectHostMutationsMetrics(
 	ctx context.Context,
 	host *metrics.WatchedHost,
@@ -333,6 +444,66 @@ func (e *Exporter) collectHostDetachedPartsMetrics(
 	}
 }
 
+func (e *Exporter) collectHostQueryLogMetrics(
+	ctx context.Context,
+	host *metrics.WatchedHost,
+	fetcher *ClickHouseMetricsFetcher,
+	writer *CHIPrometheusWriter,
+) {
+	config := &chop.Config().ClickHouse.Metrics.QueryLog
+	value, _ := e.queryLogs.LoadOrStore(host.Hostname, newQueryLogState())
+	state := value.(*queryLogState)
+
+	log.V(1).Infof("Querying query log for host %s", host.Hostname)
+	start := time.Now()
+	rows, err := state.fetch(func(since int64) (Table, error) {
+		return fetcher.getClickHouseQueryLog(ctx, since, config)
+	}, config)
+	elapsed := time.Now().Sub(start)
+	if err == nil {
+		log.V(1).Infof("Extracted [%s] %d query log rows for host %s", elapsed, rows, host.Hostname)
+		writer.WriteOKFetch("system.query_log")
+	} else {
+		// In case of an error fetching data from clickhouse store CHI name in e.cleanup
+		log.Warningf("Error [%s] querying system.query_log for host %s err: %s", elapsed, host.Hostname, err)
+		writer.WriteErrorFetch("system.query_log")
+	}
+	// Accumulated histograms are written in any case, since they are counters
+	writer.WriteQueryLog(state)
+}
+
+func (e *Exporter) collectHostCustomMetric(
+	ctx context.Context,
+	host *metrics.WatchedHost,
+	metric *api.OperatorConfigCustomMetric,
+	fetcher *ClickHouseMetricsFetcher,
+	writer *CHIPrometheusWriter,
+) {
+	fetchType := "custom." + metric.Name
+	log.V(1).Infof("Querying custom metric %s for host %s", metric.Name, host.Hostname)
+	start := time.Now()
+	data, err := fetcher.getClickHouseQueryCustomMetric(ctx, metric)
+	elapsed := time.Now().Sub(start)
+	if err == nil {
+		log.V(1).Infof("Extracted [%s] %d rows of custom metric %s for host %s", elapsed, len(data), metric.Name, host.Hostname)
+		writer.WriteCustomMetric(metric, data)
+		writer.WriteOKFetch(fetchType)
+	} else {
+		log.Warningf("Error [%s] querying custom metric %s for host %s err: %s", elapsed, metric.Name, host.Hostname, err)
+		writer.WriteErrorFetch(fetchType)
+	}
+	writer.WriteCustomMetricErrors(metric, e.countCustomMetricErrors(host, metric, err != nil))
+}
+
+// countCustomMetricErrors returns number of failed queries of the custom metric on the host, incremented in case of failure
+func (e *Exporter) countCustomMetricErrors(host *metrics.WatchedHost, metric *api.OperatorConfigCustomMetric, failed bool) uint64 {
+	counter, _ := e.customMetricsErrors.LoadOrStore(customMetricErrorsKey{host: host.Hostname, metric: metric.Name}, new(uint64))
+	if failed {
+		return atomic.AddUint64(counter.(*uint64), 1)
+	}
+	return atomic.LoadUint64(counter.(*uint64))
+}
+
 // getWatchedCHI serves HTTP request to get list of watched CHIs
 func (e *Exporter) getWatchedCHI(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -373,21 +544,53 @@ func (e *Exporter) deleteWatchedCHI(w http.ResponseWriter, r *http.Request) {
 
 // DiscoveryWatchedCHIs discovers all ClickHouseInstallation objects available for monitoring and adds them to watched list
 func (e *Exporter) DiscoveryWatchedCHIs(kubeClient kube.Interface, chopClient *chopAPI.Clientset) {
+	watchedCHIs, err := e.discoverWatchedCHIs(kubeClient, chopClient)
+	if err != nil {
+		log.V(1).Infof("Error read ClickHouseInstallations %v", err)
+		return
+	}
+	for _, watchedCHI := range watchedCHIs {
+		e.updateWatched(watchedCHI)
+	}
+}
+
+// discoverWatchedCHIs discovers ClickHouseInstallation objects available for monitoring
+func (e *Exporter) discoverWatchedCHIs(
+	kubeClient kube.Interface,
+	chopClient *chopAPI.Clientset,
+) ([]*metrics.WatchedCHI, error) {
 	// Get all CHI objects from watched namespace(s)
 	watchedNamespace := chop.Config().GetInformerNamespace()
 	list, err := chopClient.ClickhouseV1().ClickHouseInstallations(watchedNamespace).List(context.TODO(), controller.NewListOptions())
 	if err != nil {
-		log.V(1).Infof("Error read ClickHouseInstallations %v", err)
-		return
+		return nil, err
 	}
 	if list == nil {
-		return
+		return nil, nil
 	}
 
-	// Walk over the list of ClickHouseInstallation objects and add them as watched
+	chis := make([]*api.ClickHouseInstallation, 0, len(list.Items))
 	for i := range list.Items {
-		// Convenience wrapper
-		chi := &list.Items[i]
+		chis = append(chis, &list.Items[i])
+	}
+	return e.selectWatchedCHIs(kubeClient, chis, nil), nil
+}
+
+// selectWatchedCHIs selects ClickHouseInstallation objects available for monitoring.
+// Optional filter selects CHIs by watched CHI index key.
+func (e *Exporter) selectWatchedCHIs(
+	kubeClient kube.Interface,
+	chis []*api.ClickHouseInstallation,
+	filter func(key string) bool,
+) (res []*metrics.WatchedCHI) {
+	// Walk over the list of ClickHouseInstallation objects and select available for monitoring
+	for _, chi := range chis {
+		if filter != nil {
+			key := (&metrics.WatchedCHI{Namespace: chi.Namespace, Name: chi.Name}).IndexKey()
+			if !filter(key) {
+				continue
+			}
+		}
 
 		if chi.IsStopped() {
 			log.V(1).Infof("CHI %s/%s is stopped, skip it", chi.Namespace, chi.Name)
@@ -400,12 +603,14 @@ func (e *Exporter) DiscoveryWatchedCHIs(kubeClient kube.Interface, chopClient *c
 		}
 
 		log.V(1).Infof("CHI %s/%s is completed, add it", chi.Namespace, chi.Name)
+		// Zookeeper nodes are not of interest for monitoring, thus keeper references are not resolved
 		normalizer := chiNormalizer.New(func(namespace, name string) (*core.Secret, error) {
 			return kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, controller.NewGetOptions())
-		})
-		normalized, _ := normalizer.CreateTemplated(chi, normalizerCommon.NewOptions())
+		}, nil)
+		// CHI may come from the informer cache, which must not be modified
+		normalized, _ := normalizer.CreateTemplated(chi.DeepCopy(), normalizerCommon.NewOptions())
 
-		watchedCHI := metrics.NewWatchedCHI(normalized)
-		e.updateWatched(watchedCHI)
+		res = append(res, metrics.NewWatchedCHI(normalized))
 	}
+	return res
 }
@@ -0,0 +1,121 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+)
+
+// getWatchedCHKs
+func (e *Exporter) getWatchedCHKs() []*metrics.WatchedCHI {
+	return e.keeperInstallations.slice()
+}
+
+// removeFromWatchedCHK deletes record from Exporter.keeperInstallations map
+func (e *Exporter) removeFromWatchedCHK(chk *metrics.WatchedCHI) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	log.V(1).Infof("Remove ClickHouseKeeperInstallation (%s/%s)", chk.Namespace, chk.Name)
+	e.keeperInstallations.remove(chk.IndexKey())
+}
+
+// updateWatchedCHK updates Exporter.keeperInstallations map with the CHK
+func (e *Exporter) updateWatchedCHK(chk *metrics.WatchedCHI) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	log.V(1).Infof("Update ClickHouseKeeperInstallation (%s/%s): %s", chk.Namespace, chk.Name, chk)
+	e.keeperInstallations.set(chk.IndexKey(), chk)
+}
+
+// collectKeeperMetrics collects metrics from all keepers of the CHK and writes them into chan
+func (e *Exporter) collectKeeperMetrics(ctx context.Context, chk *metrics.WatchedCHI, c chan<- prometheus.Metric) {
+	var hosts []*metrics.WatchedHost
+	chk.WalkHosts(func(_ *metrics.WatchedCHI, _ *metrics.WatchedCluster, host *metrics.WatchedHost) {
+		hosts = append(hosts, host)
+	})
+
+	stats := make([]*zookeeper.ServerStats, len(hosts))
+	wg := sync.WaitGroup{}
+	wg.Add(len(hosts))
+	for i := range hosts {
+		go func(i int) {
+			defer wg.Done()
+			stats[i] = e.collectKeeperHostMetrics(ctx, hosts[i], NewKeeperPrometheusWriter(c, chk, hosts[i]))
+		}(i)
+	}
+	wg.Wait()
+
+	NewKeeperPrometheusWriter(c, chk, nil).WriteQuorumHealthy(zookeeper.IsQuorumHealthy(stats))
+}
+
+// collectKeeperHostMetrics collects metrics from one keeper and writes them into chan
+func (e *Exporter) collectKeeperHostMetrics(
+	ctx context.Context,
+	host *metrics.WatchedHost,
+	writer *KeeperPrometheusWriter,
+) *zookeeper.ServerStats {
+	log.V(1).Infof("Querying keeper stats for host %s", host.Hostname)
+	start := time.Now()
+	address := net.JoinHostPort(host.Hostname, strconv.Itoa(int(host.ZKPort)))
+	stats, err := zookeeper.GetServerStats(ctx, address)
+	elapsed := time.Now().Sub(start)
+	if err == nil {
+		log.V(1).Infof("Extracted [%s] keeper stats for host %s", elapsed, host.Hostname)
+		writer.WriteServerStats(stats)
+		return stats
+	}
+
+	log.Warningf("Error [%s] querying keeper stats for host %s err: %s", elapsed, host.Hostname, err)
+	writer.WriteServerStats(nil)
+	return nil
+}
+
+// getWatchedCHK serves HTTP request to get list of watched CHKs
+func (e *Exporter) getWatchedCHK(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(e.getWatchedCHKs())
+}
+
+// updateWatchedCHKRequest serves HTTP request to add CHK to the list of watched CHKs
+func (e *Exporter) updateWatchedCHKRequest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if chk, err := e.fetchCHI(r); err == nil {
+		e.updateWatchedCHK(chk)
+	} else {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+	}
+}
+
+// deleteWatchedCHK serves HTTP request to delete CHK from the list of watched CHKs
+func (e *Exporter) deleteWatchedCHK(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if chk, err := e.fetchCHI(r); err == nil {
+		e.removeFromWatchedCHK(chk)
+	} else {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+	}
+}
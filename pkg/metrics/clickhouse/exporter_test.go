@@ -0,0 +1,61 @@
+package clickhouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+)
+
+// customMetricsErrorsKeys lists keys of failed queries counters of custom metrics
+func customMetricsErrorsKeys(e *Exporter) []customMetricErrorsKey {
+	var keys []customMetricErrorsKey
+	e.customMetricsErrors.Range(func(key, _ interface{}) bool {
+		keys = append(keys, key.(customMetricErrorsKey))
+		return true
+	})
+	return keys
+}
+
+func TestCustomMetricsErrorsCleanup(t *testing.T) {
+	chop.New(nil, nil, "../../../config/config.yaml")
+	custom := chop.Config().ClickHouse.Metrics.Custom
+	defer func() {
+		chop.Config().ClickHouse.Metrics.Custom = custom
+	}()
+
+	host1 := &metrics.WatchedHost{Hostname: "host-1"}
+	host2 := &metrics.WatchedHost{Hostname: "host-2"}
+	chi := &metrics.WatchedCHI{
+		Namespace: "ns",
+		Name:      "chi",
+		Clusters:  []*metrics.WatchedCluster{{Name: "cluster", Hosts: []*metrics.WatchedHost{host1}}},
+	}
+	metric1 := &api.OperatorConfigCustomMetric{Name: "metric_1"}
+	metric2 := &api.OperatorConfigCustomMetric{Name: "metric_2"}
+	chop.Config().ClickHouse.Metrics.Custom = []api.OperatorConfigCustomMetric{*metric1, *metric2}
+
+	e := NewExporter(time.Second)
+	e.updateWatched(chi)
+	require.Equal(t, uint64(1), e.countCustomMetricErrors(host1, metric1, true))
+	require.Equal(t, uint64(0), e.countCustomMetricErrors(host1, metric2, false))
+	require.Equal(t, uint64(1), e.countCustomMetricErrors(host2, metric1, true))
+	require.Len(t, customMetricsErrorsKeys(e), 3)
+
+	// Counters of metrics still configured are kept
+	e.cleanupCustomMetricsErrors()
+	require.Len(t, customMetricsErrorsKeys(e), 3)
+
+	// Counters of hosts of the CHI removed are forgotten
+	e.removeFromWatched(chi)
+	require.ElementsMatch(t, []customMetricErrorsKey{{host: "host-2", metric: "metric_1"}}, customMetricsErrorsKeys(e))
+
+	// Counters of metrics no longer configured are forgotten
+	chop.Config().ClickHouse.Metrics.Custom = []api.OperatorConfigCustomMetric{*metric2}
+	e.cleanupCustomMetricsErrors()
+	require.Empty(t, customMetricsErrorsKeys(e))
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// hashRingVirtualNodes specifies number of points each member has on the ring.
+// More points lead to more even distribution of the keys among members
+const hashRingVirtualNodes = 128
+
+// hashRing distributes keys among members with consistent hashing,
+// thus only keys of the member joined or left are moved on membership change
+type hashRing struct {
+	points  []uint32
+	members map[uint32]string
+}
+
+// newHashRing creates new hash ring of the members
+func newHashRing(members []string) *hashRing {
+	ring := &hashRing{
+		members: make(map[uint32]string),
+	}
+	for _, member := range members {
+		for i := 0; i < hashRingVirtualNodes; i++ {
+			point := hashRingHash(member + "#" + strconv.Itoa(i))
+			if _, ok := ring.members[point]; ok {
+				// Collision, the first member keeps the point
+				continue
+			}
+			ring.members[point] = member
+			ring.points = append(ring.points, point)
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+	return ring
+}
+
+// owner gets member the key belongs to. Empty string in case ring has no members
+func (r *hashRing) owner(key string) string {
+	if (r == nil) || (len(r.points) == 0) {
+		return ""
+	}
+	hash := hashRingHash(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= hash })
+	if i == len(r.points) {
+		// Wrap around the ring
+		i = 0
+	}
+	return r.members[r.points[i]]
+}
+
+// hashRingHash hashes the key into the ring point.
+// FNV hashes of similar keys, as virtual nodes of a member are, differ in low bits mostly,
+// thus hash is mixed by murmur3 finalizer in order to spread points over the ring evenly.
+func hashRingHash(key string) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	h := hash.Sum32()
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,112 @@
+package clickhouse
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// hashRingTestKeys creates keys as CHIs are indexed by
+func hashRingTestKeys(n int) []string {
+	keys := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		keys = append(keys, fmt.Sprintf("namespace-%d/chi-%d", i%7, i))
+	}
+	return keys
+}
+
+func TestHashRingOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		ring    *hashRing
+		members []string
+	}{
+		{name: "nil ring", ring: nil},
+		{name: "no members", ring: newHashRing(nil)},
+		{name: "one member", ring: newHashRing([]string{"exporter-0"}), members: []string{"exporter-0"}},
+		{name: "three members", ring: newHashRing([]string{"exporter-0", "exporter-1", "exporter-2"}), members: []string{"exporter-0", "exporter-1", "exporter-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range hashRingTestKeys(100) {
+				owner := tt.ring.owner(key)
+				if len(tt.members) == 0 {
+					require.Empty(t, owner)
+				} else {
+					require.Contains(t, tt.members, owner)
+				}
+			}
+		})
+	}
+}
+
+func TestHashRingStable(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+	}{
+		{name: "same members", a: []string{"exporter-0", "exporter-1", "exporter-2"}, b: []string{"exporter-0", "exporter-1", "exporter-2"}},
+		{name: "members order", a: []string{"exporter-0", "exporter-1", "exporter-2"}, b: []string{"exporter-2", "exporter-0", "exporter-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newHashRing(tt.a), newHashRing(tt.b)
+			for _, key := range hashRingTestKeys(1000) {
+				require.Equal(t, a.owner(key), b.owner(key), key)
+			}
+		})
+	}
+}
+
+func TestHashRingMovement(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		after  []string
+		// moved specifies member keys are allowed to move from or to
+		moved string
+	}{
+		{name: "member joined", before: []string{"exporter-0", "exporter-1", "exporter-2"}, after: []string{"exporter-0", "exporter-1", "exporter-2", "exporter-3"}, moved: "exporter-3"},
+		{name: "member left", before: []string{"exporter-0", "exporter-1", "exporter-2"}, after: []string{"exporter-0", "exporter-2"}, moved: "exporter-1"},
+		{name: "last member left", before: []string{"exporter-0", "exporter-1"}, after: []string{"exporter-0"}, moved: "exporter-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := newHashRing(tt.before), newHashRing(tt.after)
+			moved, owned := 0, 0
+			for _, key := range hashRingTestKeys(1000) {
+				from, to := before.owner(key), after.owner(key)
+				if (from == tt.moved) || (to == tt.moved) {
+					owned++
+				}
+				if from == to {
+					continue
+				}
+				// Only keys of the member joined or left move
+				require.True(t, (from == tt.moved) || (to == tt.moved), "key: %s moved from: %s to: %s", key, from, to)
+				moved++
+			}
+			require.Greater(t, moved, 0)
+			// All keys of the member joined or left move
+			require.Equal(t, owned, moved)
+		})
+	}
+}
+
+func TestHashRingDistribution(t *testing.T) {
+	members := []string{"exporter-0", "exporter-1", "exporter-2"}
+	ring := newHashRing(members)
+	keys := hashRingTestKeys(3000)
+
+	owned := make(map[string]int)
+	for _, key := range keys {
+		owned[ring.owner(key)]++
+	}
+	for _, member := range members {
+		// Even distribution is 1/3 of all keys
+		require.Greater(t, owned[member], len(keys)/5, member)
+		require.Less(t, owned[member], len(keys)/2, member)
+	}
+}
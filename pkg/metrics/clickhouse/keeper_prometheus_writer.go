@@ -0,0 +1,150 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
+	"github.com/altinity/clickhouse-operator/pkg/metrics/operator"
+	"github.com/altinity/clickhouse-operator/pkg/model/zookeeper"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+const (
+	keeperNamespace = "chk"
+	keeperSubsystem = "keeper"
+)
+
+// KeeperPrometheusWriter specifies writer of keeper metrics to prometheus
+type KeeperPrometheusWriter struct {
+	out  chan<- prometheus.Metric
+	chk  *metrics.WatchedCHI
+	host *metrics.WatchedHost
+}
+
+// NewKeeperPrometheusWriter creates new keeper prometheus writer.
+// Host is optional, metrics of the whole CHK are written without hostname label.
+func NewKeeperPrometheusWriter(
+	out chan<- prometheus.Metric,
+	chk *metrics.WatchedCHI,
+	host *metrics.WatchedHost,
+) *KeeperPrometheusWriter {
+	return &KeeperPrometheusWriter{
+		out:  out,
+		chk:  chk,
+		host: host,
+	}
+}
+
+// WriteServerStats writes stats of the keeper. Nil stats means keeper is not reachable
+func (w *KeeperPrometheusWriter) WriteServerStats(stats *zookeeper.ServerStats) {
+	up := "0"
+	if stats != nil && stats.OK {
+		up = "1"
+	}
+	w.writeSingleMetricToPrometheus(
+		"up", "status of the keeper as reported by 'ruok' 1 - ok, 0 - not ok or unreachable",
+		prometheus.GaugeValue, up,
+		nil, nil)
+	if stats == nil || !stats.OK {
+		return
+	}
+
+	w.writeSingleMetricToPrometheus(
+		"role", "role of the keeper in the cluster",
+		prometheus.GaugeValue, "1",
+		[]string{"role"}, []string{stats.State})
+	if zxid, err := strconv.ParseInt(strings.TrimPrefix(stats.Zxid, "0x"), 16, 64); err == nil {
+		w.writeSingleMetricToPrometheus(
+			"zxid", "last processed transaction id",
+			prometheus.CounterValue, strconv.FormatInt(zxid, 10),
+			nil, nil)
+	}
+	w.writeSingleMetricToPrometheus(
+		"outstanding_requests", "number of queued requests",
+		prometheus.GaugeValue, strconv.FormatInt(stats.OutstandingRequests, 10),
+		nil, nil)
+	w.writeSingleMetricToPrometheus(
+		"znode_count", "number of znodes",
+		prometheus.GaugeValue, strconv.FormatInt(stats.ZnodeCount, 10),
+		nil, nil)
+}
+
+// WriteQuorumHealthy writes quorum health of the keeper cluster
+func (w *KeeperPrometheusWriter) WriteQuorumHealthy(healthy bool) {
+	value := "0"
+	if healthy {
+		value = "1"
+	}
+	w.writeSingleMetricToPrometheus(
+		"quorum_healthy", "status of the keeper cluster quorum 1 - majority is alive and has a leader, 0 - otherwise",
+		prometheus.GaugeValue, value,
+		nil, nil)
+}
+
+func (w *KeeperPrometheusWriter) getMandatoryLabelsAndValues() (labelNames []string, labelValues []string) {
+	// Prepare mandatory set of labels
+	labelNames, labelValues = operator.GetMandatoryLabelsAndValuesCHK(w.chk)
+	// Append current host label
+	if w.host != nil {
+		labelNames, labelValues = append(labelNames, "hostname"), append(labelValues, w.host.Hostname)
+	}
+
+	return labelNames, labelValues
+}
+
+func (w *KeeperPrometheusWriter) writeSingleMetricToPrometheus(
+	name string,
+	desc string,
+	metricType prometheus.ValueType,
+	value string,
+	optionalLabels []string,
+	optionalLabelValues []string,
+) {
+	// Prepare mandatory set of labels
+	labelNames, labelValues := w.getMandatoryLabelsAndValues()
+	// Append optional labels
+	labelNames = append(labelNames, optionalLabels...)
+	labelValues = append(labelValues, optionalLabelValues...)
+
+	floatValue, _ := strconv.ParseFloat(value, 64)
+	metric, err := prometheus.NewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName(keeperNamespace, keeperSubsystem, util.BuildPrometheusMetricName(name)),
+			desc,
+			util.BuildPrometheusLabels(labelNames...),
+			nil,
+		),
+		metricType,
+		floatValue,
+		labelValues...,
+	)
+	if err != nil {
+		log.Warningf("Error creating metric: %s err: %s", name, err)
+		return
+	}
+	// Send metric into channel
+	select {
+	case w.out <- metric:
+	case <-time.After(writeMetricWaitTimeout):
+		log.Warningf("Error sending metric to the channel: %s", name)
+	}
+}
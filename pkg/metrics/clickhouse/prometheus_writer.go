@@ -16,9 +16,11 @@ package clickhouse
 
 import (
 	"fmt"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
 	"github.com/altinity/clickhouse-operator/pkg/metrics/operator"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	log "github.com/golang/glog"
@@ -43,6 +45,9 @@ type CHIPrometheusWriter struct {
 	out  chan<- prometheus.Metric
 	chi  *metrics.WatchedCHI
 	host *metrics.WatchedHost
+
+	// okFetches counts successful fetches, writer is shared by concurrent collectors of the host
+	okFetches atomic.Int32
 }
 
 // NewCHIPrometheusWriter creates new CHI prometheus writer
@@ -155,6 +160,62 @@ func (w *CHIPrometheusWriter) WriteSystemReplicas(data [][]string) {
 			"system_replicas_is_session_expired", "Number of expired Zookeeper sessions of the table",
 			prometheus.GaugeValue, metric[2],
 			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_is_readonly", "Whether the replica of the table is in read-only mode",
+			prometheus.GaugeValue, metric[3],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_absolute_delay", "How big lag in seconds the current replica of the table has",
+			prometheus.GaugeValue, metric[4],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_queue_size", "Size of the queue for operations waiting to be performed on the table",
+			prometheus.GaugeValue, metric[5],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_inserts_in_queue", "Number of inserts of blocks of data that need to be made for the table",
+			prometheus.GaugeValue, metric[6],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_merges_in_queue", "Number of merges waiting to be made for the table",
+			prometheus.GaugeValue, metric[7],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_log_entries_behind", "Number of entries of the general log the replica of the table has not fetched yet (log_max_index - log_pointer)",
+			prometheus.GaugeValue, metric[8],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_active_replicas", "Number of replicas of the table that have a session in Zookeeper",
+			prometheus.GaugeValue, metric[9],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"system_replicas_total_replicas", "Total number of known replicas of the table",
+			prometheus.GaugeValue, metric[10],
+			labelNames, labelValues)
+	}
+}
+
+// WriteReplicationQueue writes replication queue
+func (w *CHIPrometheusWriter) WriteReplicationQueue(data [][]string) {
+	for _, metric := range data {
+		labelNames := []string{"database", "table"}
+		labelValues := []string{metric[0], metric[1]}
+		w.writeSingleMetricToPrometheus(
+			"replication_queue_entries", "Number of entries in the replication queue of the table",
+			prometheus.GaugeValue, metric[2],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"replication_queue_oldest_entry_age_seconds", "Age of the oldest entry in the replication queue of the table",
+			prometheus.GaugeValue, metric[3],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"replication_queue_entries_retried", "Number of entries in the replication queue of the table that were tried more than once",
+			prometheus.GaugeValue, metric[4],
+			labelNames, labelValues)
+		w.writeSingleMetricToPrometheus(
+			"replication_queue_entries_failed", "Number of entries in the replication queue of the table that failed with an exception",
+			prometheus.GaugeValue, metric[5],
+			labelNames, labelValues)
 	}
 }
 
@@ -202,6 +263,59 @@ func (w *CHIPrometheusWriter) WriteDetachedParts(data [][]string) {
 	}
 }
 
+// WriteCustomMetric writes user-defined metric
+// Expected data structure: value, label values in the order of label columns
+func (w *CHIPrometheusWriter) WriteCustomMetric(metric *api.OperatorConfigCustomMetric, data [][]string) {
+	metricType := prometheus.GaugeValue
+	if metric.IsCounter() {
+		metricType = prometheus.CounterValue
+	}
+	for _, row := range data {
+		w.writeSingleMetricToPrometheus(
+			metric.Name, metric.Help,
+			metricType, row[0],
+			metric.LabelColumns, row[1:])
+	}
+}
+
+// WriteCustomMetricErrors writes number of failed queries of the user-defined metric
+func (w *CHIPrometheusWriter) WriteCustomMetricErrors(metric *api.OperatorConfigCustomMetric, errors uint64) {
+	labelNames := []string{"metric"}
+	labelValues := []string{metric.Name}
+	w.writeSingleMetricToPrometheus(
+		"custom_metric_errors_total", "Number of failed queries of the custom metric",
+		prometheus.CounterValue, strconv.FormatUint(errors, 10),
+		labelNames, labelValues)
+}
+
+// WriteQueryLog writes query duration histograms accumulated from query_log
+func (w *CHIPrometheusWriter) WriteQueryLog(state *queryLogState) {
+	labelNames := []string{"query_kind", "user", "exception_code"}
+	state.walk(func(key queryLogKey, count uint64, sum float64, buckets map[float64]uint64) {
+		labelValues := []string{key.kind, key.user, key.exceptionCode}
+		w.writeHistogramToPrometheus(
+			"query_duration_seconds", "Duration of the queries from system.query_log",
+			count, sum, buckets,
+			labelNames, labelValues)
+	})
+}
+
+// WriteScrape writes age and duration of the last completed background scrape and age of the last scrape attempt of the host
+func (w *CHIPrometheusWriter) WriteScrape(age, attemptAge, duration time.Duration) {
+	w.writeSingleMetricToPrometheus(
+		"scrape_age_seconds", "Time passed since the last completed background scrape of the host",
+		prometheus.GaugeValue, strconv.FormatFloat(age.Seconds(), 'f', -1, 64),
+		nil, nil)
+	w.writeSingleMetricToPrometheus(
+		"scrape_attempt_age_seconds", "Time passed since the last background scrape attempt of the host, successful or not",
+		prometheus.GaugeValue, strconv.FormatFloat(attemptAge.Seconds(), 'f', -1, 64),
+		nil, nil)
+	w.writeSingleMetricToPrometheus(
+		"scrape_duration_seconds", "Duration of the last completed background scrape of the host",
+		prometheus.GaugeValue, strconv.FormatFloat(duration.Seconds(), 'f', -1, 64),
+		nil, nil)
+}
+
 // WriteErrorFetch writes error fetch
 func (w *CHIPrometheusWriter) WriteErrorFetch(fetchType string) {
 	labelNames := []string{"fetch_type"}
@@ -214,6 +328,7 @@ func (w *CHIPrometheusWriter) WriteErrorFetch(fetchType string) {
 
 // WriteOKFetch writes successful fetch
 func (w *CHIPrometheusWriter) WriteOKFetch(fetchType string) {
+	w.okFetches.Add(1)
 	labelNames := []string{"fetch_type"}
 	labelValues := []string{fetchType}
 	w.writeSingleMetricToPrometheus(
@@ -222,6 +337,11 @@ func (w *CHIPrometheusWriter) WriteOKFetch(fetchType string) {
 		labelNames, labelValues)
 }
 
+// HasOKFetches checks whether any fetch from ClickHouse succeeded, thus host is responding
+func (w *CHIPrometheusWriter) HasOKFetches() bool {
+	return w.okFetches.Load() > 0
+}
+
 func (w *CHIPrometheusWriter) appendHostLabel(labels, values []string) ([]string, []string) {
 	return append(labels, "hostname"), append(values, w.host.Hostname)
 }
@@ -260,7 +380,40 @@ func (w *CHIPrometheusWriter) writeSingleMetricToPrometheus(
 		log.Warningf("Error creating metric: %s err: %s", name, err)
 		return
 	}
-	// Send metric into channel
+	w.send(name, metric)
+}
+
+func (w *CHIPrometheusWriter) writeHistogramToPrometheus(
+	name string,
+	desc string,
+	count uint64,
+	sum float64,
+	buckets map[float64]uint64,
+	optionalLabels []string,
+	optionalLabelValues []string,
+) {
+	// Prepare mandatory set of labels
+	labelNames, labelValues := w.getMandatoryLabelsAndValues()
+	// Append optional labels
+	labelNames = append(labelNames, optionalLabels...)
+	labelValues = append(labelValues, optionalLabelValues...)
+
+	metric, err := prometheus.NewConstHistogram(
+		newMetricDescriptor(name, desc, labelNames),
+		count,
+		sum,
+		buckets,
+		labelValues...,
+	)
+	if err != nil {
+		log.Warningf("Error creating metric: %s err: %s", name, err)
+		return
+	}
+	w.send(name, metric)
+}
+
+// send sends metric into channel
+func (w *CHIPrometheusWriter) send(name string, metric prometheus.Metric) {
 	select {
 	case w.out <- metric:
 	case <-time.After(writeMetricWaitTimeout):
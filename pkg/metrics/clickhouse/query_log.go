@@ -0,0 +1,170 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+)
+
+// queryLogLabelOther is reported instead of label values above the cardinality limit
+const queryLogLabelOther = "other"
+
+// queryLogKey specifies labels of the query duration histogram
+type queryLogKey struct {
+	kind          string
+	user          string
+	exceptionCode string
+}
+
+// queryLogHistogram accumulates query durations
+type queryLogHistogram struct {
+	count uint64
+	sum   float64
+	// buckets contains number of queries per bucket, not cumulative. Last bucket is +Inf
+	buckets []uint64
+}
+
+// cumulative builds cumulative bucket counts by upper bounds, as required by prometheus
+func (h *queryLogHistogram) cumulative(bounds []float64) map[float64]uint64 {
+	res := make(map[float64]uint64, len(bounds))
+	var total uint64
+	for i, bound := range bounds {
+		total += h.buckets[i]
+		res[bound] = total
+	}
+	return res
+}
+
+// queryLogState accumulates query durations read from query_log of one host.
+// query_log is read incrementally, thus histograms are accumulated between scrapes, as prometheus counters are.
+type queryLogState struct {
+	// fetchMutex serializes fetches of the host, thus each window of query_log is read once
+	fetchMutex sync.Mutex
+	mutex      sync.Mutex
+
+	// since specifies event_time (unix timestamp) query_log is read up to
+	since int64
+	// bounds specifies upper bounds of the buckets, histograms are accumulated with
+	bounds     []float64
+	histograms map[queryLogKey]*queryLogHistogram
+
+	users          map[string]struct{}
+	exceptionCodes map[string]struct{}
+}
+
+// newQueryLogState creates new query log state
+func newQueryLogState() *queryLogState {
+	return &queryLogState{}
+}
+
+// fetch reads query_log rows since the last read with the fetch function and accumulates them.
+// Window read is claimed till rows are accumulated, thus concurrent scrapes of the host do not count the same rows twice.
+// Returns number of rows read.
+func (s *queryLogState) fetch(fetch func(since int64) (Table, error), config *api.OperatorConfigQueryLogMetrics) (int, error) {
+	s.fetchMutex.Lock()
+	defer s.fetchMutex.Unlock()
+
+	data, err := fetch(s.getSince())
+	if err != nil {
+		return 0, err
+	}
+	s.add(data, config)
+	return len(data), nil
+}
+
+// getSince gets event_time query_log should be read from
+func (s *queryLogState) getSince() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.since
+}
+
+// reset drops accumulated histograms in case buckets are changed
+func (s *queryLogState) reset(bounds []float64) {
+	if slices.Equal(s.bounds, bounds) && (s.histograms != nil) {
+		return
+	}
+	s.bounds = append([]float64{}, bounds...)
+	s.histograms = make(map[queryLogKey]*queryLogHistogram)
+	s.users = make(map[string]struct{})
+	s.exceptionCodes = make(map[string]struct{})
+}
+
+// add accumulates rows fetched from query_log
+// Expected data structure: till, kind, user, exception code, bucket, queries, duration
+func (s *queryLogState) add(data Table, config *api.OperatorConfigQueryLogMetrics) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.reset(config.Buckets)
+	for _, row := range data {
+		till, _ := strconv.ParseInt(row[0], 10, 64)
+		bucket, _ := strconv.Atoi(row[4])
+		queries, _ := strconv.ParseUint(row[5], 10, 64)
+		duration, _ := strconv.ParseFloat(row[6], 64)
+
+		key := queryLogKey{
+			kind:          row[1],
+			user:          limitLabel(s.users, row[2], config.Limits.Users),
+			exceptionCode: limitLabel(s.exceptionCodes, row[3], config.Limits.ExceptionCodes),
+		}
+		histogram, ok := s.histograms[key]
+		if !ok {
+			histogram = &queryLogHistogram{
+				buckets: make([]uint64, len(s.bounds)+1),
+			}
+			s.histograms[key] = histogram
+		}
+
+		// Bucket index is 1-based, 0 means duration is above all upper bounds
+		if (bucket < 1) || (bucket > len(s.bounds)) {
+			bucket = len(s.bounds) + 1
+		}
+		histogram.buckets[bucket-1] += queries
+		histogram.count += queries
+		histogram.sum += duration
+
+		if till > s.since {
+			s.since = till
+		}
+	}
+}
+
+// walk walks over accumulated histograms
+func (s *queryLogState) walk(f func(key queryLogKey, count uint64, sum float64, buckets map[float64]uint64)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, histogram := range s.histograms {
+		f(key, histogram.count, histogram.sum, histogram.cumulative(s.bounds))
+	}
+}
+
+// limitLabel returns value in case it is known already or limit of known values is not reached,
+// otherwise returns queryLogLabelOther
+func limitLabel(known map[string]struct{}, value string, limit int) string {
+	if _, ok := known[value]; ok {
+		return value
+	}
+	if len(known) >= limit {
+		return queryLogLabelOther
+	}
+	known[value] = struct{}{}
+	return value
+}
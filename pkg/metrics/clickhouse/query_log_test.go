@@ -0,0 +1,197 @@
+package clickhouse
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/model/clickhouse"
+)
+
+// queryLogTestConfig creates query log config with 2 buckets and limits of 2 distinct label values
+func queryLogTestConfig() *api.OperatorConfigQueryLogMetrics {
+	return &api.OperatorConfigQueryLogMetrics{
+		Buckets: []float64{0.1, 1},
+		Delay:   10 * time.Second,
+		Window:  300 * time.Second,
+		Limits: api.OperatorConfigQueryLogMetricsLimits{
+			Users:          2,
+			ExceptionCodes: 2,
+		},
+	}
+}
+
+// queryLogTestHistograms collects accumulated histograms
+func queryLogTestHistograms(s *queryLogState) map[queryLogKey]queryLogHistogram {
+	res := make(map[queryLogKey]queryLogHistogram)
+	s.walk(func(key queryLogKey, count uint64, sum float64, buckets map[float64]uint64) {
+		res[key] = queryLogHistogram{
+			count:   count,
+			sum:     sum,
+			buckets: []uint64{buckets[0.1], buckets[1]},
+		}
+	})
+	return res
+}
+
+func TestQueryLogStateAdd(t *testing.T) {
+	config := queryLogTestConfig()
+	s := newQueryLogState()
+	require.Equal(t, int64(0), s.getSince())
+
+	// Expected data structure: till, kind, user, exception code, bucket, queries, duration
+	s.add(Table{
+		{"100", "Select", "default", "0", "1", "3", "0.15"},
+		{"100", "Select", "default", "0", "2", "1", "0.5"},
+		{"100", "Select", "default", "0", "0", "2", "7"},
+		{"100", "Insert", "writer", "60", "2", "1", "0.2"},
+	}, config)
+	require.Equal(t, int64(100), s.getSince())
+	require.Equal(t, map[queryLogKey]queryLogHistogram{
+		{kind: "Select", user: "default", exceptionCode: "0"}: {count: 6, sum: 7.65, buckets: []uint64{3, 4}},
+		{kind: "Insert", user: "writer", exceptionCode: "60"}: {count: 1, sum: 0.2, buckets: []uint64{0, 1}},
+	}, queryLogTestHistograms(s))
+
+	// Histograms are accumulated between windows, labels above the limits are reported as other
+	s.add(Table{
+		{"160", "Select", "default", "0", "1", "1", "0.05"},
+		{"160", "Select", "reader", "241", "1", "1", "0.01"},
+	}, config)
+	require.Equal(t, int64(160), s.getSince())
+	require.Equal(t, map[queryLogKey]queryLogHistogram{
+		{kind: "Select", user: "default", exceptionCode: "0"}:                         {count: 7, sum: 7.7, buckets: []uint64{4, 5}},
+		{kind: "Insert", user: "writer", exceptionCode: "60"}:                         {count: 1, sum: 0.2, buckets: []uint64{0, 1}},
+		{kind: "Select", user: queryLogLabelOther, exceptionCode: queryLogLabelOther}: {count: 1, sum: 0.01, buckets: []uint64{1, 1}},
+	}, queryLogTestHistograms(s))
+
+	// Empty window keeps position
+	s.add(Table{}, config)
+	require.Equal(t, int64(160), s.getSince())
+
+	// Change of buckets drops accumulated histograms, position in query_log is kept
+	config.Buckets = []float64{0.1, 1, 10}
+	s.add(Table{}, config)
+	require.Equal(t, int64(160), s.getSince())
+	require.Empty(t, queryLogTestHistograms(s))
+}
+
+func TestQueryLogStateFetchSerialized(t *testing.T) {
+	config := queryLogTestConfig()
+	s := newQueryLogState()
+
+	var mutex sync.Mutex
+	var sinces []int64
+	inProgress := 0
+	overlapped := false
+	till := int64(0)
+	fetch := func(since int64) (Table, error) {
+		mutex.Lock()
+		inProgress++
+		overlapped = overlapped || (inProgress > 1)
+		sinces = append(sinces, since)
+		till += 10
+		row := []string{strconv.FormatInt(till, 10), "Select", "default", "0", "2", "1", "0.5"}
+		mutex.Unlock()
+
+		// Let concurrent fetches race for the window
+		time.Sleep(time.Millisecond)
+
+		mutex.Lock()
+		inProgress--
+		mutex.Unlock()
+		return Table{row}, nil
+	}
+
+	const fetches = 10
+	wg := sync.WaitGroup{}
+	for i := 0; i < fetches; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = s.fetch(fetch, config)
+		}()
+	}
+	wg.Wait()
+
+	// Each fetch starts where the previous one ended, thus each window is read once
+	require.False(t, overlapped, "fetches of the host overlap")
+	require.Len(t, sinces, fetches)
+	for i := range sinces {
+		require.Equal(t, int64(i*10), sinces[i])
+	}
+	require.Equal(t, int64(fetches*10), s.getSince())
+	require.Equal(t, map[queryLogKey]queryLogHistogram{
+		{kind: "Select", user: "default", exceptionCode: "0"}: {count: fetches, sum: 5, buckets: []uint64{0, fetches}},
+	}, queryLogTestHistograms(s))
+}
+
+func TestQueryLogStateFetchError(t *testing.T) {
+	config := queryLogTestConfig()
+	s := newQueryLogState()
+	s.add(Table{{"100", "Select", "default", "0", "1", "1", "0.01"}}, config)
+
+	// Failed fetch keeps position, thus the window is read again by the next fetch
+	_, err := s.fetch(func(since int64) (Table, error) {
+		require.Equal(t, int64(100), since)
+		return nil, io.ErrUnexpectedEOF
+	}, config)
+	require.Error(t, err)
+	require.Equal(t, int64(100), s.getSince())
+}
+
+func TestGetClickHouseQueryLogBindsParameters(t *testing.T) {
+	var mutex sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			if gz, err := gzip.NewReader(r.Body); err == nil {
+				body = gz
+			}
+		}
+		query, _ := io.ReadAll(body)
+		if strings.TrimSpace(string(query)) == "select 1" {
+			_, _ = io.WriteString(w, "1\n")
+			return
+		}
+		mutex.Lock()
+		queries = append(queries, string(query))
+		mutex.Unlock()
+
+		columns := []string{"till", "kind", "user", "exception_code", "bucket", "queries", "duration"}
+		types := []string{"String", "String", "String", "String", "String", "String", "String"}
+		_, _ = io.WriteString(w, strings.Join(columns, "\t")+"\n"+strings.Join(types, "\t")+"\n")
+		_, _ = io.WriteString(w, "200\tSelect\tdefault\t0\t1\t2\t0.03\n")
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	fetcher := NewClickHouseFetcher(
+		clickhouse.NewEndpointConnectionParams("http", host, "", "", "", portNumber).
+			SetTimeouts(clickhouse.NewTimeouts(5*time.Second, 5*time.Second)),
+	)
+
+	data, err := fetcher.getClickHouseQueryLog(context.Background(), 100, queryLogTestConfig())
+	require.NoError(t, err)
+	require.Equal(t, Table{{"200", "Select", "default", "0", "1", "2", "0.03"}}, data)
+
+	require.Len(t, queries, 1)
+	require.NotContains(t, queries[0], "?")
+	require.Contains(t, queries[0], "now() - toIntervalSecond(10)")
+	require.Contains(t, queries[0], "greatest(toDateTime(100), query_log_till - toIntervalSecond(300))")
+	require.Contains(t, queries[0], "(b * 1000)), [0.1,1])")
+}
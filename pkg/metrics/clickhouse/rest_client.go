@@ -25,3 +25,13 @@ func InformMetricsExporterAboutWatchedCHI(chi *metrics.WatchedCHI) error {
 func InformMetricsExporterToDeleteWatchedCHI(chi *metrics.WatchedCHI) error {
 	return makeRESTCall(chi, "DELETE")
 }
+
+// InformMetricsExporterAboutWatchedCHK informs exporter about new watched CHK
+func InformMetricsExporterAboutWatchedCHK(chk *metrics.WatchedCHI) error {
+	return makeRESTCallToPath(chk, "POST", "/chk")
+}
+
+// InformMetricsExporterToDeleteWatchedCHK informs exporter to delete/forget watched CHK
+func InformMetricsExporterToDeleteWatchedCHK(chk *metrics.WatchedCHI) error {
+	return makeRESTCallToPath(chk, "DELETE", "/chk")
+}
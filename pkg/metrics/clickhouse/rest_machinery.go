@@ -24,7 +24,11 @@ import (
 )
 
 func makeRESTCall(chi *metrics.WatchedCHI, method string) error {
-	url := "http://127.0.0.1:8888/chi"
+	return makeRESTCallToPath(chi, method, "/chi")
+}
+
+func makeRESTCallToPath(chi *metrics.WatchedCHI, method string, path string) error {
+	url := "http://127.0.0.1:8888" + path
 
 	json, err := json.Marshal(chi)
 	if err != nil {
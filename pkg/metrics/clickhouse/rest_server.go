@@ -33,6 +33,7 @@ func StartMetricsREST(
 
 	chiListAddress string,
 	chiListPath string,
+	chkListPath string,
 ) *Exporter {
 	log.V(1).Infof("Starting metrics exporter at '%s%s'\n", metricsAddress, metricsPath)
 
@@ -41,6 +42,7 @@ func StartMetricsREST(
 
 	http.Handle(metricsPath, promhttp.Handler())
 	http.Handle(chiListPath, exporter)
+	http.Handle(chkListPath, exporter)
 
 	go http.ListenAndServe(metricsAddress, nil)
 	if metricsAddress != chiListAddress {
@@ -52,6 +54,10 @@ func StartMetricsREST(
 
 // ServeHTTP is an interface method to serve HTTP requests
 func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/chk" {
+		e.serveCHK(w, r)
+		return
+	}
 	if r.URL.Path != "/chi" {
 		http.Error(w, "404 not found.", http.StatusNotFound)
 		return
@@ -68,3 +74,17 @@ func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		_, _ = fmt.Fprintf(w, "Sorry, only GET, POST and DELETE methods are supported.")
 	}
 }
+
+// serveCHK serves HTTP requests to the list of watched CHKs
+func (e *Exporter) serveCHK(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		e.getWatchedCHK(w, r)
+	case "POST":
+		e.updateWatchedCHKRequest(w, r)
+	case "DELETE":
+		e.deleteWatchedCHK(w, r)
+	default:
+		_, _ = fmt.Fprintf(w, "Sorry, only GET, POST and DELETE methods are supported.")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
+)
+
+const (
+	// scraperTick specifies how often scraper checks whether hosts are due to be scraped
+	scraperTick = 1 * time.Second
+	// scraperStaleIntervals specifies number of scrape intervals cached metrics are served for.
+	// Metrics of the host not scraped successfully for longer are considered stale and are not served
+	scraperStaleIntervals = 3
+)
+
+// hostScrape specifies cached results of background scraping of one host
+type hostScrape struct {
+	chi  *metrics.WatchedCHI
+	host *metrics.WatchedHost
+
+	// next specifies time the host is due to be scraped
+	next time.Time
+	// inProgress specifies whether the host is being scraped
+	inProgress bool
+
+	// metrics contains metrics collected by the last completed scrape
+	metrics []prometheus.Metric
+	// completed specifies time the last scrape completed successfully
+	completed time.Time
+	// duration specifies duration of the last completed scrape
+	duration time.Duration
+	// attempted specifies time the last scrape attempt finished, successful or not
+	attempted time.Time
+}
+
+// record records result of the scrape attempt finished at specified time.
+// Failed attempt keeps metrics of the last completed scrape, thus they age till considered stale.
+func (scrape *hostScrape) record(collected []prometheus.Metric, ok bool, duration time.Duration, now time.Time) {
+	scrape.inProgress = false
+	scrape.attempted = now
+	if !ok {
+		return
+	}
+	scrape.metrics = collected
+	scrape.completed = now
+	scrape.duration = duration
+}
+
+// hostScraper scrapes watched hosts in background and serves cached results.
+// Thus load on ClickHouse does not depend on number of Prometheus servers scraping the exporter.
+type hostScraper struct {
+	exporter *Exporter
+	interval time.Duration
+	timeout  time.Duration
+
+	mutex sync.Mutex
+	hosts map[string]*hostScrape
+}
+
+// newHostScraper creates new host scraper
+func newHostScraper(exporter *Exporter, interval, timeout time.Duration) *hostScraper {
+	return &hostScraper{
+		exporter: exporter,
+		interval: interval,
+		timeout:  timeout,
+		hosts:    make(map[string]*hostScrape),
+	}
+}
+
+// hostScrapeKey builds key of the host scrape
+func hostScrapeKey(chi *metrics.WatchedCHI, host *metrics.WatchedHost) string {
+	return chi.IndexKey() + "/" + host.Hostname
+}
+
+// run runs background scraping till context is done
+func (s *hostScraper) run(ctx context.Context) {
+	log.V(1).Infof("Background scraping started. Interval: %s", s.interval)
+	ticker := time.NewTicker(scraperTick)
+	defer ticker.Stop()
+	for {
+		s.schedule(ctx)
+		select {
+		case <-ctx.Done():
+			log.V(1).Info("Background scraping stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// schedule syncs list of scraped hosts with watched hosts and launches scraping of the hosts due
+func (s *hostScraper) schedule(ctx context.Context) {
+	watched := make(map[string]bool)
+	now := time.Now()
+
+	s.exporter.mutex.RLock()
+	s.mutex.Lock()
+	s.exporter.chInstallations.walk(func(chi *metrics.WatchedCHI, _ *metrics.WatchedCluster, host *metrics.WatchedHost) {
+		key := hostScrapeKey(chi, host)
+		watched[key] = true
+
+		scrape, ok := s.hosts[key]
+		if !ok {
+			// Spread scraping of the new hosts over the interval
+			scrape = &hostScrape{
+				next: now.Add(time.Duration(rand.Int63n(int64(s.interval)))),
+			}
+			s.hosts[key] = scrape
+		}
+		// Watched CHI is replaced on update
+		scrape.chi, scrape.host = chi, host
+
+		if scrape.inProgress || now.Before(scrape.next) {
+			return
+		}
+		scrape.inProgress = true
+		scrape.next = now.Add(s.interval)
+		go s.scrape(ctx, scrape, chi, host)
+	})
+	for key := range s.hosts {
+		if !watched[key] {
+			delete(s.hosts, key)
+		}
+	}
+	s.mutex.Unlock()
+	s.exporter.mutex.RUnlock()
+}
+
+// scrape collects metrics of the host and caches them
+func (s *hostScraper) scrape(ctx context.Context, scrape *hostScrape, chi *metrics.WatchedCHI, host *metrics.WatchedHost) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	ch := make(chan prometheus.Metric)
+	done := make(chan []prometheus.Metric)
+	go func() {
+		var collected []prometheus.Metric
+		for metric := range ch {
+			collected = append(collected, metric)
+		}
+		done <- collected
+	}()
+	ok := s.exporter.collectHostMetrics(ctx, chi, host, ch)
+	close(ch)
+	collected := <-done
+	duration := time.Now().Sub(start)
+
+	if ok {
+		log.V(1).Infof("Scraped [%s] %d metrics of host %s", duration, len(collected), host.Hostname)
+	} else {
+		log.Warningf("Unable to scrape [%s] metrics of host %s, keep metrics of the last completed scrape", duration, host.Hostname)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	scrape.record(collected, ok, duration, time.Now())
+}
+
+// collect writes cached metrics of all scraped hosts into chan
+func (s *hostScraper) collect(ch chan<- prometheus.Metric) {
+	// Copy cached results in order not to block scraping while metrics are being written
+	var scrapes []hostScrape
+	s.mutex.Lock()
+	for _, scrape := range s.hosts {
+		if !scrape.completed.IsZero() {
+			scrapes = append(scrapes, *scrape)
+		}
+	}
+	s.mutex.Unlock()
+
+	now := time.Now()
+	for i := range scrapes {
+		scrape := &scrapes[i]
+		writer := NewCHIPrometheusWriter(ch, scrape.chi, scrape.host)
+		age := now.Sub(scrape.completed)
+		writer.WriteScrape(age, now.Sub(scrape.attempted), scrape.duration)
+		if age > scraperStaleIntervals*s.interval {
+			log.V(1).Infof("Metrics of host %s are stale [%s], skip them", scrape.host.Hostname, age)
+			continue
+		}
+		for _, metric := range scrape.metrics {
+			writer.send("cached metric", metric)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package clickhouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostScrapeRecord(t *testing.T) {
+	start := time.Now()
+	metric := prometheus.MustNewConstMetric(
+		prometheus.NewDesc("test_metric", "test metric", nil, nil),
+		prometheus.GaugeValue, 1,
+	)
+
+	scrape := &hostScrape{inProgress: true}
+	scrape.record([]prometheus.Metric{metric}, true, time.Second, start)
+	require.False(t, scrape.inProgress)
+	require.Len(t, scrape.metrics, 1)
+	require.Equal(t, start, scrape.completed)
+	require.Equal(t, start, scrape.attempted)
+	require.Equal(t, time.Second, scrape.duration)
+
+	// Failed attempt keeps metrics of the last completed scrape, thus they age
+	failed := start.Add(time.Minute)
+	scrape.inProgress = true
+	scrape.record(nil, false, 2*time.Second, failed)
+	require.False(t, scrape.inProgress)
+	require.Len(t, scrape.metrics, 1)
+	require.Equal(t, start, scrape.completed)
+	require.Equal(t, failed, scrape.attempted)
+	require.Equal(t, time.Second, scrape.duration)
+
+	// Successful attempt replaces metrics
+	completed := failed.Add(time.Minute)
+	scrape.record(nil, true, 3*time.Second, completed)
+	require.Empty(t, scrape.metrics)
+	require.Equal(t, completed, scrape.completed)
+	require.Equal(t, completed, scrape.attempted)
+	require.Equal(t, 3*time.Second, scrape.duration)
+}
@@ -0,0 +1,267 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouse
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	log "github.com/golang/glog"
+
+	coordination "k8s.io/api/coordination/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kube "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/metrics"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	chopAPI "github.com/altinity/clickhouse-operator/pkg/client/clientset/versioned"
+	chopinformers "github.com/altinity/clickhouse-operator/pkg/client/informers/externalversions"
+	chopListers "github.com/altinity/clickhouse-operator/pkg/client/listers/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/controller"
+)
+
+// LabelMetricsExporterGroup labels Leases of the replicas of the sharded metrics exporter with the group name
+const LabelMetricsExporterGroup = "clickhouse.altinity.com/metrics-exporter-group"
+
+// shardMembership maintains Lease of the metrics exporter replica and tracks alive replicas of the group.
+// Each replica holds its own Lease, replica is considered alive while its Lease is renewed.
+type shardMembership struct {
+	kubeClient kube.Interface
+	namespace  string
+	identity   string
+	config     *api.OperatorConfigMetricsSharding
+}
+
+// newShardMembership creates new shard membership
+func newShardMembership(
+	kubeClient kube.Interface,
+	namespace string,
+	identity string,
+	config *api.OperatorConfigMetricsSharding,
+) *shardMembership {
+	return &shardMembership{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		identity:   identity,
+		config:     config,
+	}
+}
+
+// leaseName builds name of the Lease of the replica
+func (m *shardMembership) leaseName() string {
+	return m.config.Group + "-" + m.identity
+}
+
+// renew creates or renews Lease of the replica
+func (m *shardMembership) renew(ctx context.Context) error {
+	now := meta.NewMicroTime(time.Now())
+	duration := int32(m.config.LeaseDuration.Seconds())
+	leases := m.kubeClient.CoordinationV1().Leases(m.namespace)
+
+	lease, err := leases.Get(ctx, m.leaseName(), controller.NewGetOptions())
+	if apiErrors.IsNotFound(err) {
+		lease = &coordination.Lease{
+			ObjectMeta: meta.ObjectMeta{
+				Name:      m.leaseName(),
+				Namespace: m.namespace,
+				Labels: map[string]string{
+					LabelMetricsExporterGroup: m.config.Group,
+				},
+			},
+			Spec: coordination.LeaseSpec{
+				HolderIdentity:       &m.identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, controller.NewCreateOptions())
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	lease.Spec.HolderIdentity = &m.identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, controller.NewUpdateOptions())
+	return err
+}
+
+// members lists alive replicas of the group, sorted. The replica itself is always a member.
+// Leases of the replicas expired are deleted.
+func (m *shardMembership) members(ctx context.Context) ([]string, error) {
+	leases := m.kubeClient.CoordinationV1().Leases(m.namespace)
+	list, err := leases.List(ctx, controller.NewListOptions(map[string]string{
+		LabelMetricsExporterGroup: m.config.Group,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	members := []string{m.identity}
+	for i := range list.Items {
+		lease := &list.Items[i]
+		if (lease.Spec.HolderIdentity == nil) || (*lease.Spec.HolderIdentity == m.identity) {
+			continue
+		}
+		if isLeaseExpired(lease, now) {
+			log.V(1).Infof("Lease of the metrics exporter replica %s is expired, delete it", *lease.Spec.HolderIdentity)
+			_ = leases.Delete(ctx, lease.GetName(), controller.NewDeleteOptions())
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// release deletes Lease of the replica, thus other replicas take over its share without waiting for Lease expiration
+func (m *shardMembership) release(ctx context.Context) {
+	err := m.kubeClient.CoordinationV1().Leases(m.namespace).Delete(ctx, m.leaseName(), controller.NewDeleteOptions())
+	if (err != nil) && !apiErrors.IsNotFound(err) {
+		log.Warningf("Unable to release Lease of the metrics exporter replica %s err: %v", m.identity, err)
+	}
+}
+
+// isLeaseExpired checks whether Lease is not renewed within its duration
+func isLeaseExpired(lease *coordination.Lease, now time.Time) bool {
+	if (lease.Spec.RenewTime == nil) || (lease.Spec.LeaseDurationSeconds == nil) {
+		return true
+	}
+	duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return lease.Spec.RenewTime.Add(duration).Before(now)
+}
+
+// RunSharded runs exporter as a replica of the sharded metrics exporter Deployment till context is done.
+// Watched CHIs are discovered by the replica itself and are distributed among alive replicas of the group
+// by consistent hashing of the watched CHI index key. Each replica watches its own share of CHIs only
+// and rebalances as soon as replicas join or leave the group.
+// CHIs are discovered from the informer cache, thus discovery does not list CHIs via API server each period.
+func (e *Exporter) RunSharded(
+	ctx context.Context,
+	kubeClient kube.Interface,
+	chopClient *chopAPI.Clientset,
+	namespace string,
+	identity string,
+) {
+	config := &chop.Config().ClickHouse.Metrics.Sharding
+	membership := newShardMembership(kubeClient, namespace, identity, config)
+
+	// Informer cache keeps CHIs up-to-date by watch
+	chopInformerFactory := chopinformers.NewSharedInformerFactoryWithOptions(
+		chopClient,
+		0,
+		chopinformers.WithNamespace(chop.Config().GetInformerNamespace()),
+	)
+	chiInformer := chopInformerFactory.Clickhouse().V1().ClickHouseInstallations()
+	chiLister := chiInformer.Lister()
+	chiInformerSynced := chiInformer.Informer().HasSynced
+	chopInformerFactory.Start(ctx.Done())
+	defer chopInformerFactory.Shutdown()
+
+	// Replica does not take its share till CHIs are known
+	if !cache.WaitForCacheSync(ctx.Done(), chiInformerSynced) {
+		log.Warningf("Unable to sync ClickHouseInstallations cache of the metrics exporter replica %s", identity)
+		return
+	}
+
+	log.Infof("Metrics exporter replica %s joins group %s/%s", identity, namespace, config.Group)
+
+	ticker := time.NewTicker(config.RenewPeriod)
+	defer ticker.Stop()
+
+	var members []string
+	var ring *hashRing
+	var discovered time.Time
+	for {
+		if err := membership.renew(ctx); err != nil {
+			// Replica keeps its share till membership is known, duplicated metrics are better than missing ones
+			log.Warningf("Unable to renew Lease of the metrics exporter replica %s err: %v", identity, err)
+		}
+		if current, err := membership.members(ctx); err == nil {
+			if !slices.Equal(current, members) {
+				log.Infof("Metrics exporter replicas changed: %v", current)
+				members = current
+				ring = newHashRing(members)
+				// Rebalance immediately
+				discovered = time.Time{}
+			}
+		} else {
+			log.Warningf("Unable to list metrics exporter replicas err: %v", err)
+		}
+
+		if (ring != nil) && (time.Since(discovered) >= config.DiscoveryPeriod) {
+			if err := e.syncWatchedShard(kubeClient, chiLister, ring, identity); err == nil {
+				discovered = time.Now()
+			} else {
+				// Replica keeps watched CHIs till discovery succeeds, otherwise all of them would be forgotten
+				log.Warningf("Unable to discover ClickHouseInstallations, keep watched CHIs err: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			membership.release(context.Background())
+			log.Infof("Metrics exporter replica %s left group %s/%s", identity, namespace, config.Group)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncWatchedShard syncs watched CHIs with CHIs discovered and owned by the replica.
+// Watched CHIs are not touched in case discovery fails.
+func (e *Exporter) syncWatchedShard(
+	kubeClient kube.Interface,
+	chiLister chopListers.ClickHouseInstallationLister,
+	ring *hashRing,
+	identity string,
+) error {
+	chis, err := chiLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[string]*metrics.WatchedCHI)
+	for _, chi := range e.selectWatchedCHIs(kubeClient, chis, func(key string) bool {
+		return ring.owner(key) == identity
+	}) {
+		owned[chi.IndexKey()] = chi
+	}
+
+	// Forget CHIs owned by other replicas now or deleted
+	e.mutex.RLock()
+	watched := e.chInstallations.slice()
+	e.mutex.RUnlock()
+	for _, chi := range watched {
+		if _, ok := owned[chi.IndexKey()]; !ok {
+			e.removeFromWatched(chi)
+		}
+	}
+	for _, chi := range owned {
+		e.updateWatched(chi)
+	}
+	log.V(1).Infof("Metrics exporter replica %s watches %d CHIs", identity, len(owned))
+	return nil
+}
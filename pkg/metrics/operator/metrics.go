@@ -117,6 +117,10 @@ func getLabelsFromName(chi BaseInfoGetter) (labels []string, values []string) {
 	return []string{"chi", "namespace"}, []string{chi.GetName(), chi.GetNamespace()}
 }
 
+func getLabelsFromNameCHK(chk BaseInfoGetter) (labels []string, values []string) {
+	return []string{"chk", "namespace"}, []string{chk.GetName(), chk.GetNamespace()}
+}
+
 func getLabelsFromLabels(chi BaseInfoGetter) (labels []string, values []string) {
 	return util.MapGetSortedKeysAndValues(chi.GetLabels())
 }
@@ -133,7 +137,19 @@ func getLabelsFromAnnotations(chi BaseInfoGetter) (labels []string, values []str
 }
 
 func GetMandatoryLabelsAndValues(cr BaseInfoGetter) (labels []string, values []string) {
-	labelsFromNames, valuesFromNames := getLabelsFromName(cr)
+	return getMandatoryLabelsAndValues(getLabelsFromName, cr)
+}
+
+// GetMandatoryLabelsAndValuesCHK gets mandatory labels of the ClickHouseKeeperInstallation
+func GetMandatoryLabelsAndValuesCHK(cr BaseInfoGetter) (labels []string, values []string) {
+	return getMandatoryLabelsAndValues(getLabelsFromNameCHK, cr)
+}
+
+func getMandatoryLabelsAndValues(
+	fromName func(BaseInfoGetter) ([]string, []string),
+	cr BaseInfoGetter,
+) (labels []string, values []string) {
+	labelsFromNames, valuesFromNames := fromName(cr)
 	labels = append(labels, labelsFromNames...)
 	values = append(values, valuesFromNames...)
 
@@ -59,6 +59,12 @@ const (
 	// DirPathSecretFilesConfig specifies full path to folder, where secrets are mounted
 	DirPathSecretFilesConfig = DirPathConfigRoot + "/" + "secrets.d" + "/"
 
+	// DirPathTLS specifies full path to folder, where TLS certificate, key and CA certificate are mounted
+	DirPathTLS = DirPathConfigRoot + "/" + "tls" + "/"
+
+	// TLSCACertificateFile specifies file name of the CA certificate within DirPathTLS
+	TLSCACertificateFile = "ca.crt"
+
 	// DirPathDataStorage specifies full path of data folder where ClickHouse would place its data storage
 	DirPathDataStorage = "/var/lib/clickhouse"
 
@@ -99,6 +105,8 @@ const (
 	configSettings      = "settings"
 	configUsers         = "users"
 	configZookeeper     = "zookeeper"
+	configOpenSSL       = "openssl"
+	configDiscovery     = "discovery"
 )
 
 const (
@@ -17,6 +17,9 @@ package config
 import (
 	"bytes"
 	"fmt"
+
+	core "k8s.io/api/core/v1"
+
 	"github.com/altinity/clickhouse-operator/pkg/model/common/config"
 
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
@@ -26,9 +29,16 @@ import (
 )
 
 const (
-	InternodeClusterSecretEnvName = "CLICKHOUSE_INTERNODE_CLUSTER_SECRET"
+	InternodeClusterSecretEnvName  = "CLICKHOUSE_INTERNODE_CLUSTER_SECRET"
+	ZookeeperIdentityEnvNamePrefix = "CLICKHOUSE_ZOOKEEPER_IDENTITY"
 )
 
+// ZookeeperIdentityEnvName builds name of the ENV var with zookeeper identity of the cluster
+func ZookeeperIdentityEnvName(cluster string) string {
+	name, _ := util.BuildShellEnvVarName(ZookeeperIdentityEnvNamePrefix + "_" + cluster)
+	return name
+}
+
 const (
 	// Pattern for string path used in <distributed_ddl><path>XXX</path></distributed_ddl>
 	DistributedDDLPathPattern = "/clickhouse/%s/task_queue/ddl"
@@ -102,9 +112,28 @@ func (c *Generator) getQuotas() string {
 	return c.opts.Quotas.ClickHouseConfig(configQuotas)
 }
 
+// getHostZookeeperConfig gets zookeeper config of the host.
+// Host switched to the target keeper by the keeper migration uses nodes of the target.
+func (c *Generator) getHostZookeeperConfig(host *chi.Host) *chi.ZookeeperConfig {
+	zk := host.GetZookeeper()
+	cr, ok := c.cr.(*chi.ClickHouseInstallation)
+	if !ok || zk.IsEmpty() || zk.HasKeeperRef() {
+		return zk
+	}
+
+	migration := cr.EnsureStatus().GetKeeperMigration()
+	if !migration.IsHostSwitched(host.GetRuntime().GetAddress().GetStatefulSet()) || (len(migration.Nodes) == 0) {
+		return zk
+	}
+
+	switched := zk.DeepCopy()
+	switched.Nodes = migration.Nodes.DeepCopy()
+	return switched
+}
+
 // getHostZookeeper creates data for "zookeeper.xml"
 func (c *Generator) getHostZookeeper(host *chi.Host) string {
-	zk := host.GetZookeeper()
+	zk := c.getHostZookeeperConfig(host)
 
 	if zk.IsEmpty() {
 		// No Zookeeper nodes provided
@@ -157,7 +186,11 @@ func (c *Generator) getHostZookeeper(host *chi.Host) string {
 	}
 
 	// Append identity
-	if len(zk.Identity) > 0 {
+	switch {
+	case zk.HasIdentitySecret():
+		// Use identity via ENV var from secret
+		util.Iline(b, 8, `<identity from_env="%s" />`, ZookeeperIdentityEnvName(host.Runtime.Address.ClusterName))
+	case len(zk.Identity) > 0:
 		util.Iline(b, 8, "<identity>%s</identity>", zk.Identity)
 	}
 
@@ -180,11 +213,66 @@ func (c *Generator) getHostZookeeper(host *chi.Host) string {
 	return b.String()
 }
 
-// chiHostsNum count hosts according to the options
-func (c *Generator) chiHostsNum(selector *config.HostSelector) int {
+// hasTLSCA checks whether Secret with TLS certificate provides CA certificate.
+// Without CA certificate peers are verified by the default CA only.
+func (c *Generator) hasTLSCA() bool {
+	cr, ok := c.cr.(*chi.ClickHouseInstallation)
+	return ok && cr.EnsureStatus().GetTLS().HasCA()
+}
+
+// getOpenSSL creates data for "openssl.xml"
+func (c *Generator) getOpenSSL() string {
+	if !c.opts.TLS.IsEnabled() {
+		// No TLS certificates issued by the operator
+		return ""
+	}
+
+	b := &bytes.Buffer{}
+	// <yandex>
+	//		<openSSL>
+	util.Iline(b, 0, "<"+xmlTagYandex+">")
+	util.Iline(b, 4, "<openSSL>")
+
+	// Server and client share the same certificate, which is issued for both server and client auth
+	for _, side := range []string{"server", "client"} {
+		// <server>
+		//		<certificateFile>/etc/clickhouse-server/tls/tls.crt</certificateFile>
+		//		<privateKeyFile>/etc/clickhouse-server/tls/tls.key</privateKeyFile>
+		//		<caConfig>/etc/clickhouse-server/tls/ca.crt</caConfig>
+		//		<verificationMode>relaxed</verificationMode>
+		// </server>
+		util.Iline(b, 8, "<%s>", side)
+		util.Iline(b, 8, "    <certificateFile>%s</certificateFile>", DirPathTLS+core.TLSCertKey)
+		util.Iline(b, 8, "    <privateKeyFile>%s</privateKeyFile>", DirPathTLS+core.TLSPrivateKeyKey)
+		if c.hasTLSCA() {
+			util.Iline(b, 8, "    <caConfig>%s</caConfig>", DirPathTLS+TLSCACertificateFile)
+		}
+		util.Iline(b, 8, "    <verificationMode>%s</verificationMode>", c.opts.TLS.GetVerificationMode())
+		util.Iline(b, 8, "    <loadDefaultCAFile>true</loadDefaultCAFile>")
+		util.Iline(b, 8, "    <cacheSessions>true</cacheSessions>")
+		util.Iline(b, 8, "    <disableProtocols>sslv2,sslv3</disableProtocols>")
+		util.Iline(b, 8, "    <preferServerCiphers>true</preferServerCiphers>")
+		if side == "client" {
+			util.Iline(b, 8, "    <invalidCertificateHandler>")
+			util.Iline(b, 8, "        <name>RejectCertificateHandler</name>")
+			util.Iline(b, 8, "    </invalidCertificateHandler>")
+		}
+		util.Iline(b, 8, "</%s>", side)
+	}
+
+	//		</openSSL>
+	// </yandex>
+	util.Iline(b, 4, "</openSSL>")
+	util.Iline(b, 0, "</"+xmlTagYandex+">")
+
+	return b.String()
+}
+
+// autoClustersHostsNum count hosts to be listed in auto-generated clusters according to the options
+func (c *Generator) autoClustersHostsNum(selector *config.HostSelector) int {
 	num := 0
 	c.cr.WalkHosts(func(host *chi.Host) error {
-		if selector.Include(host) {
+		if c.includeInAutoClusters(host, selector) {
 			num++
 		}
 		return nil
@@ -192,6 +280,13 @@ func (c *Generator) chiHostsNum(selector *config.HostSelector) int {
 	return num
 }
 
+// includeInAutoClusters checks whether host is to be listed in auto-generated clusters.
+// Hosts of discovery clusters register themselves and are not listed statically,
+// thus adding or removing them does not change remote_servers
+func (c *Generator) includeInAutoClusters(host *chi.Host, selector *config.HostSelector) bool {
+	return selector.Include(host) && !host.GetCluster().GetDiscovery().IsEnabled()
+}
+
 // clusterHostsNum count hosts according to the options
 func (c *Generator) clusterHostsNum(cluster chi.ICluster, selector *config.HostSelector) int {
 	num := 0
@@ -234,6 +329,49 @@ func (c *Generator) getRemoteServersReplica(host *chi.Host, b *bytes.Buffer) {
 	util.Iline(b, 16, "</replica>")
 }
 
+// getRemoteServersSecret writes cluster secret
+func (c *Generator) getRemoteServersSecret(cluster chi.ICluster, b *bytes.Buffer, indent int) {
+	// <secret>VALUE</secret>
+	switch cluster.GetSecret().Source() {
+	case chi.ClusterSecretSourcePlaintext:
+		// Secret value is explicitly specified
+		util.Iline(b, indent, "<secret>%s</secret>", cluster.GetSecret().Value)
+	case chi.ClusterSecretSourceSecretRef, chi.ClusterSecretSourceAuto:
+		// Use secret via ENV var from secret
+		util.Iline(b, indent, `<secret from_env="%s" />`, InternodeClusterSecretEnvName)
+	}
+}
+
+// getRemoteServersDiscovery writes discovery-based cluster definition
+func (c *Generator) getRemoteServersDiscovery(cluster chi.ICluster, b *bytes.Buffer) {
+	// <discovery>
+	//		<path>XXX</path>
+	//		<secret>XXX</secret>
+	// </discovery>
+	util.Iline(b, 12, "<discovery>")
+	util.Iline(b, 12, "    <path>%s</path>", c.getDiscoveryPath(cluster))
+	c.getRemoteServersSecret(cluster, b, 16)
+	util.Iline(b, 12, "</discovery>")
+}
+
+// getDiscoveryPath gets ZooKeeper/Keeper path hosts of the cluster register themselves at
+func (c *Generator) getDiscoveryPath(cluster chi.ICluster) string {
+	if path := cluster.GetDiscovery().GetPath(); path.HasValue() {
+		return path.Value()
+	}
+	return "/clickhouse/discovery/" + cluster.GetRuntime().GetAddress().GetCRName() + "/" + cluster.GetName()
+}
+
+// hasDiscoveryClusters checks whether any cluster is defined via cluster discovery
+func (c *Generator) hasDiscoveryClusters() bool {
+	found := false
+	c.cr.WalkClusters(func(cluster chi.ICluster) error {
+		found = found || cluster.GetDiscovery().IsEnabled()
+		return nil
+	})
+	return found
+}
+
 // getRemoteServers creates "remote_servers.xml" content and calculates data generation parameters for other sections
 func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 	if selector == nil {
@@ -245,6 +383,9 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 	// <yandex>
 	//		<remote_servers>
 	util.Iline(b, 0, "<"+xmlTagYandex+">")
+	if c.hasDiscoveryClusters() {
+		util.Iline(b, 4, "<allow_experimental_cluster_discovery>1</allow_experimental_cluster_discovery>")
+	}
 	util.Iline(b, 4, "<remote_servers>")
 
 	util.Iline(b, 8, "<!-- User-specified clusters -->")
@@ -258,16 +399,17 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 		// <my_cluster_name>
 		util.Iline(b, 8, "<%s>", cluster.GetName())
 
-		// <secret>VALUE</secret>
-		switch cluster.GetSecret().Source() {
-		case chi.ClusterSecretSourcePlaintext:
-			// Secret value is explicitly specified
-			util.Iline(b, 12, "<secret>%s</secret>", cluster.GetSecret().Value)
-		case chi.ClusterSecretSourceSecretRef, chi.ClusterSecretSourceAuto:
-			// Use secret via ENV var from secret
-			util.Iline(b, 12, `<secret from_env="%s" />`, InternodeClusterSecretEnvName)
+		if cluster.GetDiscovery().IsEnabled() {
+			// Hosts register themselves, no need to list shards and replicas
+			c.getRemoteServersDiscovery(cluster, b)
+			// </my_cluster_name>
+			util.Iline(b, 8, "</%s>", cluster.GetName())
+			return nil
 		}
 
+		// <secret>VALUE</secret>
+		c.getRemoteServersSecret(cluster, b, 12)
+
 		// Build each shard XML
 		cluster.WalkShards(func(index int, shard chi.IShard) error {
 			if c.shardHostsNum(shard, selector) < 1 {
@@ -308,7 +450,7 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 
 	// Auto-generated clusters
 
-	if c.chiHostsNum(selector) < 1 {
+	if c.autoClustersHostsNum(selector) < 1 {
 		util.Iline(b, 8, "<!-- Autogenerated clusters are skipped due to absence of hosts -->")
 	} else {
 		util.Iline(b, 8, "<!-- Autogenerated clusters -->")
@@ -322,7 +464,7 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 		util.Iline(b, 8, "    <shard>")
 		util.Iline(b, 8, "        <internal_replication>true</internal_replication>")
 		c.cr.WalkHosts(func(host *chi.Host) error {
-			if selector.Include(host) {
+			if c.includeInAutoClusters(host, selector) {
 				c.getRemoteServersReplica(host, b)
 			}
 			return nil
@@ -339,7 +481,7 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 		clusterName = AllShardsOneReplicaClusterName
 		util.Iline(b, 8, "<%s>", clusterName)
 		c.cr.WalkHosts(func(host *chi.Host) error {
-			if selector.Include(host) {
+			if c.includeInAutoClusters(host, selector) {
 				// <shard>
 				//     <internal_replication>
 				util.Iline(b, 12, "<shard>")
@@ -361,6 +503,10 @@ func (c *Generator) getRemoteServers(selector *config.HostSelector) string {
 		clusterName = AllClustersClusterName
 		util.Iline(b, 8, "<%s>", clusterName)
 		c.cr.WalkClusters(func(cluster chi.ICluster) error {
+			if cluster.GetDiscovery().IsEnabled() {
+				// Hosts of the discovery cluster are not listed statically
+				return nil
+			}
 			cluster.WalkShards(func(index int, shard chi.IShard) error {
 				if c.shardHostsNum(shard, selector) < 1 {
 					// Skip empty shard
@@ -404,7 +550,7 @@ func (c *Generator) getHostMacros(host *chi.Host) string {
 	util.Iline(b, 0, "    <macros>")
 
 	// <installation>CHI-name-macros-value</installation>
-	util.Iline(b, 8, "<installation>%s</installation>", host.Runtime.Address.CHIName)
+	util.Iline(b, 8, "<installation>%s</installation>", c.getMacrosInstallation(host))
 
 	// <CLUSTER_NAME>cluster-name-macros-value</CLUSTER_NAME>
 	// util.Iline(b, 8, "<%s>%[2]s</%[1]s>", replica.Address.ClusterName, c.getMacrosCluster(replica.Address.ClusterName))
@@ -432,6 +578,44 @@ func (c *Generator) getHostMacros(host *chi.Host) string {
 	return b.String()
 }
 
+// getHostDiscovery creates "discovery.xml" content - shard the host registers itself in
+func (c *Generator) getHostDiscovery(host *chi.Host) string {
+	cluster := host.GetCluster()
+	if !cluster.GetDiscovery().IsEnabled() {
+		return ""
+	}
+
+	b := &bytes.Buffer{}
+
+	// <yandex>
+	//     <remote_servers>
+	//         <my_cluster_name>
+	//             <discovery>
+	//                 <shard>1-based shard index within cluster</shard>
+	util.Iline(b, 0, "<"+xmlTagYandex+">")
+	util.Iline(b, 4, "<remote_servers>")
+	util.Iline(b, 8, "<%s>", cluster.GetName())
+	util.Iline(b, 12, "<discovery>")
+	util.Iline(b, 16, "<shard>%d</shard>", host.Runtime.Address.ShardIndex+1)
+	util.Iline(b, 12, "</discovery>")
+	util.Iline(b, 8, "</%s>", cluster.GetName())
+	util.Iline(b, 4, "</remote_servers>")
+	util.Iline(b, 0, "</"+xmlTagYandex+">")
+
+	return b.String()
+}
+
+// getMacrosInstallation gets value of the <installation> macro.
+// Federated cluster shares the same installation among all its parts, so all parts use the same Keeper paths
+func (c *Generator) getMacrosInstallation(host *chi.Host) string {
+	if cluster, ok := host.GetCluster().(*chi.Cluster); ok {
+		if installation := cluster.GetFederation().GetInstallation(); installation.HasValue() {
+			return installation.Value()
+		}
+	}
+	return host.Runtime.Address.CHIName
+}
+
 // getHostHostnameAndPorts creates "ports.xml" content
 func (c *Generator) getHostHostnameAndPorts(host *chi.Host) string {
 
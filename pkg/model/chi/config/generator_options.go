@@ -27,6 +27,8 @@ type GeneratorOptions struct {
 
 	Settings *api.Settings
 	Files    *api.Settings
+
+	TLS *api.TLS
 }
 
 func defaultSelectorIncludeAll() *config.HostSelector {
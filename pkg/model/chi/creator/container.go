@@ -50,6 +50,24 @@ func (cm *ContainerManager) EnsureLogContainer(statefulSet *apps.StatefulSet) {
 	cm.ensureContainerSpecifiedClickHouseLog(statefulSet)
 }
 
+// SetupTroubleshootCommand makes ClickHouse container fall back to sleep in case clickhouse-server fails to start
+func (cm *ContainerManager) SetupTroubleshootCommand(container *core.Container) {
+	sleep := " || sleep 1800"
+	if len(container.Command) > 0 {
+		// In case we have user-specified command, let's
+		// append troubleshooting-capable tail and hope for the best
+		container.Command[len(container.Command)-1] += sleep
+	} else {
+		// Assume standard ClickHouse container is used
+		// Substitute entrypoint with troubleshooting-capable command
+		container.Command = []string{
+			"/bin/sh",
+			"-c",
+			"/entrypoint.sh" + sleep,
+		}
+	}
+}
+
 // getContainerClickHouse(
 func (cm *ContainerManager) getContainerClickHouse(statefulSet *apps.StatefulSet) (*core.Container, bool) {
 	return k8s.StatefulSetContainerGet(statefulSet, config.ClickHouseContainerName, 0)
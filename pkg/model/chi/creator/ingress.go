@@ -0,0 +1,210 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creator
+
+import (
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	chi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/macro"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/namer"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/tags/labeler"
+	"github.com/altinity/clickhouse-operator/pkg/model/common/creator"
+	commonMacro "github.com/altinity/clickhouse-operator/pkg/model/common/macro"
+)
+
+// IngressManager creates Ingress and Gateway API routes, which expose CHI, cluster or shard outside of k8s cluster.
+// Ingress and routes expose the same scope as the corresponding Service does, so they are tagged alike.
+type IngressManager struct {
+	cr      chi.ICustomResource
+	or      interfaces.IOwnerReferencesManager
+	tagger  interfaces.ITagger
+	macro   interfaces.IMacro
+	namer   interfaces.INameManager
+	labeler interfaces.ILabeler
+}
+
+func NewIngressManager() *IngressManager {
+	return &IngressManager{
+		or:      NewOwnerReferencer(),
+		macro:   commonMacro.New(macro.List),
+		namer:   namer.New(),
+		labeler: nil,
+	}
+}
+
+func (m *IngressManager) CreateIngress(what interfaces.IngressType, params ...any) *networking.Ingress {
+	switch what {
+	case interfaces.IngressCR:
+		return m.createIngressCR()
+	case interfaces.IngressCluster:
+		if len(params) > 0 {
+			return m.createIngressCluster(params[0].(*chi.Cluster))
+		}
+	case interfaces.IngressShard:
+		if len(params) > 0 {
+			return m.createIngressShard(params[0].(*chi.ChiShard))
+		}
+	}
+	panic("unknown ingress type")
+}
+
+func (m *IngressManager) CreateRoute(what interfaces.IngressType, params ...any) *unstructured.Unstructured {
+	switch what {
+	case interfaces.IngressCR:
+		return m.createRouteCR()
+	case interfaces.IngressCluster:
+		if len(params) > 0 {
+			return m.createRouteCluster(params[0].(*chi.Cluster))
+		}
+	case interfaces.IngressShard:
+		if len(params) > 0 {
+			return m.createRouteShard(params[0].(*chi.ChiShard))
+		}
+	}
+	panic("unknown route type")
+}
+
+func (m *IngressManager) SetCR(cr chi.ICustomResource) {
+	m.cr = cr
+	m.labeler = labeler.New(cr)
+}
+func (m *IngressManager) SetTagger(tagger interfaces.ITagger) {
+	m.tagger = tagger
+}
+
+// createIngressCR creates new networking.Ingress for specified CR
+func (m *IngressManager) createIngressCR() *networking.Ingress {
+	cr, ok := m.cr.(*chi.ClickHouseInstallation)
+	if !ok {
+		return nil
+	}
+	if template, ok := cr.GetRootIngressTemplate(); ok {
+		// .templates.IngressTemplate specified
+		return creator.CreateIngressFromTemplate(
+			template,
+			cr.GetNamespace(),
+			m.namer.Name(interfaces.NameIngress, cr),
+			m.tagger.Label(interfaces.LabelServiceCR, cr),
+			m.tagger.Annotate(interfaces.AnnotateServiceCR, cr),
+			m.or.CreateOwnerReferences(cr),
+			m.macro.Scope(cr),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create ingress
+	return nil
+}
+
+// createIngressCluster creates new networking.Ingress for specified Cluster
+func (m *IngressManager) createIngressCluster(cluster *chi.Cluster) *networking.Ingress {
+	if template, ok := cluster.GetIngressTemplate(); ok {
+		// .templates.IngressTemplate specified
+		return creator.CreateIngressFromTemplate(
+			template,
+			cluster.GetRuntime().GetAddress().GetNamespace(),
+			m.namer.Name(interfaces.NameIngress, cluster),
+			m.tagger.Label(interfaces.LabelServiceCluster, cluster),
+			m.tagger.Annotate(interfaces.AnnotateServiceCluster, cluster),
+			m.or.CreateOwnerReferences(m.cr),
+			m.macro.Scope(cluster),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create ingress
+	return nil
+}
+
+// createIngressShard creates new networking.Ingress for specified Shard
+func (m *IngressManager) createIngressShard(shard *chi.ChiShard) *networking.Ingress {
+	if template, ok := shard.GetIngressTemplate(); ok {
+		// .templates.IngressTemplate specified
+		return creator.CreateIngressFromTemplate(
+			template,
+			shard.GetRuntime().GetAddress().GetNamespace(),
+			m.namer.Name(interfaces.NameIngress, shard),
+			m.tagger.Label(interfaces.LabelServiceShard, shard),
+			m.tagger.Annotate(interfaces.AnnotateServiceShard, shard),
+			m.or.CreateOwnerReferences(m.cr),
+			m.macro.Scope(shard),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create ingress
+	return nil
+}
+
+// createRouteCR creates new Gateway API route for specified CR
+func (m *IngressManager) createRouteCR() *unstructured.Unstructured {
+	cr, ok := m.cr.(*chi.ClickHouseInstallation)
+	if !ok {
+		return nil
+	}
+	if template, ok := cr.GetRootRouteTemplate(); ok {
+		// .templates.RouteTemplate specified
+		return creator.CreateRouteFromTemplate(
+			template,
+			cr.GetNamespace(),
+			m.namer.Name(interfaces.NameRoute, cr),
+			m.tagger.Label(interfaces.LabelServiceCR, cr),
+			m.tagger.Annotate(interfaces.AnnotateServiceCR, cr),
+			m.or.CreateOwnerReferences(cr),
+			m.macro.Scope(cr),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create route
+	return nil
+}
+
+// createRouteCluster creates new Gateway API route for specified Cluster
+func (m *IngressManager) createRouteCluster(cluster *chi.Cluster) *unstructured.Unstructured {
+	if template, ok := cluster.GetRouteTemplate(); ok {
+		// .templates.RouteTemplate specified
+		return creator.CreateRouteFromTemplate(
+			template,
+			cluster.GetRuntime().GetAddress().GetNamespace(),
+			m.namer.Name(interfaces.NameRoute, cluster),
+			m.tagger.Label(interfaces.LabelServiceCluster, cluster),
+			m.tagger.Annotate(interfaces.AnnotateServiceCluster, cluster),
+			m.or.CreateOwnerReferences(m.cr),
+			m.macro.Scope(cluster),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create route
+	return nil
+}
+
+// createRouteShard creates new Gateway API route for specified Shard
+func (m *IngressManager) createRouteShard(shard *chi.ChiShard) *unstructured.Unstructured {
+	if template, ok := shard.GetRouteTemplate(); ok {
+		// .templates.RouteTemplate specified
+		return creator.CreateRouteFromTemplate(
+			template,
+			shard.GetRuntime().GetAddress().GetNamespace(),
+			m.namer.Name(interfaces.NameRoute, shard),
+			m.tagger.Label(interfaces.LabelServiceShard, shard),
+			m.tagger.Annotate(interfaces.AnnotateServiceShard, shard),
+			m.or.CreateOwnerReferences(m.cr),
+			m.macro.Scope(shard),
+			m.labeler,
+		)
+	}
+	// No template specified, no need to create route
+	return nil
+}
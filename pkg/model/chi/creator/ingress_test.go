@@ -0,0 +1,188 @@
+package creator_test
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/yaml"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/creator"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/normalizer"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/managers"
+)
+
+const ingressTestCHI = `
+apiVersion: clickhouse.altinity.com/v1
+kind: ClickHouseInstallation
+metadata:
+  name: test
+  namespace: ns
+spec:
+  defaults:
+    templates:
+      ingressTemplate: ingress
+      routeTemplate: route
+  configuration:
+    clusters:
+      - name: exposed
+        templates:
+          clusterIngressTemplate: ingress
+          shardRouteTemplate: tls-route
+        layout:
+          shardsCount: 1
+      - name: internal
+        layout:
+          shardsCount: 1
+  templates:
+    ingressTemplates:
+      - name: ingress
+        metadata:
+          labels:
+            team: analytics
+          annotations:
+            ingress.kubernetes.io/host: "{chi}.example.com"
+        spec:
+          ingressClassName: nginx
+          rules:
+            - host: "{chi}-{cluster}.example.com"
+              http:
+                paths:
+                  - path: /
+                    pathType: Prefix
+                    backend:
+                      service:
+                        name: "clickhouse-{chi}"
+                        port:
+                          number: 8123
+    routeTemplates:
+      - name: route
+        spec:
+          parentRefs:
+            - name: gateway
+          rules:
+            - backendRefs:
+                - name: "clickhouse-{chi}"
+                  port: 8123
+      - name: tls-route
+        kind: TLSRoute
+        spec:
+          parentRefs:
+            - name: gateway
+          rules:
+            - backendRefs:
+                - name: "{chi}-{cluster}-{shard}"
+                  port: 8443
+`
+
+// newIngressTestManager creates ingress manager for normalized test CHI
+func newIngressTestManager(t *testing.T) (*creator.IngressManager, *api.ClickHouseInstallation) {
+	chop.New(nil, nil, "../../../../config/config.yaml")
+
+	cr := &api.ClickHouseInstallation{}
+	require.NoError(t, yaml.Unmarshal([]byte(ingressTestCHI), cr))
+	cr, err := normalizer.New(func(namespace, name string) (*core.Secret, error) {
+		return nil, nil
+	}, nil).CreateTemplated(cr, commonNormalizer.NewOptions())
+	require.NoError(t, err)
+
+	manager := creator.NewIngressManager()
+	manager.SetCR(cr)
+	manager.SetTagger(managers.NewTagManager(managers.TagManagerTypeClickHouse, cr))
+	return manager, cr
+}
+
+func TestCreateIngress(t *testing.T) {
+	manager, cr := newIngressTestManager(t)
+	exposed := cr.FindCluster("exposed").(*api.Cluster)
+	internal := cr.FindCluster("internal").(*api.Cluster)
+
+	tests := []struct {
+		name     string
+		what     interfaces.IngressType
+		params   []any
+		wantName string
+		wantHost string
+	}{
+		{name: "CR", what: interfaces.IngressCR, wantName: "clickhouse-test", wantHost: "test-{cluster}.example.com"},
+		{name: "cluster", what: interfaces.IngressCluster, params: []any{exposed}, wantName: "cluster-test-exposed", wantHost: "test-exposed.example.com"},
+		{name: "cluster without template", what: interfaces.IngressCluster, params: []any{internal}},
+		{name: "shard without template", what: interfaces.IngressShard, params: []any{exposed.GetShard(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := manager.CreateIngress(tt.what, tt.params...)
+			if tt.wantName == "" {
+				require.Nil(t, ingress)
+				return
+			}
+			require.NotNil(t, ingress)
+			require.Equal(t, tt.wantName, ingress.GetName())
+			require.Equal(t, "ns", ingress.GetNamespace())
+			require.Len(t, ingress.GetOwnerReferences(), 1)
+			require.Equal(t, "test", ingress.GetOwnerReferences()[0].Name)
+
+			// Labels and annotations of the template are kept along with the operator's ones
+			require.Equal(t, "analytics", ingress.GetLabels()["team"])
+			require.Equal(t, "test", ingress.GetLabels()["clickhouse.altinity.com/chi"])
+			require.Equal(t, "test.example.com", ingress.GetAnnotations()["ingress.kubernetes.io/host"])
+
+			// Macros in spec are expanded within the scope of the ingress
+			require.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+			require.Equal(t, tt.wantHost, ingress.Spec.Rules[0].Host)
+			require.Equal(t, "clickhouse-test", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+		})
+	}
+}
+
+func TestCreateRoute(t *testing.T) {
+	manager, cr := newIngressTestManager(t)
+	exposed := cr.FindCluster("exposed").(*api.Cluster)
+	internal := cr.FindCluster("internal").(*api.Cluster)
+
+	tests := []struct {
+		name        string
+		what        interfaces.IngressType
+		params      []any
+		wantName    string
+		wantKind    string
+		wantBackend string
+	}{
+		{name: "CR", what: interfaces.IngressCR, wantName: "clickhouse-test", wantKind: api.RouteKindHTTPRoute, wantBackend: "clickhouse-test"},
+		{name: "cluster without template", what: interfaces.IngressCluster, params: []any{exposed}},
+		{name: "shard", what: interfaces.IngressShard, params: []any{exposed.GetShard(0)}, wantName: "shard-test-exposed-0", wantKind: api.RouteKindTLSRoute, wantBackend: "test-exposed-0"},
+		{name: "shard without template", what: interfaces.IngressShard, params: []any{internal.GetShard(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := manager.CreateRoute(tt.what, tt.params...)
+			if tt.wantName == "" {
+				require.Nil(t, route)
+				return
+			}
+			require.NotNil(t, route)
+			require.Equal(t, tt.wantName, route.GetName())
+			require.Equal(t, "ns", route.GetNamespace())
+			require.Equal(t, "gateway.networking.k8s.io", route.GroupVersionKind().Group)
+			require.Equal(t, tt.wantKind, route.GetKind())
+			require.Len(t, route.GetOwnerReferences(), 1)
+			require.Equal(t, "test", route.GetLabels()["clickhouse.altinity.com/chi"])
+
+			// Macros in spec are expanded within the scope of the route
+			rules := route.Object["spec"].(map[string]interface{})["rules"].([]interface{})
+			backend := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})
+			require.Equal(t, tt.wantBackend, backend["name"])
+		})
+	}
+
+	// Template is not modified by macros expansion
+	template, ok := cr.GetRouteTemplate("tls-route")
+	require.True(t, ok)
+	rules := template.Spec["rules"].([]interface{})
+	backend := rules[0].(map[string]interface{})["backendRefs"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, "{chi}-{cluster}-{shard}", backend["name"])
+}
@@ -81,6 +81,23 @@ func (n *Namer) createCRServiceFQDN(cr api.ICustomResource, namespaceDomainPatte
 	)
 }
 
+// createServiceFQDN creates a FQDN for a Service with specified name in the namespace of the CR
+func (n *Namer) createServiceFQDN(cr api.ICustomResource, name string) string {
+	// Start with default pattern
+	pattern := patternServiceFQDN
+
+	if cr.GetSpec().GetNamespaceDomainPattern().HasValue() {
+		// NamespaceDomainPattern has been explicitly specified
+		pattern = "%s." + cr.GetSpec().GetNamespaceDomainPattern().Value()
+	}
+
+	return fmt.Sprintf(
+		pattern,
+		name,
+		cr.GetNamespace(),
+	)
+}
+
 // createClusterServiceName returns a name of a cluster's Service
 func (n *Namer) createClusterServiceName(cluster api.ICluster) string {
 	// Name can be generated either from default name pattern,
@@ -123,6 +140,56 @@ func (n *Namer) createShardServiceName(shard api.IShard) string {
 	return n.macro.Scope(shard).Line(pattern)
 }
 
+// createIngressName returns a name of an Ingress, exposing specified CR, cluster or shard
+func (n *Namer) createIngressName(obj any) string {
+	// Name can be generated either from default name pattern,
+	// or from personal name pattern provided in IngressTemplate.
+	// Default name is the same as the one of the Service of the same scope.
+	switch typed := obj.(type) {
+	case *api.ClickHouseInstallation:
+		if template, ok := typed.GetRootIngressTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createCRServiceName(typed)
+	case *api.Cluster:
+		if template, ok := typed.GetIngressTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createClusterServiceName(typed)
+	case *api.ChiShard:
+		if template, ok := typed.GetIngressTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createShardServiceName(typed)
+	}
+	return ""
+}
+
+// createRouteName returns a name of a Gateway API route, exposing specified CR, cluster or shard
+func (n *Namer) createRouteName(obj any) string {
+	// Name can be generated either from default name pattern,
+	// or from personal name pattern provided in RouteTemplate.
+	// Default name is the same as the one of the Service of the same scope.
+	switch typed := obj.(type) {
+	case *api.ClickHouseInstallation:
+		if template, ok := typed.GetRootRouteTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createCRServiceName(typed)
+	case *api.Cluster:
+		if template, ok := typed.GetRouteTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createClusterServiceName(typed)
+	case *api.ChiShard:
+		if template, ok := typed.GetRouteTemplate(); ok && template.GenerateName != "" {
+			return n.macro.Scope(typed).Line(template.GenerateName)
+		}
+		return n.createShardServiceName(typed)
+	}
+	return ""
+}
+
 // createStatefulSetName creates a name of a StatefulSet for ClickHouse instance
 func (n *Namer) createStatefulSetName(host *api.Host) string {
 	// Name can be generated either from default name pattern,
@@ -169,10 +236,19 @@ func (n *Namer) createStatefulSetServiceName(host *api.Host) string {
 // Is supposed to be used where network connection to a Pod is required.
 // NB: right now Pod's hostname points to a Service, through which Pod can be accessed.
 func (n *Namer) createPodHostname(host *api.Host) string {
+	if host.IsExternal() {
+		// External host has no pod, it is reachable by its own hostname
+		return n.createExternalHostname(host)
+	}
 	// Do not use Pod own hostname - point to appropriate StatefulSet's Service
 	return n.createStatefulSetServiceName(host)
 }
 
+// createExternalHostname creates hostname of the external host. Hostname may be specified as a pattern with macros
+func (n *Namer) createExternalHostname(host *api.Host) string {
+	return n.macro.Scope(host).Line(host.GetExternalHostname())
+}
+
 // createInstanceHostname returns hostname (pod-hostname + service or FQDN) which can be used as a replica name
 // in all places where ClickHouse requires replica name. These are such places as:
 // 1. "remote_servers.xml" config file
@@ -193,6 +269,11 @@ func (n *Namer) createInstanceHostname(host *api.Host) string {
 // createPodFQDN creates a fully qualified domain name of a pod
 // ss-1eb454-2-0.my-dev-domain.svc.cluster.local
 func (n *Namer) createPodFQDN(host *api.Host) string {
+	if host.IsExternal() {
+		// External host has no pod, it is reachable by its own hostname
+		return n.createExternalHostname(host)
+	}
+
 	// FQDN can be generated either from default pattern,
 	// or from personal pattern provided
 
@@ -215,7 +296,9 @@ func (n *Namer) createPodFQDN(host *api.Host) string {
 // createPodFQDNsOfCluster creates fully qualified domain names of all pods in a cluster
 func (n *Namer) createPodFQDNsOfCluster(cluster api.ICluster) (fqdns []string) {
 	cluster.WalkHosts(func(host *api.Host) error {
-		fqdns = append(fqdns, n.createPodFQDN(host))
+		if n.isFQDNsMember(host) {
+			fqdns = append(fqdns, n.createPodFQDN(host))
+		}
 		return nil
 	})
 	return fqdns
@@ -224,7 +307,9 @@ func (n *Namer) createPodFQDNsOfCluster(cluster api.ICluster) (fqdns []string) {
 // createPodFQDNsOfShard creates fully qualified domain names of all pods in a shard
 func (n *Namer) createPodFQDNsOfShard(shard api.IShard) (fqdns []string) {
 	shard.WalkHosts(func(host *api.Host) error {
-		fqdns = append(fqdns, n.createPodFQDN(host))
+		if n.isFQDNsMember(host) {
+			fqdns = append(fqdns, n.createPodFQDN(host))
+		}
 		return nil
 	})
 	return fqdns
@@ -233,12 +318,20 @@ func (n *Namer) createPodFQDNsOfShard(shard api.IShard) (fqdns []string) {
 // createPodFQDNsOfCHI creates fully qualified domain names of all pods in a CHI
 func (n *Namer) createPodFQDNsOfCHI(chi api.ICustomResource) (fqdns []string) {
 	chi.WalkHosts(func(host *api.Host) error {
-		fqdns = append(fqdns, n.createPodFQDN(host))
+		if n.isFQDNsMember(host) {
+			fqdns = append(fqdns, n.createPodFQDN(host))
+		}
 		return nil
 	})
 	return fqdns
 }
 
+// isFQDNsMember checks whether host is listed in FQDNs of the cluster, shard or CHI.
+// External hosts are listed only in case they are schema sources, since FQDNs are used to fetch schema from.
+func (n *Namer) isFQDNsMember(host *api.Host) bool {
+	return !host.IsExternal() || host.IsSchemaSource()
+}
+
 // createFQDN is a wrapper over pod FQDN function
 func (n *Namer) createFQDN(host *api.Host) string {
 	return n.createPodFQDN(host)
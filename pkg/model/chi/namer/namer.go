@@ -56,12 +56,20 @@ func (n *Namer) Name(what interfaces.NameType, params ...any) string {
 		cr := params[0].(api.ICustomResource)
 		namespaceDomainPattern := params[1].(*types.String)
 		return n.createCRServiceFQDN(cr, namespaceDomainPattern)
+	case interfaces.NameServiceFQDN:
+		cr := params[0].(api.ICustomResource)
+		name := params[1].(string)
+		return n.createServiceFQDN(cr, name)
 	case interfaces.NameClusterService:
 		cluster := params[0].(api.ICluster)
 		return n.createClusterServiceName(cluster)
 	case interfaces.NameShardService:
 		shard := params[0].(api.IShard)
 		return n.createShardServiceName(shard)
+	case interfaces.NameIngress:
+		return n.createIngressName(params[0])
+	case interfaces.NameRoute:
+		return n.createRouteName(params[0])
 	case interfaces.NameInstanceHostname:
 		host := params[0].(*api.Host)
 		return n.createInstanceHostname(host)
@@ -64,6 +64,8 @@ func hostApplyHostTemplate(host *chi.Host, template *chi.HostTemplate) {
 
 	host.Insecure = host.Insecure.MergeFrom(template.Spec.Insecure)
 	host.Secure = host.Secure.MergeFrom(template.Spec.Secure)
+	host.StorageMode = host.StorageMode.MergeFrom(template.Spec.StorageMode)
+	hostNormalizeStorageMode(host)
 
 	hostApplyHostTemplatePortDistribution(host, template)
 	hostApplyPortsFromSettings(host)
@@ -130,6 +132,22 @@ func hostApplyHostTemplatePortDistribution(host *chi.Host, template *chi.HostTem
 	}
 }
 
+// hostNormalizeStorageMode ensures host has known storage mode. Unspecified storage mode means persistent storage
+func hostNormalizeStorageMode(host *chi.Host) {
+	if !host.StorageMode.HasValue() {
+		return
+	}
+	switch host.StorageMode.Value() {
+	case
+		chi.HostStorageModePersistent,
+		chi.HostStorageModeEphemeral:
+		// Storage mode is known
+	default:
+		log.V(1).M(host).F().Warning("host: %s has unknown storage mode: %s, fallback to persistent", host.Name, host.StorageMode.Value())
+		host.StorageMode = nil
+	}
+}
+
 // hostApplyPortsFromSettings
 func hostApplyPortsFromSettings(host *chi.Host) {
 	// Use host personal settings at first
@@ -15,13 +15,17 @@
 package normalizer
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/google/uuid"
 
 	core "k8s.io/api/core/v1"
+	k8sLabels "k8s.io/apimachinery/pkg/labels"
 
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	chk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
 	chi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
 	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
@@ -42,19 +46,25 @@ import (
 	"github.com/altinity/clickhouse-operator/pkg/util"
 )
 
+// KeeperGetter gets ClickHouseKeeperInstallation referenced by zookeeper config
+type KeeperGetter func(namespace, name string) (*chk.ClickHouseKeeperInstallation, error)
+
 // Normalizer specifies structures normalizer
 type Normalizer struct {
 	secretGet subst.SecretGetter
+	keeperGet KeeperGetter
 	req       *Request
 	namer     interfaces.INameManager
 	macro     interfaces.IMacro
 	labeler   interfaces.ILabeler
 }
 
-// New creates new normalizer
-func New(secretGet subst.SecretGetter) *Normalizer {
+// New creates new normalizer.
+// Keeper getter may be nil, references to ClickHouseKeeperInstallation are not resolved then.
+func New(secretGet subst.SecretGetter, keeperGet KeeperGetter) *Normalizer {
 	return &Normalizer{
 		secretGet: secretGet,
+		keeperGet: keeperGet,
 		namer:     managers.NewNameManager(managers.NameManagerTypeClickHouse),
 		macro:     commonMacro.New(macro.List),
 		labeler:   labeler.New(nil),
@@ -149,7 +159,7 @@ func (n *Normalizer) normalizeTarget() (*chi.ClickHouseInstallation, error) {
 	n.finalize()
 	n.fillStatus()
 
-	return n.req.GetTarget(), nil
+	return n.req.GetTarget(), n.req.GetError()
 }
 
 func (n *Normalizer) normalizeSpec() {
@@ -162,6 +172,7 @@ func (n *Normalizer) normalizeSpec() {
 	n.req.GetTarget().GetSpecT().NamespaceDomainPattern = n.normalizeNamespaceDomainPattern(n.req.GetTarget().GetSpecT().NamespaceDomainPattern)
 	n.req.GetTarget().GetSpecT().Templating = n.normalizeTemplating(n.req.GetTarget().GetSpecT().Templating)
 	n.req.GetTarget().GetSpecT().Reconciling = n.normalizeReconciling(n.req.GetTarget().GetSpecT().Reconciling)
+	n.req.GetTarget().GetSpecT().Scaling = n.normalizeScaling(n.req.GetTarget().GetSpecT().Scaling)
 	n.req.GetTarget().GetSpecT().Defaults = n.normalizeDefaults(n.req.GetTarget().GetSpecT().Defaults)
 	n.req.GetTarget().GetSpecT().Configuration = n.normalizeConfiguration(n.req.GetTarget().GetSpecT().Configuration)
 	n.req.GetTarget().GetSpecT().Templates = n.normalizeTemplates(n.req.GetTarget().GetSpecT().Templates)
@@ -193,12 +204,33 @@ func (n *Normalizer) fillStatus() {
 	pods := make([]string, 0)
 	fqdns := make([]string, 0)
 	n.req.GetTarget().WalkHosts(func(host *chi.Host) error {
+		if host.IsExternal() {
+			// External host is not managed by the operator
+			return nil
+		}
 		pods = append(pods, n.namer.Name(interfaces.NamePod, host))
 		fqdns = append(fqdns, n.namer.Name(interfaces.NameFQDN, host))
 		return nil
 	})
 	ip, _ := chop.Get().ConfigManager.GetRuntimeParam(deployment.OPERATOR_POD_IP)
 	n.req.GetTarget().FillStatus(endpoint, pods, fqdns, ip)
+	n.fillStatusScaling()
+}
+
+// fillStatusScaling fills .status.scaling section, which backs `/scale` subresource
+func (n *Normalizer) fillStatusScaling() {
+	scaling := n.req.GetTarget().GetSpecT().GetScaling()
+	if scaling == nil {
+		return
+	}
+	cluster, _ := n.req.GetTarget().FindCluster(scaling.GetCluster()).(*chi.Cluster)
+	if cluster == nil {
+		return
+	}
+	selector := k8sLabels.SelectorFromSet(
+		labeler.New(n.req.GetTarget()).Selector(interfaces.SelectorClusterScope, cluster),
+	).String()
+	n.req.GetTarget().EnsureStatus().SetScaling(int32(cluster.Layout.ReplicasCount), selector)
 }
 
 // normalizeTaskID normalizes .spec.taskID
@@ -290,6 +322,7 @@ func (n *Normalizer) normalizeConfiguration(conf *chi.Configuration) *chi.Config
 		conf = chi.NewConfiguration()
 	}
 	conf.Zookeeper = n.normalizeConfigurationZookeeper(conf.Zookeeper)
+	conf.TLS = n.normalizeConfigurationTLS(conf.TLS)
 	n.normalizeConfigurationAllSettingsBasedSections(conf)
 	conf.Clusters = n.normalizeClusters(conf.Clusters)
 	return conf
@@ -314,6 +347,8 @@ func (n *Normalizer) normalizeTemplates(templates *chi.Templates) *chi.Templates
 	n.normalizePodTemplates(templates)
 	n.normalizeVolumeClaimTemplates(templates)
 	n.normalizeServiceTemplates(templates)
+	n.normalizeIngressTemplates(templates)
+	n.normalizeRouteTemplates(templates)
 	return templates
 }
 
@@ -352,10 +387,69 @@ func (n *Normalizer) normalizeReconciling(reconciling *chi.Reconciling) *chi.Rec
 		// Unknown value, fallback to default
 		reconciling.SetPolicy(chi.ReconcilingPolicyUnspecified)
 	}
+	switch strings.ToLower(reconciling.Order) {
+	case chi.ReconcilingOrderZone:
+		// Known value, overwrite it to ensure case-ness
+		reconciling.Order = chi.ReconcilingOrderZone
+		if reconciling.ZoneKey == "" {
+			reconciling.ZoneKey = core.LabelTopologyZone
+		}
+	default:
+		// Unknown value, fallback to default
+		reconciling.Order = chi.ReconcilingOrderShard
+	}
 	reconciling.SetCleanup(n.normalizeReconcilingCleanup(reconciling.GetCleanup()))
 	return reconciling
 }
 
+// normalizeScaling normalizes .spec.scaling
+func (n *Normalizer) normalizeScaling(scaling *chi.ChiScaling) *chi.ChiScaling {
+	if scaling == nil {
+		return nil
+	}
+	if scaling.Replicas.HasValue() && (scaling.Replicas.Value() < 1) {
+		// Scaling down to zero replicas is not supported, CHI has to be stopped instead
+		scaling.Replicas = nil
+	}
+	scaling.Autoscaler = n.normalizeAutoscaler(scaling.Autoscaler)
+	return scaling
+}
+
+// normalizeAutoscaler normalizes .spec.scaling.autoscaler
+func (n *Normalizer) normalizeAutoscaler(autoscaler *chi.ChiAutoscaler) *chi.ChiAutoscaler {
+	if autoscaler == nil {
+		return nil
+	}
+
+	autoscaler.Enabled = autoscaler.Enabled.Normalize(false)
+
+	// Unspecified min and max replicas stay unspecified, they are bound to current replicas count of the cluster
+	if autoscaler.MinReplicas.HasValue() && (autoscaler.MinReplicas.Value() < chi.AutoscalerMinReplicas) {
+		autoscaler.MinReplicas = types.NewInt32(chi.AutoscalerMinReplicas)
+	}
+	if autoscaler.MaxReplicas.HasValue() && (autoscaler.MaxReplicas.Value() < chi.AutoscalerMinReplicas) {
+		autoscaler.MaxReplicas = types.NewInt32(chi.AutoscalerMinReplicas)
+	}
+	if autoscaler.MinReplicas.HasValue() && autoscaler.MaxReplicas.HasValue() &&
+		(autoscaler.MaxReplicas.Value() < autoscaler.MinReplicas.Value()) {
+		// Max replicas can not be less than min replicas
+		autoscaler.MaxReplicas = types.NewInt32(autoscaler.MinReplicas.Value())
+	}
+	if !autoscaler.Cooldown.HasValue() || (autoscaler.Cooldown.Value() < 0) {
+		autoscaler.Cooldown = types.NewInt32(chi.AutoscalerDefaultCooldown)
+	}
+
+	// Non-positive target means metric is not used
+	if autoscaler.TargetQueries.Value() < 1 {
+		autoscaler.TargetQueries = nil
+	}
+	if autoscaler.TargetCPU.Value() < 1 {
+		autoscaler.TargetCPU = nil
+	}
+
+	return autoscaler
+}
+
 func (n *Normalizer) normalizeReconcilingCleanup(cleanup *chi.Cleanup) *chi.Cleanup {
 	if cleanup == nil {
 		cleanup = chi.NewCleanup()
@@ -420,6 +514,18 @@ func (n *Normalizer) normalizeServiceTemplates(templates *chi.Templates) {
 	}
 }
 
+func (n *Normalizer) normalizeIngressTemplates(templates *chi.Templates) {
+	for i := range templates.IngressTemplates {
+		n.normalizeIngressTemplate(&templates.IngressTemplates[i])
+	}
+}
+
+func (n *Normalizer) normalizeRouteTemplates(templates *chi.Templates) {
+	for i := range templates.RouteTemplates {
+		n.normalizeRouteTemplate(&templates.RouteTemplates[i])
+	}
+}
+
 // normalizeHostTemplate normalizes .spec.templates.hostTemplates
 func (n *Normalizer) normalizeHostTemplate(template *chi.HostTemplate) {
 	templates.NormalizeHostTemplate(template)
@@ -453,6 +559,20 @@ func (n *Normalizer) normalizeServiceTemplate(template *chi.ServiceTemplate) {
 	n.req.GetTarget().GetSpecT().GetTemplates().EnsureServiceTemplatesIndex().Set(template.Name, template)
 }
 
+// normalizeIngressTemplate normalizes .spec.templates.ingressTemplates
+func (n *Normalizer) normalizeIngressTemplate(template *chi.IngressTemplate) {
+	templates.NormalizeIngressTemplate(template)
+	// Introduce IngressTemplate into Index
+	n.req.GetTarget().GetSpecT().GetTemplates().EnsureIngressTemplatesIndex().Set(template.Name, template)
+}
+
+// normalizeRouteTemplate normalizes .spec.templates.routeTemplates
+func (n *Normalizer) normalizeRouteTemplate(template *chi.RouteTemplate) {
+	templates.NormalizeRouteTemplate(template)
+	// Introduce RouteTemplate into Index
+	n.req.GetTarget().GetSpecT().GetTemplates().EnsureRouteTemplatesIndex().Set(template.Name, template)
+}
+
 // normalizeUseTemplates is a wrapper to hold the name of normalized section
 func (n *Normalizer) normalizeUseTemplates(templates []*chi.TemplateRef) []*chi.TemplateRef {
 	return crTemplatesNormalizer.NormalizeTemplatesList(templates)
@@ -462,6 +582,7 @@ func (n *Normalizer) normalizeUseTemplates(templates []*chi.TemplateRef) []*chi.
 func (n *Normalizer) normalizeClusters(clusters []*chi.Cluster) []*chi.Cluster {
 	// We need to have at least one cluster available
 	clusters = n.ensureClusters(clusters)
+	n.ensureScalingCluster(clusters)
 	// Normalize all clusters
 	for i := range clusters {
 		clusters[i] = n.normalizeCluster(clusters[i])
@@ -469,6 +590,15 @@ func (n *Normalizer) normalizeClusters(clusters []*chi.Cluster) []*chi.Cluster {
 	return clusters
 }
 
+// ensureScalingCluster ensures scaled cluster is specified, the first cluster is scaled by default
+func (n *Normalizer) ensureScalingCluster(clusters []*chi.Cluster) {
+	scaling := n.req.GetTarget().GetSpecT().GetScaling()
+	if (scaling == nil) || scaling.Cluster.HasValue() || (len(clusters) == 0) {
+		return
+	}
+	scaling.Cluster = types.NewString(clusters[0].GetName())
+}
+
 // ensureClusters
 func (n *Normalizer) ensureClusters(clusters []*chi.Cluster) []*chi.Cluster {
 	// May be we have cluster(s) available
@@ -493,6 +623,13 @@ func (n *Normalizer) normalizeConfigurationZookeeper(zk *chi.ZookeeperConfig) *c
 		return nil
 	}
 
+	// Keeper reference has priority over explicitly specified nodes
+	if zk.HasKeeperRef() {
+		if nodes, ok := n.resolveKeeperRef(zk.GetKeeperRef()); ok {
+			zk.Nodes = nodes
+		}
+	}
+
 	// In case no ZK port specified - assign default
 	for i := range zk.Nodes {
 		// Convenience wrapper
@@ -510,6 +647,56 @@ func (n *Normalizer) normalizeConfigurationZookeeper(zk *chi.ZookeeperConfig) *c
 	return zk
 }
 
+// resolveKeeperRef resolves reference to ClickHouseKeeperInstallation into the list of its current nodes
+func (n *Normalizer) resolveKeeperRef(ref *chi.ZookeeperKeeperRef) (chi.ZookeeperNodes, bool) {
+	if n.keeperGet == nil {
+		return nil, false
+	}
+
+	namespace := ref.GetNamespace()
+	if namespace == "" {
+		namespace = n.req.GetTarget().GetNamespace()
+	}
+	keeper, err := n.keeperGet(namespace, ref.GetName())
+	if err != nil || keeper == nil {
+		log.V(1).F().Warning("unable to get CHK %s/%s referenced by zookeeper config. err: %v", namespace, ref.GetName(), err)
+		return nil, false
+	}
+
+	nodes := keeper.GetZookeeperNodes()
+	if len(nodes) == 0 {
+		return nil, false
+	}
+	return nodes, true
+}
+
+// tlsVolumeName specifies name of the volume with TLS certificates issued by the operator
+const tlsVolumeName = "tls-certificate"
+
+// normalizeConfigurationTLS normalizes .spec.configuration.tls
+func (n *Normalizer) normalizeConfigurationTLS(tls *chi.TLS) *chi.TLS {
+	if !tls.IsEnabled() {
+		return tls
+	}
+
+	// Certificates are provided to hosts via the secret, which is mounted into the TLS folder
+	n.req.AppendAdditionalVolume(core.Volume{
+		Name: tlsVolumeName,
+		VolumeSource: core.VolumeSource{
+			Secret: &core.SecretVolumeSource{
+				SecretName: n.namer.Name(interfaces.NameTLSSecret, n.req.GetTarget()),
+			},
+		},
+	})
+	n.req.AppendAdditionalVolumeMount(core.VolumeMount{
+		Name:      tlsVolumeName,
+		MountPath: config.DirPathTLS,
+		ReadOnly:  true,
+	})
+
+	return tls
+}
+
 func (n *Normalizer) appendClusterSecretEnvVar(cluster chi.ICluster) {
 	switch cluster.GetSecret().Source() {
 	case chi.ClusterSecretSourcePlaintext:
@@ -541,6 +728,21 @@ func (n *Normalizer) appendClusterSecretEnvVar(cluster chi.ICluster) {
 	}
 }
 
+// appendZookeeperIdentityEnvVar provides zookeeper identity of the cluster via ENV var, in case identity is kept in the Secret
+func (n *Normalizer) appendZookeeperIdentityEnvVar(cluster *chi.Cluster) {
+	if !cluster.Zookeeper.HasIdentitySecret() {
+		return
+	}
+	n.req.AppendAdditionalEnvVar(
+		core.EnvVar{
+			Name: config.ZookeeperIdentityEnvName(cluster.GetName()),
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: cluster.Zookeeper.GetIdentitySecret(),
+			},
+		},
+	)
+}
+
 // normalizeUsersList extracts usernames from provided 'users' settings and adds some extra usernames
 func (n *Normalizer) normalizeUsersList(users *chi.Settings, extraUsernames ...string) (usernames []string) {
 	usernames = append(usernames, users.Groups()...)
@@ -674,13 +876,19 @@ func (n *Normalizer) normalizeCluster(cluster *chi.Cluster) *chi.Cluster {
 	if cluster.Layout == nil {
 		cluster.Layout = chi.NewChiClusterLayout()
 	}
+	n.applyClusterScaling(cluster)
 	cluster.FillShardReplicaSpecified()
 	cluster.Layout = n.normalizeClusterLayoutShardsCountAndReplicasCount(cluster.Layout)
+	n.normalizeClusterFederation(cluster)
+	n.normalizeClusterDiscovery(cluster)
+	n.normalizeClusterFederationReplicasCount(cluster)
 	n.ensureClusterLayoutShards(cluster.Layout)
 	n.ensureClusterLayoutReplicas(cluster.Layout)
 
 	createHostsField(cluster)
+	n.normalizeClusterFederationHosts(cluster)
 	n.appendClusterSecretEnvVar(cluster)
+	n.appendZookeeperIdentityEnvVar(cluster)
 
 	// Loop over all shards and replicas inside shards and fill structure
 	cluster.WalkShards(func(index int, shard chi.IShard) error {
@@ -701,6 +909,180 @@ func (n *Normalizer) normalizeCluster(cluster *chi.Cluster) *chi.Cluster {
 	return cluster
 }
 
+// applyClusterScaling applies replicas count of the scaled cluster to its layout.
+// Replicas count decided by the autoscaler is applied while autoscaler is enabled.
+// Otherwise the latest changed of .spec.scaling.replicas and layout.replicasCount takes effect,
+// so neither `kubectl scale` nor later edits of the layout are silently ignored.
+// Status is not modified, values the decision is based on are recorded into status by the reconcile.
+func (n *Normalizer) applyClusterScaling(cluster *chi.Cluster) {
+	scaling := n.req.GetTarget().GetSpecT().GetScaling()
+	if !scaling.IsScaledCluster(cluster) {
+		return
+	}
+
+	status := n.req.GetTarget().Status
+	specReplicas := scaling.GetReplicas().IntValue()
+	layoutReplicas := cluster.Layout.ReplicasCount
+	autoscalerReplicas := 0
+	if scaling.GetAutoscaler().IsEnabled() {
+		// Decision of the autoscaler is applied while autoscaler is enabled only
+		autoscalerReplicas = status.GetScalingAutoscalerReplicas()
+	}
+
+	cluster.Runtime.LayoutReplicasCount = layoutReplicas
+	cluster.Layout.ReplicasCount = getScaledClusterReplicas(specReplicas, layoutReplicas, autoscalerReplicas, status)
+}
+
+// getScaledClusterReplicas gets replicas count of the scaled cluster having scaling replicas, layout replicas count
+// and replicas count decided by the autoscaler along with scaling replicas and layout replicas count as of the last reconcile
+func getScaledClusterReplicas(specReplicas, layoutReplicas, autoscalerReplicas int, status *chi.Status) int {
+	switch {
+	case autoscalerReplicas > 0:
+		return autoscalerReplicas
+	case specReplicas == 0:
+		// Layout as it is
+		return layoutReplicas
+	case specReplicas != status.GetScalingSpecReplicas():
+		// Scaling replicas are changed
+		return specReplicas
+	case layoutReplicas != status.GetScalingLayoutReplicas():
+		// Layout is changed, layout as it is
+		return layoutReplicas
+	case status.GetScalingReplicas() > 0:
+		// Nothing is changed, keep replicas count applied last time
+		return status.GetScalingReplicas()
+	default:
+		return specReplicas
+	}
+}
+
+// normalizeClusterFederation validates federation of the cluster.
+// Parts of the federation, including this CHI, have to own contiguous non-overlapping ranges of replicas,
+// starting with the first one, and remote parts have to specify hostnames of their hosts.
+// Invalid federation is reported and not applied.
+func (n *Normalizer) normalizeClusterFederation(cluster *chi.Cluster) {
+	federation := cluster.GetFederation()
+	if federation == nil {
+		return
+	}
+	if err := validateClusterFederation(federation, cluster.Layout.ReplicasCount); err != nil {
+		n.req.AppendError(fmt.Errorf("invalid federation of the cluster %s: %v", cluster.GetName(), err))
+		cluster.Federation = nil
+	}
+}
+
+// normalizeClusterDiscovery validates discovery mode of the cluster.
+// Invalid discovery mode is reported and not applied.
+func (n *Normalizer) normalizeClusterDiscovery(cluster *chi.Cluster) {
+	if !cluster.GetDiscovery().IsEnabled() {
+		return
+	}
+	if err := validateClusterDiscovery(cluster, n.req.GetTarget().GetReconciling()); err != nil {
+		n.req.AppendError(fmt.Errorf("invalid discovery mode of the cluster %s: %v", cluster.GetName(), err))
+		cluster.Discovery = nil
+	}
+}
+
+// validateClusterDiscovery validates discovery mode is applicable to the cluster
+func validateClusterDiscovery(cluster *chi.Cluster, reconciling *chi.Reconciling) error {
+	// Hosts register themselves in ZooKeeper/Keeper, thus it has to be configured
+	if cluster.Zookeeper.IsEmpty() && !cluster.Zookeeper.HasKeeperRef() {
+		return fmt.Errorf("zookeeper is not configured")
+	}
+	// Host of the discovery cluster can not be excluded from remote_servers before it is restarted,
+	// so explicitly requested wait for the host to be excluded can not be satisfied
+	if reconciling.IsReconcilingPolicyWait() {
+		return fmt.Errorf("hosts can not be excluded from the cluster, reconciling policy %s is not supported", chi.ReconcilingPolicyWait)
+	}
+	return nil
+}
+
+// validateClusterFederation validates replica ranges and hostnames of the federation parts
+func validateClusterFederation(federation *chi.ClusterFederation, localReplicasCount int) error {
+	type replicaRange struct {
+		name       string
+		start, end int
+	}
+	ranges := []replicaRange{
+		{
+			name:  "local",
+			start: federation.GetReplicaOffset(),
+			end:   federation.GetReplicaOffset() + localReplicasCount,
+		},
+	}
+	for i := range federation.GetParts() {
+		part := &federation.Parts[i]
+		if !part.Hostname.HasValue() {
+			return fmt.Errorf("part %s has no hostname specified", part.Name)
+		}
+		if part.GetReplicasCount() < 1 {
+			return fmt.Errorf("part %s owns no replicas", part.Name)
+		}
+		ranges = append(ranges, replicaRange{
+			name:  part.Name,
+			start: part.GetReplicaOffset(),
+			end:   part.GetReplicaEnd(),
+		})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+	next := 0
+	for _, r := range ranges {
+		switch {
+		case r.start < next:
+			return fmt.Errorf("replicas of the part %s overlap with the previous part, starts at %d", r.name, r.start)
+		case r.start > next:
+			return fmt.Errorf("replicas %d-%d are not owned by any part", next, r.start-1)
+		}
+		next = r.end
+	}
+	return nil
+}
+
+// normalizeClusterFederationReplicasCount enlarges layout of the federated cluster to all replicas of the federation.
+// Replicas owned by this CHI are placed starting at the federation replica offset,
+// thus replica names and {replica} macros are consistent among all parts of the federation
+func (n *Normalizer) normalizeClusterFederationReplicasCount(cluster *chi.Cluster) {
+	federation := cluster.GetFederation()
+	if federation == nil {
+		return
+	}
+	cluster.Layout.ReplicasCount = federation.GetReplicasCount(cluster.Layout.ReplicasCount)
+}
+
+// normalizeClusterFederationHosts marks hosts of the federated cluster, which are not owned by this CHI, as external.
+// External hosts are listed in remote_servers, however are not managed by this CHI
+func (n *Normalizer) normalizeClusterFederationHosts(cluster *chi.Cluster) {
+	federation := cluster.GetFederation()
+	if federation == nil {
+		return
+	}
+	for shardIndex := 0; shardIndex < cluster.Layout.ShardsCount; shardIndex++ {
+		for replicaIndex := 0; replicaIndex < cluster.Layout.ReplicasCount; replicaIndex++ {
+			part := federation.GetPart(replicaIndex)
+			if (part == nil) && (replicaIndex >= federation.GetReplicaOffset()) {
+				// Host is owned by this CHI
+				continue
+			}
+			host := cluster.GetOrCreateHost(shardIndex, replicaIndex)
+			host.External = types.NewStringBool(true)
+			if part == nil {
+				continue
+			}
+			host.Hostname = host.Hostname.MergeFrom(part.Hostname)
+			host.Insecure = host.Insecure.MergeFrom(part.Insecure)
+			host.Secure = host.Secure.MergeFrom(part.Secure)
+			host.TCPPort = host.TCPPort.MergeFrom(part.TCPPort)
+			host.TLSPort = host.TLSPort.MergeFrom(part.TLSPort)
+			host.HTTPPort = host.HTTPPort.MergeFrom(part.HTTPPort)
+			host.HTTPSPort = host.HTTPSPort.MergeFrom(part.HTTPSPort)
+			host.InterserverHTTPPort = host.InterserverHTTPPort.MergeFrom(part.InterserverHTTPPort)
+		}
+	}
+}
+
 // normalizeClusterLayoutShardsCountAndReplicasCount ensures at least 1 shard and 1 replica counters
 func (n *Normalizer) normalizeClusterSchemaPolicy(policy *chi.SchemaPolicy) *chi.SchemaPolicy {
 	if policy == nil {
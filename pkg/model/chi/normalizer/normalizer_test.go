@@ -0,0 +1,256 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	chi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+)
+
+func newFederationPart(name string, offset, count int32, hostname string) chi.ClusterFederationPart {
+	part := chi.ClusterFederationPart{
+		Name:          name,
+		ReplicaOffset: types.NewInt32(offset),
+		ReplicasCount: types.NewInt32(count),
+	}
+	if hostname != "" {
+		part.Hostname = types.NewString(hostname)
+	}
+	return part
+}
+
+func TestValidateClusterFederation(t *testing.T) {
+	const hostname = "chi-dr-{cluster}-{shard}-{replicaIndex}.dr.example.com"
+	tests := []struct {
+		name          string
+		offset        int32
+		localReplicas int
+		parts         []chi.ClusterFederationPart
+		wantErr       string
+	}{
+		{
+			name:          "local first",
+			offset:        0,
+			localReplicas: 2,
+			parts:         []chi.ClusterFederationPart{newFederationPart("dr", 2, 1, hostname)},
+		},
+		{
+			name:          "local last",
+			offset:        3,
+			localReplicas: 1,
+			parts: []chi.ClusterFederationPart{
+				newFederationPart("b", 1, 2, hostname),
+				newFederationPart("a", 0, 1, hostname),
+			},
+		},
+		{
+			name:          "local in the middle",
+			offset:        1,
+			localReplicas: 2,
+			parts: []chi.ClusterFederationPart{
+				newFederationPart("a", 0, 1, hostname),
+				newFederationPart("b", 3, 1, hostname),
+			},
+		},
+		{
+			name:          "no hostname",
+			offset:        0,
+			localReplicas: 1,
+			parts:         []chi.ClusterFederationPart{newFederationPart("dr", 1, 1, "")},
+			wantErr:       "no hostname",
+		},
+		{
+			name:          "no replicas",
+			offset:        0,
+			localReplicas: 1,
+			parts:         []chi.ClusterFederationPart{newFederationPart("dr", 1, 0, hostname)},
+			wantErr:       "owns no replicas",
+		},
+		{
+			name:          "overlaps local",
+			offset:        0,
+			localReplicas: 2,
+			parts:         []chi.ClusterFederationPart{newFederationPart("dr", 1, 2, hostname)},
+			wantErr:       "overlap",
+		},
+		{
+			name:          "parts overlap",
+			offset:        0,
+			localReplicas: 1,
+			parts: []chi.ClusterFederationPart{
+				newFederationPart("a", 1, 2, hostname),
+				newFederationPart("b", 2, 2, hostname),
+			},
+			wantErr: "overlap",
+		},
+		{
+			name:          "gap between parts",
+			offset:        0,
+			localReplicas: 1,
+			parts:         []chi.ClusterFederationPart{newFederationPart("dr", 2, 1, hostname)},
+			wantErr:       "replicas 1-1 are not owned",
+		},
+		{
+			name:          "gap at the beginning",
+			offset:        1,
+			localReplicas: 1,
+			wantErr:       "replicas 0-0 are not owned",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			federation := &chi.ClusterFederation{
+				ReplicaOffset: types.NewInt32(tt.offset),
+				Parts:         tt.parts,
+			}
+			err := validateClusterFederation(federation, tt.localReplicas)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClusterFederationReplicas(t *testing.T) {
+	federation := &chi.ClusterFederation{
+		ReplicaOffset: types.NewInt32(1),
+		Parts: []chi.ClusterFederationPart{
+			newFederationPart("a", 0, 1, "a"),
+			newFederationPart("b", 3, 2, "b"),
+		},
+	}
+
+	require.Equal(t, 5, federation.GetReplicasCount(2))
+
+	require.Equal(t, "a", federation.GetPart(0).Name)
+	require.Nil(t, federation.GetPart(1), "owned by this CHI")
+	require.Nil(t, federation.GetPart(2), "owned by this CHI")
+	require.Equal(t, "b", federation.GetPart(3).Name)
+	require.Equal(t, "b", federation.GetPart(4).Name)
+	require.Nil(t, federation.GetPart(5))
+
+	var none *chi.ClusterFederation
+	require.Equal(t, 2, none.GetReplicasCount(2))
+	require.Nil(t, none.GetPart(0))
+}
+
+func TestValidateClusterDiscovery(t *testing.T) {
+	zookeeper := &chi.ZookeeperConfig{
+		Nodes: chi.ZookeeperNodes{{Host: "zookeeper"}},
+	}
+	keeperRef := &chi.ZookeeperConfig{
+		KeeperRef: &chi.ZookeeperKeeperRef{Name: "keeper"},
+	}
+	wait := &chi.Reconciling{Policy: chi.ReconcilingPolicyWait}
+	noWait := &chi.Reconciling{Policy: chi.ReconcilingPolicyNoWait}
+
+	tests := []struct {
+		name        string
+		zookeeper   *chi.ZookeeperConfig
+		reconciling *chi.Reconciling
+		wantErr     string
+	}{
+		{name: "zookeeper nodes", zookeeper: zookeeper},
+		{name: "keeper reference", zookeeper: keeperRef},
+		{name: "nowait policy", zookeeper: zookeeper, reconciling: noWait},
+		{name: "no zookeeper", wantErr: "zookeeper is not configured"},
+		{name: "empty zookeeper", zookeeper: &chi.ZookeeperConfig{}, wantErr: "zookeeper is not configured"},
+		{name: "wait policy", zookeeper: zookeeper, reconciling: wait, wantErr: "not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &chi.Cluster{Name: "cluster", Zookeeper: tt.zookeeper}
+			err := validateClusterDiscovery(cluster, tt.reconciling)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestScaledClusterReplicas(t *testing.T) {
+	newStatus := func(autoscaler, spec, layout, replicas int32) *chi.Status {
+		return &chi.Status{
+			Scaling: &chi.ScalingStatus{
+				Replicas:           replicas,
+				AutoscalerReplicas: autoscaler,
+				SpecReplicas:       spec,
+				LayoutReplicas:     layout,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		specReplicas   int
+		layoutReplicas int
+		autoscaler     int
+		status         *chi.Status
+		want           int
+	}{
+		{name: "no scaling replicas", layoutReplicas: 2, status: &chi.Status{}, want: 2},
+		{name: "new scaling replicas", specReplicas: 3, layoutReplicas: 2, status: &chi.Status{}, want: 3},
+		{name: "scaling replicas changed", specReplicas: 4, layoutReplicas: 2, status: newStatus(0, 3, 2, 3), want: 4},
+		{name: "layout changed", specReplicas: 3, layoutReplicas: 5, status: newStatus(0, 3, 2, 3), want: 5},
+		{name: "nothing changed after layout change", specReplicas: 3, layoutReplicas: 5, status: newStatus(0, 3, 5, 5), want: 5},
+		{name: "nothing changed after scaling", specReplicas: 3, layoutReplicas: 2, status: newStatus(0, 3, 2, 3), want: 3},
+		{name: "autoscaler", specReplicas: 3, layoutReplicas: 2, autoscaler: 6, status: newStatus(6, 3, 2, 3), want: 6},
+		{name: "autoscaler with layout changed", specReplicas: 3, layoutReplicas: 5, autoscaler: 6, status: newStatus(6, 3, 2, 6), want: 6},
+		{name: "autoscaler disabled", specReplicas: 3, layoutReplicas: 2, status: newStatus(6, 3, 2, 6), want: 6},
+		{name: "autoscaler disabled with layout changed", specReplicas: 3, layoutReplicas: 5, status: newStatus(6, 3, 2, 6), want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getScaledClusterReplicas(tt.specReplicas, tt.layoutReplicas, tt.autoscaler, tt.status))
+		})
+	}
+}
+
+func TestInvalidClusterDoesNotBreakOtherClusters(t *testing.T) {
+	chop.New(nil, nil, "../../../../config/config.yaml")
+
+	const hostname = "chi-dr-{cluster}-{shard}-{replicaIndex}.dr.example.com"
+	newCluster := func(name string, parts ...chi.ClusterFederationPart) *chi.Cluster {
+		return &chi.Cluster{
+			Name:   name,
+			Layout: &chi.ChiClusterLayout{ShardsCount: 1, ReplicasCount: 2},
+			Federation: &chi.ClusterFederation{
+				ReplicaOffset: types.NewInt32(0),
+				Parts:         parts,
+			},
+		}
+	}
+	cr := &chi.ClickHouseInstallation{}
+	cr.Name = "federated"
+	cr.Namespace = "test"
+	cr.Spec.Configuration = &chi.Configuration{
+		Clusters: []*chi.Cluster{
+			newCluster("valid", newFederationPart("dr", 2, 1, hostname)),
+			newCluster("invalid", newFederationPart("dr", 1, 2, hostname)),
+		},
+	}
+
+	normalized, err := New(nil, nil).CreateTemplated(cr, normalizer.NewOptions())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cluster invalid")
+	require.NotContains(t, err.Error(), "cluster valid")
+	require.NotNil(t, normalized)
+
+	valid := normalized.FindCluster("valid").(*chi.Cluster)
+	require.NotNil(t, valid.GetFederation(), "valid federation is applied")
+	require.Positive(t, valid.HostsCount())
+
+	invalid := normalized.FindCluster("invalid").(*chi.Cluster)
+	require.Nil(t, invalid.GetFederation(), "invalid federation is not applied")
+	require.Equal(t, 2, invalid.HostsCount(), "hosts of the invalid cluster are reconciled without federation")
+}
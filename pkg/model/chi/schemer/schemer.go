@@ -54,7 +54,11 @@ func (s *ClusterSchemer) HostSyncTables(ctx context.Context, host *api.Host) err
 
 // HostDropReplica calls SYSTEM DROP REPLICA
 func (s *ClusterSchemer) HostDropReplica(ctx context.Context, hostToRunOn, hostToDrop *api.Host) error {
-	replica := s.Name(interfaces.NameInstanceHostname, hostToDrop)
+	return s.HostDropReplicaByName(ctx, hostToRunOn, s.Name(interfaces.NameInstanceHostname, hostToDrop))
+}
+
+// HostDropReplicaByName calls SYSTEM DROP REPLICA for the replica specified by name
+func (s *ClusterSchemer) HostDropReplicaByName(ctx context.Context, hostToRunOn *api.Host, replica string) error {
 	shard := hostToRunOn.Runtime.Address.ShardIndex
 	log.V(1).M(hostToRunOn).F().Info("Drop replica: %v at %v", replica, hostToRunOn.Runtime.Address.HostName)
 	return s.ExecHost(ctx, hostToRunOn, s.sqlDropReplica(shard, replica), clickhouse.NewQueryOptions().SetRetry(false))
@@ -127,10 +131,11 @@ func (s *ClusterSchemer) HostDropTables(ctx context.Context, host *api.Host) err
 	return s.ExecHost(ctx, host, dropTableSQLs, clickhouse.NewQueryOptions().SetRetry(false))
 }
 
-// IsHostInCluster checks whether host is a member of at least one ClickHouse cluster
+// IsHostInCluster checks whether host is a member of at least one ClickHouse cluster.
+// Host of the discovery cluster is checked to be registered in its own cluster
 func (s *ClusterSchemer) IsHostInCluster(ctx context.Context, host *api.Host) bool {
 	inside := false
-	SQLs := []string{s.sqlHostInCluster()}
+	SQLs := []string{s.sqlHostInCluster(host)}
 	opts := clickhouse.NewQueryOptions().SetSilent(true)
 	err := s.ExecHost(ctx, host, SQLs, opts)
 	if err == nil {
@@ -156,6 +161,26 @@ func (s *ClusterSchemer) HostActiveQueriesNum(ctx context.Context, host *api.Hos
 	return s.QueryHostInt(ctx, host, s.sqlActiveQueriesNum())
 }
 
+// HostCPUUtilization returns CPU utilization of the host, in percent of CPU cores available to the host
+func (s *ClusterSchemer) HostCPUUtilization(ctx context.Context, host *api.Host) (int, error) {
+	return s.QueryHostInt(ctx, host, s.sqlCPUUtilization())
+}
+
+// HostReplicasMaxDelay returns max absolute delay of replicated tables of the host, in seconds
+func (s *ClusterSchemer) HostReplicasMaxDelay(ctx context.Context, host *api.Host) (int, error) {
+	return s.QueryHostInt(ctx, host, s.sqlReplicasMaxDelay())
+}
+
+// HostReplicasMaxQueueSize returns max replication queue size of replicated tables of the host
+func (s *ClusterSchemer) HostReplicasMaxQueueSize(ctx context.Context, host *api.Host) (int, error) {
+	return s.QueryHostInt(ctx, host, s.sqlReplicasMaxQueueSize())
+}
+
+// HostZookeeperRootChildrenNum returns how many children root znode has, as seen by the host through system.zookeeper
+func (s *ClusterSchemer) HostZookeeperRootChildrenNum(ctx context.Context, host *api.Host) (int, error) {
+	return s.QueryHostInt(ctx, host, s.sqlZookeeperRootChildrenNum())
+}
+
 // HostClickHouseVersion returns ClickHouse version on the host
 func (s *ClusterSchemer) HostClickHouseVersion(ctx context.Context, host *api.Host) (string, error) {
 	return s.QueryHostString(ctx, host, s.sqlVersion())
@@ -246,11 +246,32 @@ func (s *ClusterSchemer) sqlActiveQueriesNum() string {
 	return `SELECT count() FROM system.processes`
 }
 
+func (s *ClusterSchemer) sqlCPUUtilization() string {
+	return `SELECT toUInt64(round(sum(value) * 100)) FROM system.asynchronous_metrics WHERE metric IN ('OSUserTimeNormalized', 'OSSystemTimeNormalized')`
+}
+
+func (s *ClusterSchemer) sqlReplicasMaxDelay() string {
+	return `SELECT max(absolute_delay) FROM system.replicas`
+}
+
+func (s *ClusterSchemer) sqlReplicasMaxQueueSize() string {
+	return `SELECT max(queue_size) FROM system.replicas`
+}
+
+func (s *ClusterSchemer) sqlZookeeperRootChildrenNum() string {
+	return `SELECT count() FROM system.zookeeper WHERE path = '/'`
+}
+
 func (s *ClusterSchemer) sqlVersion() string {
 	return `SELECT version()`
 }
 
-func (s *ClusterSchemer) sqlHostInCluster() string {
+func (s *ClusterSchemer) sqlHostInCluster(host *api.Host) string {
+	cluster := config.AllShardsOneReplicaClusterName
+	if host.GetCluster().GetDiscovery().IsEnabled() {
+		// Host of the discovery cluster is expected to be registered in its own cluster
+		cluster = host.GetCluster().GetName()
+	}
 	// TODO: Change to select count() query to avoid exception in operator and ClickHouse logs
 	return heredoc.Docf(`
 		SELECT
@@ -260,6 +281,6 @@ func (s *ClusterSchemer) sqlHostInCluster() string {
 		WHERE
 			cluster='%s' AND is_local
 		`,
-		config.AllShardsOneReplicaClusterName,
+		cluster,
 	)
 }
@@ -18,6 +18,7 @@ import (
 	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/interfaces"
 	"github.com/altinity/clickhouse-operator/pkg/model/common/tags/annotator"
+	"github.com/altinity/clickhouse-operator/pkg/util"
 )
 
 // Annotator is an entity which can annotate CHI artifacts
@@ -46,8 +47,32 @@ func (a *Annotator) Annotate(what interfaces.AnnotateType, params ...any) map[st
 			host = params[0].(*api.Host)
 			return a.GetHostScope(host)
 		}
+	case interfaces.AnnotatePodTemplate:
+		return a.getPodTemplateScope(params...)
 	default:
 		return a.Annotator.Annotate(what, params...)
 	}
 	panic("unknown annotate type")
 }
+
+// getPodTemplateScope gets annotations for pod template.
+// Pod template is annotated with fingerprints of the TLS certificate and zookeeper identities,
+// so certificate or identity rotation rolls pods.
+func (a *Annotator) getPodTemplateScope(params ...any) map[string]string {
+	annotations := a.Annotator.Annotate(interfaces.AnnotatePodTemplate, params...)
+	chi, ok := a.cr.(*api.ClickHouseInstallation)
+	if !ok || (chi == nil) {
+		return annotations
+	}
+	if fingerprint := chi.EnsureStatus().GetTLS().GetFingerprint(); fingerprint != "" {
+		annotations = util.MergeStringMapsOverwrite(annotations, map[string]string{
+			api.AnnotationTLSCertificateFingerprint: fingerprint,
+		})
+	}
+	if fingerprint := chi.EnsureStatus().GetZookeeperIdentity().GetFingerprint(); fingerprint != "" {
+		annotations = util.MergeStringMapsOverwrite(annotations, map[string]string{
+			api.AnnotationZookeeperIdentityFingerprint: fingerprint,
+		})
+	}
+	return annotations
+}
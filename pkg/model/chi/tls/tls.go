@@ -0,0 +1,218 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tls
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/creator"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/namer"
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// Fields of the Secret with TLS certificate.
+// Both cert-manager and the operator use the same layout, which is the layout of k8s TLS Secret.
+// CA certificate is optional, some cert-manager issuers, ACME for one, do not provide it.
+const (
+	SecretFieldCertificate   = core.TLSCertKey
+	SecretFieldPrivateKey    = core.TLSPrivateKeyKey
+	SecretFieldCACertificate = "ca.crt"
+)
+
+// Manager creates TLS-related objects of the CHI
+type Manager struct {
+	namer interfaces.INameManager
+	or    interfaces.IOwnerReferencesManager
+}
+
+// NewManager creates new TLS manager
+func NewManager() *Manager {
+	return &Manager{
+		namer: namer.New(),
+		or:    creator.NewOwnerReferencer(),
+	}
+}
+
+// GetSecretName gets name of the Secret with TLS certificate of the hosts
+func (m *Manager) GetSecretName(cr api.ICustomResource) string {
+	return m.namer.Name(interfaces.NameTLSSecret, cr)
+}
+
+// GetDNSNames gets DNS names the certificate has to be issued for.
+// These are names of all Services and hosts of the CR, thus certificate is valid however CHI is addressed.
+func (m *Manager) GetDNSNames(cr api.ICustomResource) []string {
+	names := []string{"localhost"}
+	names = append(names, m.serviceNames(cr, m.namer.Name(interfaces.NameCRService, cr))...)
+	cr.WalkClusters(func(cluster api.ICluster) error {
+		names = append(names, m.serviceNames(cr, m.namer.Name(interfaces.NameClusterService, cluster))...)
+		cluster.WalkShards(func(index int, shard api.IShard) error {
+			names = append(names, m.serviceNames(cr, m.namer.Name(interfaces.NameShardService, shard))...)
+			return nil
+		})
+		return nil
+	})
+	cr.WalkHosts(func(host *api.Host) error {
+		if host.IsExternal() {
+			// External host has its own certificate
+			return nil
+		}
+		names = append(names,
+			m.namer.Name(interfaces.NameInstanceHostname, host),
+			m.namer.Name(interfaces.NamePodHostname, host),
+			m.namer.Name(interfaces.NameFQDN, host),
+		)
+		return nil
+	})
+
+	names = util.NonEmpty(util.Unique(names))
+	sort.Strings(names)
+	return names
+}
+
+// serviceNames gets all names a Service can be addressed by
+func (m *Manager) serviceNames(cr api.ICustomResource, name string) []string {
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s", name, cr.GetNamespace()),
+		fmt.Sprintf("%s.%s.svc", name, cr.GetNamespace()),
+		m.namer.Name(interfaces.NameServiceFQDN, cr, name),
+	}
+}
+
+// CreateCertificate creates cert-manager Certificate for the hosts of the CR
+func (m *Manager) CreateCertificate(cr *api.ClickHouseInstallation) *unstructured.Unstructured {
+	tls := cr.GetSpecT().Configuration.GetTLS()
+
+	issuerRef := map[string]interface{}{}
+	if ref := tls.GetIssuerRef(); ref != nil {
+		issuerRef["name"] = ref.Name
+		if ref.Kind != "" {
+			issuerRef["kind"] = ref.Kind
+		}
+		if ref.Group != "" {
+			issuerRef["group"] = ref.Group
+		}
+	}
+
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName":  m.GetSecretName(cr),
+				"dnsNames":    toInterfaces(m.GetDNSNames(cr)),
+				"duration":    tls.GetDuration().String(),
+				"renewBefore": tls.GetRenewBefore().String(),
+				"issuerRef":   issuerRef,
+				"usages": []interface{}{
+					"digital signature",
+					"key encipherment",
+					"server auth",
+					"client auth",
+				},
+				"privateKey": map[string]interface{}{
+					"algorithm":      "ECDSA",
+					"size":           int64(256),
+					"encoding":       "PKCS8",
+					"rotationPolicy": "Always",
+				},
+			},
+		},
+	}
+	certificate.SetGroupVersionKind(k8s.CertificateGroupVersionKind)
+	certificate.SetNamespace(cr.GetNamespace())
+	certificate.SetName(m.GetSecretName(cr))
+	certificate.SetOwnerReferences(m.or.CreateOwnerReferences(cr))
+
+	return certificate
+}
+
+// CreateSecret issues certificate for the hosts of the CR from the CA provided and creates Secret with it
+func (m *Manager) CreateSecret(cr *api.ClickHouseInstallation, ca *core.Secret) (*core.Secret, error) {
+	tls := cr.GetSpecT().Configuration.GetTLS()
+
+	caCert := ca.Data[SecretFieldCertificate]
+	cert, key, err := util.IssueCertificate(
+		caCert,
+		ca.Data[SecretFieldPrivateKey],
+		m.namer.Name(interfaces.NameCRService, cr),
+		m.GetDNSNames(cr),
+		tls.GetDuration(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace:       cr.GetNamespace(),
+			Name:            m.GetSecretName(cr),
+			OwnerReferences: m.or.CreateOwnerReferences(cr),
+		},
+		Data: map[string][]byte{
+			SecretFieldCertificate:   cert,
+			SecretFieldPrivateKey:    key,
+			SecretFieldCACertificate: caCert,
+		},
+		Type: core.SecretTypeTLS,
+	}, nil
+}
+
+// GetCertificate gets certificate kept in the Secret
+func GetCertificate(secret *core.Secret) (*x509.Certificate, error) {
+	if secret == nil {
+		return nil, fmt.Errorf("no secret")
+	}
+	return util.ParseCertificatePEM(secret.Data[SecretFieldCertificate])
+}
+
+// IsRenewalRequired checks whether certificate has to be re-issued for the CR.
+// Certificate is re-issued when it is about to expire or when it does not cover all DNS names of the CR.
+func (m *Manager) IsRenewalRequired(cr *api.ClickHouseInstallation, cert *x509.Certificate, now time.Time) bool {
+	if cert == nil {
+		return true
+	}
+	renewBefore := cr.GetSpecT().Configuration.GetTLS().GetRenewBefore()
+	if now.Add(renewBefore).After(cert.NotAfter) {
+		return true
+	}
+	return !util.CertificateCoversDNSNames(cert, m.GetDNSNames(cr))
+}
+
+// CreateStatus creates TLS status describing the certificate in use
+func CreateStatus(secret *core.Secret, cert *x509.Certificate) *api.TLSStatus {
+	return &api.TLSStatus{
+		SecretName:  secret.GetName(),
+		NotAfter:    cert.NotAfter.Format(time.RFC3339),
+		Fingerprint: util.CertificateFingerprint(cert),
+		CA:          len(secret.Data[SecretFieldCACertificate]) > 0,
+	}
+}
+
+func toInterfaces(strs []string) []interface{} {
+	res := make([]interface{}, 0, len(strs))
+	for _, str := range strs {
+		res = append(res, str)
+	}
+	return res
+}
@@ -69,6 +69,15 @@ const (
 
 	// KeeperContainerName specifies name of the clickhouse container in the pod
 	KeeperContainerName = "clickhouse-keeper"
+
+	// DefaultBackupDockerImage specifies default docker image to be used for keeper backup and restore
+	DefaultBackupDockerImage = "minio/mc:RELEASE.2024-11-21T17-21-54Z"
+
+	// BackupContainerName specifies name of the backup container in the backup job pod
+	BackupContainerName = "clickhouse-keeper-backup"
+
+	// RestoreContainerName specifies name of the restore init container in the pod
+	RestoreContainerName = "clickhouse-keeper-restore"
 )
 
 const (
@@ -84,7 +84,7 @@ func (c *Generator) getRaftConfig(selector *config.HostSelector) string {
 		msg := fmt.Sprintf("SKIP host from RAFT servers: %s", host.GetName())
 		if selector.Include(host) {
 			util.Iline(raft, i, "<server>")
-			util.Iline(raft, i, "    <id>%d</id>", getServerId(host))
+			util.Iline(raft, i, "    <id>%d</id>", GetServerId(host))
 			util.Iline(raft, i, "    <hostname>%s</hostname>", c.namer.Name(interfaces.NameInstanceHostname, host))
 			util.Iline(raft, i, "    <port>%d</port>", host.RaftPort.Value())
 			util.Iline(raft, i, "</server>")
@@ -99,9 +99,10 @@ func (c *Generator) getRaftConfig(selector *config.HostSelector) string {
 
 // getHostServerId builds server id config for the host
 func (c *Generator) getHostServerId(host *chi.Host) string {
-	return chi.NewSettings().Set("keeper_server/server_id", chi.MustNewSettingScalarFromAny(getServerId(host))).ClickHouseConfig()
+	return chi.NewSettings().Set("keeper_server/server_id", chi.MustNewSettingScalarFromAny(GetServerId(host))).ClickHouseConfig()
 }
 
-func getServerId(host *chi.Host) int {
+// GetServerId returns RAFT server id of the host
+func GetServerId(host *chi.Host) int {
 	return host.GetRuntime().GetAddress().GetReplicaIndex()
 }
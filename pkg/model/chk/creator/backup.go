@@ -0,0 +1,200 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/chk/config"
+	"github.com/altinity/clickhouse-operator/pkg/model/chk/tags/labeler"
+)
+
+const (
+	// backupTimeFormat specifies format of the backup time within backup name, sortable and DNS-1123 compliant
+	backupTimeFormat = "20060102-150405"
+	// backupJobTTL specifies how long finished backup job is kept
+	backupJobTTL = int32(24 * 60 * 60)
+	// backupDataVolumeName specifies name of the volume with keeper data within backup job pod
+	backupDataVolumeName = "data"
+)
+
+// Scripts are executed by `sh`. Env vars are provided by backupEnvVars
+const (
+	backupScript = `set -e
+mc alias set backup "${S3_ENDPOINT}" "${AWS_ACCESS_KEY_ID}" "${AWS_SECRET_ACCESS_KEY}"
+tar -czf - -C "${DATA_DIR}" coordination | mc pipe "backup/${S3_BUCKET}/${S3_PATH}${BACKUP_NAME}.tar.gz"
+if [ "${RETENTION}" -gt 0 ]; then
+  mc ls "backup/${S3_BUCKET}/${S3_PATH}" | awk '{print $NF}' | grep -E "^${BACKUP_PREFIX}[0-9]{8}-[0-9]{6}\.tar\.gz$" | sort -r | tail -n +$((RETENTION+1)) | while read -r obsolete; do
+    mc rm "backup/${S3_BUCKET}/${S3_PATH}${obsolete}"
+  done
+fi
+`
+	restoreScript = `set -e
+if [ -n "$(ls -A "${DATA_DIR}/coordination/snapshots" 2>/dev/null)" ]; then
+  echo "snapshots are in place, skip restore"
+  exit 0
+fi
+mc alias set backup "${S3_ENDPOINT}" "${AWS_ACCESS_KEY_ID}" "${AWS_SECRET_ACCESS_KEY}"
+mc cat "backup/${S3_BUCKET}/${S3_PATH}${BACKUP_NAME}.tar.gz" | tar -xzf - -C "${DATA_DIR}" coordination/snapshots
+`
+)
+
+// BackupName creates name of the backup taken at specified time
+func BackupName(cr *apiChk.ClickHouseKeeperInstallation, t time.Time) string {
+	return backupPrefix(cr) + t.UTC().Format(backupTimeFormat)
+}
+
+// backupPrefix creates prefix of all backups of the CR
+func backupPrefix(cr *apiChk.ClickHouseKeeperInstallation) string {
+	return cr.GetName() + "-"
+}
+
+// backupJobName creates name of the job which takes specified backup.
+// Job name is used as a label value, thus has to fit into 63 chars
+func backupJobName(backupName string) string {
+	name := "backup-" + backupName
+	if len(name) > 63 {
+		name = name[len(name)-63:]
+	}
+	return strings.TrimLeft(name, "-")
+}
+
+// getBackupS3Path gets path prefix within the bucket, ensured to be either empty or to end with '/'
+func getBackupS3Path(backup *apiChk.ChkBackup) string {
+	path := strings.Trim(backup.GetS3().GetPath(), "/")
+	if path == "" {
+		return ""
+	}
+	return path + "/"
+}
+
+// getBackupImage gets image of backup container
+func getBackupImage(backup *apiChk.ChkBackup) string {
+	if image := backup.GetImage(); image != "" {
+		return image
+	}
+	return config.DefaultBackupDockerImage
+}
+
+// backupEnvVars creates env vars required by backup and restore scripts
+func backupEnvVars(cr *apiChk.ClickHouseKeeperInstallation, backupName string) []core.EnvVar {
+	backup := cr.GetSpecT().GetBackup()
+	envVars := []core.EnvVar{
+		{Name: "DATA_DIR", Value: config.DirPathDataStorage},
+		{Name: "S3_ENDPOINT", Value: backup.GetS3().GetEndpoint()},
+		{Name: "S3_BUCKET", Value: backup.GetS3().GetBucket()},
+		{Name: "S3_PATH", Value: getBackupS3Path(backup)},
+		{Name: "BACKUP_PREFIX", Value: backupPrefix(cr)},
+		{Name: "BACKUP_NAME", Value: backupName},
+		{Name: "RETENTION", Value: fmt.Sprintf("%d", backup.GetRetention())},
+	}
+	if s3 := backup.GetS3(); s3 != nil {
+		if s3.AccessKeyID != nil {
+			envVars = append(envVars, core.EnvVar{
+				Name:      "AWS_ACCESS_KEY_ID",
+				ValueFrom: &core.EnvVarSource{SecretKeyRef: s3.AccessKeyID},
+			})
+		}
+		if s3.SecretAccessKey != nil {
+			envVars = append(envVars, core.EnvVar{
+				Name:      "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: &core.EnvVarSource{SecretKeyRef: s3.SecretAccessKey},
+			})
+		}
+	}
+	return envVars
+}
+
+// CreateBackupJob creates job, which takes backup of keeper data from the specified PVC.
+// Job is pinned to the node where the PVC is mounted, so ReadWriteOnce volumes can be mounted as well.
+func CreateBackupJob(
+	cr *apiChk.ClickHouseKeeperInstallation,
+	backupName string,
+	nodeName string,
+	pvcName string,
+) *batch.Job {
+	backup := cr.GetSpecT().GetBackup()
+	backoffLimit := int32(0)
+	ttl := backupJobTTL
+	return &batch.Job{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      backupJobName(backupName),
+			Namespace: cr.GetNamespace(),
+			Labels:    labeler.New(cr).Selector(interfaces.SelectorCRScope),
+		},
+		Spec: batch.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			// Pod has no CR labels in order not to be selected along with keeper pods
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					RestartPolicy: core.RestartPolicyNever,
+					NodeName:      nodeName,
+					Containers: []core.Container{
+						{
+							Name:    config.BackupContainerName,
+							Image:   getBackupImage(backup),
+							Command: []string{"/bin/sh", "-c", backupScript},
+							Env:     backupEnvVars(cr, backupName),
+							VolumeMounts: []core.VolumeMount{
+								{
+									Name:      backupDataVolumeName,
+									MountPath: config.DirPathDataStorage,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []core.Volume{
+						{
+							Name: backupDataVolumeName,
+							VolumeSource: core.VolumeSource{
+								PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+									ClaimName: pvcName,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newRestoreInitContainer creates init container, which bootstraps host with snapshots of the backup
+func newRestoreInitContainer(cr *apiChk.ClickHouseKeeperInstallation, dataVolumeName string) core.Container {
+	backup := cr.GetSpecT().GetBackup()
+	return core.Container{
+		Name:    config.RestoreContainerName,
+		Image:   getBackupImage(backup),
+		Command: []string{"/bin/sh", "-c", restoreScript},
+		Env:     backupEnvVars(cr, backup.GetRestoreFrom()),
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      dataVolumeName,
+				MountPath: config.DirPathDataStorage,
+			},
+		},
+	}
+}
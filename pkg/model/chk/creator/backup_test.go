@@ -0,0 +1,177 @@
+package creator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/model/chk/config"
+)
+
+// newBackupTestCR creates CR with backup into S3 specified
+func newBackupTestCR(name string) *apiChk.ClickHouseKeeperInstallation {
+	return &apiChk.ClickHouseKeeperInstallation{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		Spec: apiChk.ChkSpec{
+			Backup: &apiChk.ChkBackup{
+				Schedule:    types.NewString("0 * * * *"),
+				Retention:   types.NewInt32(3),
+				RestoreFrom: types.NewString(name + "-20240102-030405"),
+				S3: &apiChk.ChkBackupS3{
+					Endpoint: types.NewString("http://minio.minio.svc:9000"),
+					Bucket:   types.NewString("backups"),
+					Path:     types.NewString("/keeper/"),
+					AccessKeyID: &core.SecretKeySelector{
+						LocalObjectReference: core.LocalObjectReference{Name: "s3"},
+						Key:                  "id",
+					},
+					SecretAccessKey: &core.SecretKeySelector{
+						LocalObjectReference: core.LocalObjectReference{Name: "s3"},
+						Key:                  "secret",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBackupName(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+2", 2*60*60))
+	require.Equal(t, "keeper-20240102-010405", BackupName(newBackupTestCR("keeper"), at))
+}
+
+func TestBackupJobName(t *testing.T) {
+	tests := []struct {
+		name       string
+		backupName string
+		want       string
+	}{
+		{
+			name:       "short",
+			backupName: "keeper-20240102-030405",
+			want:       "backup-keeper-20240102-030405",
+		},
+		{
+			name:       "long name keeps backup time",
+			backupName: "a-very-long-name-of-the-keeper-installation-x-20240102-030405",
+			want:       "p-a-very-long-name-of-the-keeper-installation-x-20240102-030405",
+		},
+		{
+			name:       "leading dash is trimmed",
+			backupName: "a-very-long-name-of-the-keeper-installation-xy-20240102-030405",
+			want:       "a-very-long-name-of-the-keeper-installation-xy-20240102-030405",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := backupJobName(tt.backupName)
+			require.Equal(t, tt.want, got)
+			require.LessOrEqual(t, len(got), 63)
+		})
+	}
+}
+
+func TestGetBackupS3Path(t *testing.T) {
+	tests := []struct {
+		name string
+		path *types.String
+		want string
+	}{
+		{name: "not specified", want: ""},
+		{name: "root", path: types.NewString("/"), want: ""},
+		{name: "plain", path: types.NewString("keeper"), want: "keeper/"},
+		{name: "slashes", path: types.NewString("/keeper/prod/"), want: "keeper/prod/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := &apiChk.ChkBackup{S3: &apiChk.ChkBackupS3{Path: tt.path}}
+			require.Equal(t, tt.want, getBackupS3Path(backup))
+		})
+	}
+	require.Equal(t, "", getBackupS3Path(nil))
+}
+
+func TestGetBackupImage(t *testing.T) {
+	require.Equal(t, config.DefaultBackupDockerImage, getBackupImage(nil))
+	require.Equal(t, config.DefaultBackupDockerImage, getBackupImage(&apiChk.ChkBackup{}))
+	require.Equal(t, "mc:custom", getBackupImage(&apiChk.ChkBackup{Image: types.NewString("mc:custom")}))
+}
+
+func TestCreateBackupJob(t *testing.T) {
+	chop.New(nil, nil, "../../../../config/config.yaml")
+	cr := newBackupTestCR("keeper")
+
+	job := CreateBackupJob(cr, "keeper-20240102-030405", "node-1", "data-keeper-0")
+	require.Equal(t, "backup-keeper-20240102-030405", job.GetName())
+	require.Equal(t, "ns", job.GetNamespace())
+	require.Equal(t, "keeper", job.GetLabels()["clickhouse-keeper.altinity.com/chk"])
+	require.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	require.Equal(t, backupJobTTL, *job.Spec.TTLSecondsAfterFinished)
+
+	// Pod is pinned to the node of the PVC and has no CR labels
+	pod := job.Spec.Template
+	require.Empty(t, pod.GetLabels())
+	require.Equal(t, core.RestartPolicyNever, pod.Spec.RestartPolicy)
+	require.Equal(t, "node-1", pod.Spec.NodeName)
+	require.Equal(t, []core.Volume{
+		{
+			Name: backupDataVolumeName,
+			VolumeSource: core.VolumeSource{
+				PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: "data-keeper-0", ReadOnly: true},
+			},
+		},
+	}, pod.Spec.Volumes)
+
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	require.Equal(t, config.BackupContainerName, container.Name)
+	require.Equal(t, config.DefaultBackupDockerImage, container.Image)
+	require.Equal(t, []string{"/bin/sh", "-c", backupScript}, container.Command)
+	require.Equal(t, []core.VolumeMount{
+		{Name: backupDataVolumeName, MountPath: config.DirPathDataStorage, ReadOnly: true},
+	}, container.VolumeMounts)
+	require.Equal(t, []core.EnvVar{
+		{Name: "DATA_DIR", Value: config.DirPathDataStorage},
+		{Name: "S3_ENDPOINT", Value: "http://minio.minio.svc:9000"},
+		{Name: "S3_BUCKET", Value: "backups"},
+		{Name: "S3_PATH", Value: "keeper/"},
+		{Name: "BACKUP_PREFIX", Value: "keeper-"},
+		{Name: "BACKUP_NAME", Value: "keeper-20240102-030405"},
+		{Name: "RETENTION", Value: "3"},
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: &core.EnvVarSource{SecretKeyRef: cr.Spec.Backup.S3.AccessKeyID}},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: &core.EnvVarSource{SecretKeyRef: cr.Spec.Backup.S3.SecretAccessKey}},
+	}, container.Env)
+}
+
+func TestNewRestoreInitContainer(t *testing.T) {
+	cr := newBackupTestCR("keeper")
+	cr.Spec.Backup.Image = types.NewString("mc:custom")
+	cr.Spec.Backup.S3.AccessKeyID = nil
+	cr.Spec.Backup.S3.SecretAccessKey = nil
+
+	container := newRestoreInitContainer(cr, "data")
+	require.Equal(t, config.RestoreContainerName, container.Name)
+	require.Equal(t, "mc:custom", container.Image)
+	require.Equal(t, []string{"/bin/sh", "-c", restoreScript}, container.Command)
+
+	// Data volume is writable, as snapshots are restored into it
+	require.Equal(t, []core.VolumeMount{{Name: "data", MountPath: config.DirPathDataStorage}}, container.VolumeMounts)
+	require.Equal(t, []core.EnvVar{
+		{Name: "DATA_DIR", Value: config.DirPathDataStorage},
+		{Name: "S3_ENDPOINT", Value: "http://minio.minio.svc:9000"},
+		{Name: "S3_BUCKET", Value: "backups"},
+		{Name: "S3_PATH", Value: "keeper/"},
+		{Name: "BACKUP_PREFIX", Value: "keeper-"},
+		{Name: "BACKUP_NAME", Value: "keeper-20240102-030405"},
+		{Name: "RETENTION", Value: "3"},
+	}, container.Env)
+}
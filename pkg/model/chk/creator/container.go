@@ -18,6 +18,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
 	chi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
 	"github.com/altinity/clickhouse-operator/pkg/interfaces"
 	"github.com/altinity/clickhouse-operator/pkg/model/chk/config"
@@ -44,11 +45,24 @@ func (cm *ContainerManager) GetAppContainer(statefulSet *apps.StatefulSet) (*cor
 
 func (cm *ContainerManager) EnsureAppContainer(statefulSet *apps.StatefulSet, host *chi.Host) {
 	cm.ensureContainerSpecifiedKeeper(statefulSet, host)
+	cm.ensureInitContainerSpecifiedRestore(statefulSet, host)
 }
 
 func (cm *ContainerManager) EnsureLogContainer(statefulSet *apps.StatefulSet) {
 }
 
+// SetupTroubleshootCommand replaces keeper process with sleep.
+// Keeper must not run while its snapshots and logs are being inspected and repaired,
+// so the pod only keeps volumes mounted and waits for CLI access.
+func (cm *ContainerManager) SetupTroubleshootCommand(container *core.Container) {
+	container.Command = []string{
+		"/bin/sh", "-c", "--",
+	}
+	container.Args = []string{
+		"while true; do sleep 30; done;",
+	}
+}
+
 func (cm *ContainerManager) getContainerKeeper(statefulSet *apps.StatefulSet) (*core.Container, bool) {
 	return k8s.StatefulSetContainerGet(statefulSet, config.KeeperContainerName)
 }
@@ -67,6 +81,37 @@ func (cm *ContainerManager) ensureContainerSpecifiedKeeper(statefulSet *apps.Sta
 	)
 }
 
+// ensureInitContainerSpecifiedRestore adds init container, which bootstraps host from the backup, in case restore is requested.
+// Init container is added to the hosts being created only, thus it is dropped from the pod spec by the next reconcile.
+func (cm *ContainerManager) ensureInitContainerSpecifiedRestore(statefulSet *apps.StatefulSet, host *chi.Host) {
+	cr, ok := host.GetCR().(*apiChk.ClickHouseKeeperInstallation)
+	if !ok || !cr.GetSpecT().GetBackup().HasRestoreFrom() {
+		return
+	}
+	if !host.GetReconcileAttributes().IsAdd() {
+		// Existing hosts have data in place already
+		return
+	}
+
+	dataVolumeName := host.Templates.GetDataVolumeClaimTemplate()
+	if dataVolumeName == "" {
+		// Nowhere to restore to
+		return
+	}
+
+	for i := range statefulSet.Spec.Template.Spec.InitContainers {
+		if statefulSet.Spec.Template.Spec.InitContainers[i].Name == config.RestoreContainerName {
+			// Already specified
+			return
+		}
+	}
+
+	statefulSet.Spec.Template.Spec.InitContainers = append(
+		statefulSet.Spec.Template.Spec.InitContainers,
+		newRestoreInitContainer(cr, dataVolumeName),
+	)
+}
+
 // newDefaultContainerKeeper returns default ClickHouse Container
 func (cm *ContainerManager) newDefaultContainerKeeper(host *chi.Host) core.Container {
 	container := core.Container{
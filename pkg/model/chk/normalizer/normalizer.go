@@ -25,8 +25,9 @@ import (
 	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
 	"github.com/altinity/clickhouse-operator/pkg/chop"
 	"github.com/altinity/clickhouse-operator/pkg/interfaces"
-	crTemplatesNormalizer "github.com/altinity/clickhouse-operator/pkg/model/chi/normalizer/templates_cr"
+	chiTemplatesNormalizer "github.com/altinity/clickhouse-operator/pkg/model/chi/normalizer/templates_cr"
 	"github.com/altinity/clickhouse-operator/pkg/model/chk/macro"
+	crTemplatesNormalizer "github.com/altinity/clickhouse-operator/pkg/model/chk/normalizer/templates_cr"
 	"github.com/altinity/clickhouse-operator/pkg/model/chk/tags/labeler"
 	commonCreator "github.com/altinity/clickhouse-operator/pkg/model/common/creator"
 	commonMacro "github.com/altinity/clickhouse-operator/pkg/model/common/macro"
@@ -39,18 +40,21 @@ import (
 
 // Normalizer specifies structures normalizer
 type Normalizer struct {
-	req     *Request
-	namer   interfaces.INameManager
-	macro   interfaces.IMacro
-	labeler interfaces.ILabeler
+	templatesGet crTemplatesNormalizer.TemplatesGetter
+	req          *Request
+	namer        interfaces.INameManager
+	macro        interfaces.IMacro
+	labeler      interfaces.ILabeler
 }
 
-// New creates new normalizer
-func New() *Normalizer {
+// New creates new normalizer.
+// Templates getter may be nil, templates referenced by CR are not applied then.
+func New(templatesGet crTemplatesNormalizer.TemplatesGetter) *Normalizer {
 	return &Normalizer{
-		namer:   managers.NewNameManager(managers.NameManagerTypeKeeper),
-		macro:   commonMacro.New(macro.List),
-		labeler: labeler.New(nil),
+		templatesGet: templatesGet,
+		namer:        managers.NewNameManager(managers.NameManagerTypeKeeper),
+		macro:        commonMacro.New(macro.List),
+		labeler:      labeler.New(nil),
 	}
 }
 
@@ -87,9 +91,9 @@ func (n *Normalizer) buildTargetFromTemplates(subj *chk.ClickHouseKeeperInstalla
 }
 
 func (n *Normalizer) applyCRTemplatesOnTarget(subj crTemplatesNormalizer.TemplateSubject) {
-	//for _, template := range crTemplatesNormalizer.ApplyTemplates(n.req.GetTarget(), subj) {
-	//	n.req.GetTarget().EnsureStatus().PushUsedTemplate(template)
-	//}
+	for _, template := range crTemplatesNormalizer.ApplyTemplates(n.req.GetTarget(), subj, n.templatesGet) {
+		n.req.GetTarget().EnsureStatus().PushUsedTemplate(template)
+	}
 }
 
 func (n *Normalizer) newSubject() *chk.ClickHouseKeeperInstallation {
@@ -148,7 +152,12 @@ func (n *Normalizer) normalizeTarget() (*chk.ClickHouseKeeperInstallation, error
 func (n *Normalizer) normalizeSpec() {
 	// Walk over Spec datatype fields
 	n.req.GetTarget().GetSpecT().TaskID = n.normalizeTaskID(n.req.GetTarget().GetSpecT().TaskID)
+	n.req.GetTarget().GetSpecT().UseTemplates = n.normalizeUseTemplates(n.req.GetTarget().GetSpecT().UseTemplates)
+	n.req.GetTarget().GetSpecT().Stop = n.normalizeStop(n.req.GetTarget().GetSpecT().Stop)
+	n.req.GetTarget().GetSpecT().Restart = n.normalizeRestart(n.req.GetTarget().GetSpecT().Restart)
+	n.req.GetTarget().GetSpecT().Troubleshoot = n.normalizeTroubleshoot(n.req.GetTarget().GetSpecT().Troubleshoot)
 	n.req.GetTarget().GetSpecT().NamespaceDomainPattern = n.normalizeNamespaceDomainPattern(n.req.GetTarget().GetSpecT().NamespaceDomainPattern)
+	n.req.GetTarget().GetSpecT().Templating = n.normalizeTemplating(n.req.GetTarget().GetSpecT().Templating)
 	n.req.GetTarget().GetSpecT().Reconciling = n.normalizeReconciling(n.req.GetTarget().GetSpecT().Reconciling)
 	n.req.GetTarget().GetSpecT().Defaults = n.normalizeDefaults(n.req.GetTarget().GetSpecT().Defaults)
 	n.req.GetTarget().GetSpecT().Configuration = n.normalizeConfiguration(n.req.GetTarget().GetSpecT().Configuration)
@@ -198,6 +207,64 @@ func (n *Normalizer) normalizeTaskID(taskID *types.String) *types.String {
 	return types.NewString(uuid.New().String())
 }
 
+// normalizeUseTemplates is a wrapper to hold the name of normalized section
+func (n *Normalizer) normalizeUseTemplates(templates []*chi.TemplateRef) []*chi.TemplateRef {
+	return chiTemplatesNormalizer.NormalizeTemplatesList(templates)
+}
+
+// normalizeTemplating normalizes .spec.templating
+func (n *Normalizer) normalizeTemplating(templating *chi.ChiTemplating) *chi.ChiTemplating {
+	if templating == nil {
+		templating = chi.NewChiTemplating()
+	}
+	switch strings.ToLower(templating.GetPolicy()) {
+	case strings.ToLower(chi.TemplatingPolicyAuto):
+		// Known value, overwrite it to ensure case-ness
+		templating.SetPolicy(chi.TemplatingPolicyAuto)
+	case strings.ToLower(chi.TemplatingPolicyManual):
+		// Known value, overwrite it to ensure case-ness
+		templating.SetPolicy(chi.TemplatingPolicyManual)
+	default:
+		// Unknown value, fallback to default
+		templating.SetPolicy(chi.TemplatingPolicyManual)
+	}
+	return templating
+}
+
+// normalizeStop normalizes .spec.stop
+func (n *Normalizer) normalizeStop(stop *types.StringBool) *types.StringBool {
+	if stop.IsValid() {
+		// It is bool, use as it is
+		return stop
+	}
+
+	// In case it is unknown value - just use set it to false
+	return types.NewStringBool(false)
+}
+
+// normalizeRestart normalizes .spec.restart
+func (n *Normalizer) normalizeRestart(restart *types.String) *types.String {
+	switch strings.ToLower(restart.Value()) {
+	case strings.ToLower(chi.RestartRollingUpdate):
+		// Known value, overwrite it to ensure case-ness
+		return types.NewString(chi.RestartRollingUpdate)
+	}
+
+	// In case it is unknown value - just use empty
+	return nil
+}
+
+// normalizeTroubleshoot normalizes .spec.troubleshoot
+func (n *Normalizer) normalizeTroubleshoot(troubleshoot *types.StringBool) *types.StringBool {
+	if troubleshoot.IsValid() {
+		// It is bool, use as it is
+		return troubleshoot
+	}
+
+	// In case it is unknown value - just use set it to false
+	return types.NewStringBool(false)
+}
+
 func isNamespaceDomainPatternValid(namespaceDomainPattern *types.String) bool {
 	if strings.Count(namespaceDomainPattern.Value(), "%s") > 1 {
 		return false
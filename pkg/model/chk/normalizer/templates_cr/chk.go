@@ -0,0 +1,214 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates_cr
+
+import (
+	"sort"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	apiChi "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	chiTemplatesCR "github.com/altinity/clickhouse-operator/pkg/model/chi/normalizer/templates_cr"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// TemplatesGetter gets all ClickHouseKeeperInstallationTemplates available
+type TemplatesGetter func() []*apiChk.ClickHouseKeeperInstallation
+
+type TemplateSubject interface {
+	GetNamespace() string
+	GetLabels() map[string]string
+	GetUsedTemplates() []*apiChi.TemplateRef
+}
+
+// getListOfAutoTemplates gets all auto templates.
+// Auto templates are sorted alphabetically by tuple: namespace, name
+func getListOfAutoTemplates(templates []*apiChk.ClickHouseKeeperInstallation) (autoTemplates []*apiChk.ClickHouseKeeperInstallation) {
+	for _, template := range templates {
+		if template.IsAuto() && !template.FoundIn(autoTemplates) {
+			autoTemplates = append(autoTemplates, template)
+		}
+	}
+	sort.SliceStable(autoTemplates, func(i, j int) bool {
+		if autoTemplates[i].Namespace != autoTemplates[j].Namespace {
+			return autoTemplates[i].Namespace < autoTemplates[j].Namespace
+		}
+		return autoTemplates[i].Name < autoTemplates[j].Name
+	})
+	return autoTemplates
+}
+
+// getTemplate finds specified template within possibly specified namespace
+func getTemplate(templates []*apiChk.ClickHouseKeeperInstallation, templateRef *apiChi.TemplateRef, fallbackNamespace string) *apiChk.ClickHouseKeeperInstallation {
+	// Try to find direct match
+	for _, template := range templates {
+		if template.MatchFullName(templateRef.Namespace, templateRef.Name) {
+			// Exact match, found the result
+			return template
+		}
+	}
+
+	if templateRef.Namespace != "" {
+		// With fully-specified template namespace+name pair exact match is applicable only
+		return nil
+	}
+
+	// Look for templates with specified name in "predefined" namespace
+	for _, template := range templates {
+		if template.MatchFullName(fallbackNamespace, templateRef.Name) {
+			return template
+		}
+	}
+
+	return nil
+}
+
+func prepareListOfTemplates(subj TemplateSubject, all []*apiChk.ClickHouseKeeperInstallation) (templates []*apiChi.TemplateRef) {
+	// 1. Get list of auto templates available
+	templates = append(templates, prepareListOfAutoTemplates(subj, all)...)
+	// 2. Append templates which are explicitly requested by the CHK
+	templates = append(templates, prepareListOfManualTemplates(subj)...)
+	// 3 Normalize list of templates
+	templates = chiTemplatesCR.NormalizeTemplatesList(templates)
+
+	log.V(1).M(subj).F().Info("Found applicable templates num: %d", len(templates))
+	return templates
+}
+
+func prepareListOfAutoTemplates(subj any, all []*apiChk.ClickHouseKeeperInstallation) (templates []*apiChi.TemplateRef) {
+	// 1. Get list of auto templates available
+	if autoTemplates := getListOfAutoTemplates(all); len(autoTemplates) > 0 {
+		log.V(1).M(subj).F().Info("Found auto-templates num: %d", len(autoTemplates))
+		for _, template := range autoTemplates {
+			log.V(1).M(subj).F().Info(
+				"Adding auto-template to the list of applicable templates: %s/%s ",
+				template.Namespace, template.Name)
+			templates = append(templates, &apiChi.TemplateRef{
+				Name:      template.Name,
+				Namespace: template.Namespace,
+				UseType:   chiTemplatesCR.UseTypeMerge,
+			})
+		}
+	}
+
+	return templates
+}
+
+func prepareListOfManualTemplates(subj TemplateSubject) (templates []*apiChi.TemplateRef) {
+	if len(subj.GetUsedTemplates()) > 0 {
+		log.V(1).M(subj).F().Info("Found manual-templates num: %d", len(subj.GetUsedTemplates()))
+		templates = append(templates, subj.GetUsedTemplates()...)
+	}
+
+	return templates
+}
+
+// ApplyTemplates applies auto and explicitly requested templates on the target
+func ApplyTemplates(target *apiChk.ClickHouseKeeperInstallation, subj TemplateSubject, templatesGet TemplatesGetter) (appliedTemplates []*apiChi.TemplateRef) {
+	if templatesGet == nil {
+		return nil
+	}
+
+	// All templates available
+	all := templatesGet()
+
+	// Prepare list of templates to be applied to the target
+	templates := prepareListOfTemplates(subj, all)
+
+	// Apply templates from the list and count applied templates - just to make nice log entry
+	for _, template := range templates {
+		if applyTemplate(target, template, subj, all) {
+			appliedTemplates = append(appliedTemplates, template)
+		}
+	}
+
+	log.V(1).M(subj).F().Info("Applied templates num: %d", len(appliedTemplates))
+	return appliedTemplates
+}
+
+func applyTemplate(target *apiChk.ClickHouseKeeperInstallation, templateRef *apiChi.TemplateRef, subj TemplateSubject, all []*apiChk.ClickHouseKeeperInstallation) bool {
+	// Find and apply (merge) template
+	if template := findApplicableTemplate(templateRef, subj, all); template != nil {
+		mergeFromTemplate(target, template)
+		return true
+	}
+
+	return false
+}
+
+func findApplicableTemplate(templateRef *apiChi.TemplateRef, subj TemplateSubject, all []*apiChk.ClickHouseKeeperInstallation) *apiChk.ClickHouseKeeperInstallation {
+	if templateRef == nil {
+		log.Warning("unable to apply template - nil templateRef provided")
+		// Template is not applied
+		return nil
+	}
+
+	// What template are we going to apply?
+	defaultNamespace := subj.GetNamespace()
+	template := getTemplate(all, templateRef, defaultNamespace)
+	if template == nil {
+		log.V(1).M(templateRef).F().Warning(
+			"skip template - UNABLE to find by templateRef: %s/%s",
+			templateRef.Namespace, templateRef.Name)
+		// Template is not applied
+		return nil
+	}
+
+	// What target(s) this template wants to be applied to?
+	// This is determined by matching selector of the template and target's labels
+	selector := template.GetSpecT().GetTemplating().GetSelector()
+	if !selector.Matches(subj.GetLabels()) {
+		// This template does not want to be applied to this CHK
+		log.V(1).M(templateRef).F().Info(
+			"Skip template: %s/%s. Selector: %v does not match labels: %v",
+			templateRef.Namespace, templateRef.Name, selector, subj.GetLabels())
+		// Template is not applied
+		return nil
+	}
+
+	log.V(1).M(templateRef).F().Info(
+		"Apply template: %s/%s. Selector: %v matches labels: %v",
+		templateRef.Namespace, templateRef.Name, selector, subj.GetLabels())
+
+	return template
+}
+
+func mergeFromTemplate(target, template *apiChk.ClickHouseKeeperInstallation) *apiChk.ClickHouseKeeperInstallation {
+	// Merge template's Labels over target's Labels
+	target.Labels = util.MergeStringMapsOverwrite(
+		target.Labels,
+		util.CopyMapFilter(
+			template.Labels,
+			chop.Config().Label.Include,
+			chop.Config().Label.Exclude,
+		),
+	)
+
+	// Merge template's Annotations over target's Annotations
+	target.Annotations = util.MergeStringMapsOverwrite(
+		target.Annotations,
+		util.CopyMapFilter(
+			template.Annotations,
+			chop.Config().Annotation.Include,
+			append(chop.Config().Annotation.Exclude, util.ListSkippedAnnotations()...),
+		),
+	)
+
+	// Merge template's Spec over target's Spec
+	target.GetSpecT().MergeFrom(template.GetSpecT(), apiChi.MergeTypeOverrideByNonEmptyValues)
+
+	return target
+}
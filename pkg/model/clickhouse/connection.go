@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 
 	// go-clickhouse is explicitly required in order to setup connection to clickhouse db
@@ -119,8 +120,9 @@ func (c *Connection) ensureConnected(ctx context.Context) bool {
 	return c.db != nil
 }
 
-// QueryContext runs given sql query on behalf of specified context
-func (c *Connection) QueryContext(ctx context.Context, sql string) (*QueryResult, error) {
+// QueryContext runs given sql query on behalf of specified context.
+// Optional args are bound to `?` placeholders of the query.
+func (c *Connection) QueryContext(ctx context.Context, sql string, args ...any) (*QueryResult, error) {
 	if len(sql) == 0 {
 		return nil, nil
 	}
@@ -138,7 +140,7 @@ func (c *Connection) QueryContext(ctx context.Context, sql string) (*QueryResult
 	// Query should have timeout
 	queryCtx, cancel := context.WithTimeout(c.ensureCtx(ctx), c.params.GetQueryTimeout())
 
-	rows, err := c.db.QueryContext(queryCtx, sql)
+	rows, err := c.db.QueryContext(queryCtx, sql, args...)
 	if err != nil {
 		cancel()
 		s := fmt.Sprintf("FAILED Query(%s) %v for SQL: %s", c.params.GetDSNWithHiddenCredentials(), err, sql)
@@ -151,6 +153,11 @@ func (c *Connection) QueryContext(ctx context.Context, sql string) (*QueryResult
 	return NewQueryResult(queryCtx, cancel, rows), nil
 }
 
+// Array wraps slice in order to be bound to a query placeholder as ClickHouse Array
+func Array(v any) driver.Valuer {
+	return goch.Array(v)
+}
+
 // Query runs given sql query
 func (c *Connection) Query(sql string) (*QueryResult, error) {
 	return c.QueryContext(nil, sql)
@@ -234,18 +234,25 @@ func (ap *ActionPlan) String() string {
 	return str
 }
 
-// GetRemovedHostsNum - how many hosts would be removed
+// GetRemovedHostsNum - how many hosts would be removed.
+// External hosts are not managed by the operator, so they are not counted
 func (ap *ActionPlan) GetRemovedHostsNum() int {
 	var count int
+	countHost := func(host *api.Host) error {
+		if !host.IsExternal() {
+			count++
+		}
+		return nil
+	}
 	ap.WalkRemoved(
 		func(cluster api.ICluster) {
-			count += cluster.HostsCount()
+			cluster.WalkHosts(countHost)
 		},
 		func(shard api.IShard) {
-			count += shard.HostsCount()
+			shard.WalkHosts(countHost)
 		},
 		func(host *api.Host) {
-			count++
+			_ = countHost(host)
 		},
 	)
 	return count
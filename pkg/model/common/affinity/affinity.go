@@ -57,10 +57,13 @@ func (a *Affinity) Make(template *api.PodTemplate) *core.Affinity {
 
 // PreparePreparePodTemplate
 func (a *Affinity) PreparePodTemplate(podTemplate *api.PodTemplate, host *api.Host) {
-	switch {
-	case podTemplate == nil:
+	if podTemplate == nil {
 		return
-	case podTemplate.Spec.Affinity == nil:
+	}
+
+	a.processTopologySpreadConstraints(podTemplate.Spec.TopologySpreadConstraints, host)
+
+	if podTemplate.Spec.Affinity == nil {
 		return
 	}
 
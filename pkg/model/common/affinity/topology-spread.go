@@ -0,0 +1,99 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package affinity
+
+import (
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"gopkg.in/d4l3k/messagediff.v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
+	commonLabeler "github.com/altinity/clickhouse-operator/pkg/model/common/tags/labeler"
+)
+
+// MakeTopologySpreadConstraints creates topology spread constraints out of pod distributions
+func (a *Affinity) MakeTopologySpreadConstraints(template *api.PodTemplate) []core.TopologySpreadConstraint {
+	var constraints []core.TopologySpreadConstraint
+
+	// PodDistribution
+	for i := range template.PodDistribution {
+		podDistribution := &template.PodDistribution[i]
+		switch podDistribution.Type {
+		case deployment.PodDistributionTopologySpread:
+			constraints = append(
+				constraints,
+				a.newTopologySpreadConstraint(podDistribution, core.DoNotSchedule),
+			)
+		case deployment.PodDistributionPreferredTopologySpread:
+			constraints = append(
+				constraints,
+				a.newTopologySpreadConstraint(podDistribution, core.ScheduleAnyway),
+			)
+		}
+	}
+
+	return constraints
+}
+
+// newTopologySpreadConstraint
+func (a *Affinity) newTopologySpreadConstraint(
+	podDistribution *api.PodDistribution,
+	whenUnsatisfiable core.UnsatisfiableConstraintAction,
+) core.TopologySpreadConstraint {
+	return core.TopologySpreadConstraint{
+		MaxSkew:           int32(podDistribution.Number),
+		TopologyKey:       podDistribution.TopologyKey,
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector: &meta.LabelSelector{
+			MatchLabels: a.newMatchLabels(
+				podDistribution,
+				map[string]string{
+					a.labeler.Get(commonLabeler.LabelAppName): a.labeler.Get(commonLabeler.LabelAppValue),
+				},
+			),
+		},
+	}
+}
+
+// MergeTopologySpreadConstraints appends constraints from src into dst, skipping already existing ones
+func MergeTopologySpreadConstraints(dst []core.TopologySpreadConstraint, src []core.TopologySpreadConstraint) []core.TopologySpreadConstraint {
+	// Check whether constraint is already in dst
+	for i := range src {
+		s := &src[i]
+		equal := false
+		for j := range dst {
+			d := &dst[j]
+			if _, equal = messagediff.DeepDiff(*s, *d); equal {
+				break
+			}
+		}
+		if !equal {
+			dst = append(dst, *s)
+		}
+	}
+
+	return dst
+}
+
+// processTopologySpreadConstraints
+func (a *Affinity) processTopologySpreadConstraints(constraints []core.TopologySpreadConstraint, host *api.Host) {
+	for i := range constraints {
+		constraint := &constraints[i]
+		a.processLabelSelector(constraint.LabelSelector, host)
+		constraint.TopologyKey = a.macro.Scope(host).Line(constraint.TopologyKey)
+	}
+}
@@ -0,0 +1,123 @@
+package affinity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/macro"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/tags/labeler"
+	commonMacro "github.com/altinity/clickhouse-operator/pkg/model/common/macro"
+)
+
+func TestMakeTopologySpreadConstraints(t *testing.T) {
+	chop.New(nil, nil, "../../../../config/config.yaml")
+	shardSelector := &meta.LabelSelector{
+		MatchLabels: map[string]string{
+			"clickhouse.altinity.com/app":       "chop",
+			"clickhouse.altinity.com/namespace": "{namespace}",
+			"clickhouse.altinity.com/chi":       "{chi}",
+			"clickhouse.altinity.com/cluster":   "{cluster}",
+			"clickhouse.altinity.com/shard":     "{shard}",
+		},
+	}
+	clusterSelector := &meta.LabelSelector{
+		MatchLabels: map[string]string{
+			"clickhouse.altinity.com/app":       "chop",
+			"clickhouse.altinity.com/namespace": "{namespace}",
+			"clickhouse.altinity.com/chi":       "{chi}",
+			"clickhouse.altinity.com/cluster":   "{cluster}",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		distributions []api.PodDistribution
+		want          []core.TopologySpreadConstraint
+	}{
+		{
+			name: "no distributions",
+		},
+		{
+			name: "anti-affinity distribution only",
+			distributions: []api.PodDistribution{
+				{Type: deployment.PodDistributionShardAntiAffinity, Scope: deployment.PodDistributionScopeCluster},
+			},
+		},
+		{
+			name: "topology spread",
+			distributions: []api.PodDistribution{
+				{Type: deployment.PodDistributionTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelTopologyZone},
+			},
+			want: []core.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: core.LabelTopologyZone, WhenUnsatisfiable: core.DoNotSchedule, LabelSelector: shardSelector},
+			},
+		},
+		{
+			name: "preferred topology spread",
+			distributions: []api.PodDistribution{
+				{Type: deployment.PodDistributionPreferredTopologySpread, Scope: deployment.PodDistributionScopeCluster, Number: 2, TopologyKey: core.LabelHostname},
+			},
+			want: []core.TopologySpreadConstraint{
+				{MaxSkew: 2, TopologyKey: core.LabelHostname, WhenUnsatisfiable: core.ScheduleAnyway, LabelSelector: clusterSelector},
+			},
+		},
+		{
+			name: "topology spread and preferred topology spread",
+			distributions: []api.PodDistribution{
+				{Type: deployment.PodDistributionTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelTopologyZone},
+				{Type: deployment.PodDistributionPreferredTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelHostname},
+			},
+			want: []core.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: core.LabelTopologyZone, WhenUnsatisfiable: core.DoNotSchedule, LabelSelector: shardSelector},
+				{MaxSkew: 1, TopologyKey: core.LabelHostname, WhenUnsatisfiable: core.ScheduleAnyway, LabelSelector: shardSelector},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &api.PodTemplate{PodDistribution: tt.distributions}
+			got := New(commonMacro.New(macro.List), labeler.New(nil)).MakeTopologySpreadConstraints(template)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMergeTopologySpreadConstraints(t *testing.T) {
+	zone := core.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: core.LabelTopologyZone, WhenUnsatisfiable: core.DoNotSchedule}
+	node := core.TopologySpreadConstraint{MaxSkew: 1, TopologyKey: core.LabelHostname, WhenUnsatisfiable: core.ScheduleAnyway}
+
+	tests := []struct {
+		name string
+		dst  []core.TopologySpreadConstraint
+		src  []core.TopologySpreadConstraint
+		want []core.TopologySpreadConstraint
+	}{
+		{
+			name: "empty dst",
+			src:  []core.TopologySpreadConstraint{zone},
+			want: []core.TopologySpreadConstraint{zone},
+		},
+		{
+			name: "empty src",
+			dst:  []core.TopologySpreadConstraint{zone},
+			want: []core.TopologySpreadConstraint{zone},
+		},
+		{
+			name: "existing constraint is skipped",
+			dst:  []core.TopologySpreadConstraint{zone},
+			src:  []core.TopologySpreadConstraint{zone, node},
+			want: []core.TopologySpreadConstraint{zone, node},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, MergeTopologySpreadConstraints(tt.dst, tt.src))
+		})
+	}
+}
@@ -29,6 +29,7 @@ type Creator struct {
 	cm                   interfaces.IContainerManager
 	pm                   interfaces.IProbeManager
 	sm                   interfaces.IServiceManager
+	im                   interfaces.IIngressManager
 	vm                   interfaces.IVolumeManager
 	cmm                  interfaces.IConfigMapManager
 	nm                   interfaces.INameManager
@@ -56,6 +57,7 @@ func NewCreator(
 	tagger interfaces.ITagger,
 	probeManager interfaces.IProbeManager,
 	serviceManager interfaces.IServiceManager,
+	ingressManager interfaces.IIngressManager,
 	volumeManager interfaces.IVolumeManager,
 	configMapManager interfaces.IConfigMapManager,
 	nameManager interfaces.INameManager,
@@ -72,6 +74,7 @@ func NewCreator(
 		cm:                   containerManager,
 		pm:                   probeManager,
 		sm:                   serviceManager,
+		im:                   ingressManager,
 		vm:                   volumeManager,
 		cmm:                  configMapManager,
 		nm:                   nameManager,
@@ -0,0 +1,135 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creator
+
+import (
+	"encoding/json"
+
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	"github.com/altinity/clickhouse-operator/pkg/model/k8s"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// CreateIngress creates Ingress of specified scope.
+// Creator without ingress manager, as Keeper is, exposes nothing outside of k8s cluster.
+func (c *Creator) CreateIngress(what interfaces.IngressType, params ...any) *networking.Ingress {
+	if c.im == nil {
+		return nil
+	}
+	c.im.SetCR(c.cr)
+	c.im.SetTagger(c.tagger)
+	return c.im.CreateIngress(what, params...)
+}
+
+// CreateRoute creates Gateway API route of specified scope
+func (c *Creator) CreateRoute(what interfaces.IngressType, params ...any) *unstructured.Unstructured {
+	if c.im == nil {
+		return nil
+	}
+	c.im.SetCR(c.cr)
+	c.im.SetTagger(c.tagger)
+	return c.im.CreateRoute(what, params...)
+}
+
+// CreateIngressFromTemplate create Ingress from IngressTemplate and additional info
+func CreateIngressFromTemplate(
+	template *api.IngressTemplate,
+	namespace string,
+	name string,
+	labels map[string]string,
+	annotations map[string]string,
+	ownerReferences []meta.OwnerReference,
+	macro interfaces.IMacro,
+	labeler interfaces.ILabeler,
+) *networking.Ingress {
+	// Create Ingress
+	ingress := &networking.Ingress{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+
+	// Spec may refer to operator-managed objects, such as Services, by macros
+	if err := applyMacrosToSpec(&ingress.Spec, macro); err != nil {
+		return nil
+	}
+
+	// Overwrite .name and .namespace - they are not allowed to be specified in template
+	ingress.Name = name
+	ingress.Namespace = namespace
+	ingress.OwnerReferences = ownerReferences
+
+	// Combine labels and annotations
+	ingress.Labels = macro.Map(util.MergeStringMapsOverwrite(ingress.Labels, labels))
+	ingress.Annotations = macro.Map(util.MergeStringMapsOverwrite(ingress.Annotations, annotations))
+
+	// And after the object is ready we can put version label
+	labeler.MakeObjectVersion(ingress.GetObjectMeta(), ingress)
+
+	return ingress
+}
+
+// CreateRouteFromTemplate create Gateway API route from RouteTemplate and additional info
+func CreateRouteFromTemplate(
+	template *api.RouteTemplate,
+	namespace string,
+	name string,
+	labels map[string]string,
+	annotations map[string]string,
+	ownerReferences []meta.OwnerReference,
+	macro interfaces.IMacro,
+	labeler interfaces.ILabeler,
+) *unstructured.Unstructured {
+	// Spec may refer to operator-managed objects, such as Services, by macros
+	spec := template.Spec.DeepCopy()
+	if err := applyMacrosToSpec(&spec, macro); err != nil {
+		return nil
+	}
+
+	// Create route
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}(spec),
+		},
+	}
+	route.SetGroupVersionKind(k8s.RouteGroupVersionKind(template.GetKind()))
+
+	// Overwrite .name and .namespace - they are not allowed to be specified in template
+	route.SetName(name)
+	route.SetNamespace(namespace)
+	route.SetOwnerReferences(ownerReferences)
+
+	// Combine labels and annotations
+	route.SetLabels(macro.Map(util.MergeStringMapsOverwrite(template.ObjectMeta.GetLabels(), labels)))
+	route.SetAnnotations(macro.Map(util.MergeStringMapsOverwrite(template.ObjectMeta.GetAnnotations(), annotations)))
+
+	// And after the object is ready we can put version label
+	labeler.MakeObjectVersion(route, route)
+
+	return route
+}
+
+// applyMacrosToSpec expands macros in all string values of the spec
+func applyMacrosToSpec(spec any, macro interfaces.IMacro) error {
+	bytes, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(macro.Line(string(bytes))), spec)
+}
@@ -0,0 +1,345 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creator
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiChk "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse-keeper.altinity.com/v1"
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/common/types"
+	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/interfaces"
+	chkLabeler "github.com/altinity/clickhouse-operator/pkg/model/chk/tags/labeler"
+)
+
+const (
+	// networkPolicyOperatorAppLabel specifies label the operator pod is labeled with by default
+	networkPolicyOperatorAppLabel = "app"
+	// networkPolicyOperatorAppValue specifies value of the label the operator pod is labeled with by default
+	networkPolicyOperatorAppValue = "clickhouse-operator"
+	// networkPolicyDNSPort specifies DNS port, which has to be reachable by hosts in order to resolve each other
+	networkPolicyDNSPort = 53
+)
+
+// CreateNetworkPolicy creates new NetworkPolicy for the CR.
+// Policy allows:
+//  1. all ports of the hosts from the hosts of the CR
+//  2. client ports of the hosts from the operator and from the specified clients
+//  3. in case egress is restricted - all ports of the hosts, ports of external hosts, ZooKeeper/Keeper nodes
+//     and DNS from the hosts of the CR, along with the specified egress rules
+func (c *Creator) CreateNetworkPolicy(policy *api.ChiNetworkPolicy) *networking.NetworkPolicy {
+	self := []networking.NetworkPolicyPeer{
+		{
+			PodSelector: &meta.LabelSelector{
+				MatchLabels: c.tagger.Selector(interfaces.SelectorCRScope),
+			},
+		},
+	}
+	allPorts := c.networkPolicyHostPorts(false, false)
+	clientPorts := c.networkPolicyHostPorts(false, true)
+
+	ingress := []networking.NetworkPolicyIngressRule{
+		{
+			// Inter-host traffic - replication, distributed queries, Keeper quorum
+			From:  self,
+			Ports: allPorts,
+		},
+		{
+			// Operator and metrics exporter
+			From:  []networking.NetworkPolicyPeer{c.networkPolicyOperatorPeer(policy)},
+			Ports: clientPorts,
+		},
+	}
+	if clients := policy.GetClients(); len(clients) > 0 {
+		ingress = append(ingress, networking.NetworkPolicyIngressRule{
+			From:  clients,
+			Ports: clientPorts,
+		})
+	}
+
+	policyTypes := []networking.PolicyType{
+		networking.PolicyTypeIngress,
+	}
+	var egress []networking.NetworkPolicyEgressRule
+	if policy.GetEgress().IsEnabled() {
+		policyTypes = append(policyTypes, networking.PolicyTypeEgress)
+		egress = c.networkPolicyEgress(policy.GetEgress(), self, allPorts)
+	}
+
+	return &networking.NetworkPolicy{
+		TypeMeta: meta.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: meta.ObjectMeta{
+			Name:            c.namer.Name(interfaces.NameNetworkPolicy, c.cr),
+			Namespace:       c.cr.GetNamespace(),
+			Labels:          c.macro.Scope(c.cr).Map(c.tagger.Label(interfaces.LabelNetworkPolicy)),
+			Annotations:     c.macro.Scope(c.cr).Map(c.tagger.Annotate(interfaces.AnnotateNetworkPolicy)),
+			OwnerReferences: c.or.CreateOwnerReferences(c.cr),
+		},
+		Spec: networking.NetworkPolicySpec{
+			PodSelector: meta.LabelSelector{
+				MatchLabels: c.tagger.Selector(interfaces.SelectorCRScope),
+			},
+			Ingress:     ingress,
+			Egress:      egress,
+			PolicyTypes: policyTypes,
+		},
+	}
+}
+
+// networkPolicyEgress creates egress rules of the hosts
+func (c *Creator) networkPolicyEgress(
+	policy *api.ChiNetworkPolicyEgress,
+	self []networking.NetworkPolicyPeer,
+	allPorts []networking.NetworkPolicyPort,
+) []networking.NetworkPolicyEgressRule {
+	egress := []networking.NetworkPolicyEgressRule{
+		{
+			// Inter-host traffic - replication, distributed queries, Keeper quorum
+			To:    self,
+			Ports: allPorts,
+		},
+		{
+			// Hosts have to resolve each other and ZooKeeper/Keeper nodes
+			Ports: []networking.NetworkPolicyPort{
+				newNetworkPolicyPort(core.ProtocolUDP, networkPolicyDNSPort),
+				newNetworkPolicyPort(core.ProtocolTCP, networkPolicyDNSPort),
+			},
+		},
+	}
+	if externalPorts := c.networkPolicyHostPorts(true, false); len(externalPorts) > 0 {
+		// External hosts are not selectable by pod selector, so they can be limited by ports only
+		egress = append(egress, networking.NetworkPolicyEgressRule{
+			Ports: externalPorts,
+		})
+	}
+	if zkPorts := c.networkPolicyZookeeperPorts(); len(zkPorts) > 0 {
+		peers := policy.GetZookeeper()
+		if len(peers) == 0 {
+			peers = c.networkPolicyZookeeperPeers()
+		}
+		egress = append(egress, networking.NetworkPolicyEgressRule{
+			To:    peers,
+			Ports: zkPorts,
+		})
+	}
+	return append(egress, policy.GetRules()...)
+}
+
+// networkPolicyOperatorPeer gets peer the operator runs at
+func (c *Creator) networkPolicyOperatorPeer(policy *api.ChiNetworkPolicy) networking.NetworkPolicyPeer {
+	if operator := policy.GetOperator(); operator != nil {
+		return *operator
+	}
+	peer := networking.NetworkPolicyPeer{
+		PodSelector: &meta.LabelSelector{
+			MatchLabels: map[string]string{
+				networkPolicyOperatorAppLabel: networkPolicyOperatorAppValue,
+			},
+		},
+	}
+	if namespace, ok := chop.Get().ConfigManager.GetRuntimeParam(deployment.OPERATOR_POD_NAMESPACE); ok && (namespace != "") {
+		peer.NamespaceSelector = &meta.LabelSelector{
+			MatchLabels: map[string]string{
+				core.LabelMetadataName: namespace,
+			},
+		}
+	}
+	return peer
+}
+
+// networkPolicyHostPorts collects ports of either managed or external hosts of the CR.
+// Client ports only may be requested
+func (c *Creator) networkPolicyHostPorts(external, clientOnly bool) []networking.NetworkPolicyPort {
+	ports := map[int32]bool{}
+	c.cr.WalkHosts(func(host *api.Host) error {
+		if host.IsExternal() != external {
+			return nil
+		}
+		host.WalkSpecifiedPorts(func(name string, port *types.Int32, protocol core.Protocol) bool {
+			if !clientOnly || isClientPort(name) {
+				ports[port.Value()] = true
+			}
+			// Do not abort, continue iterating
+			return false
+		})
+		return nil
+	})
+	return newNetworkPolicyPorts(ports)
+}
+
+// networkPolicyZookeeperPorts collects ports of all ZooKeeper/Keeper nodes referenced by the CR
+func (c *Creator) networkPolicyZookeeperPorts() []networking.NetworkPolicyPort {
+	ports := map[int32]bool{}
+	c.cr.WalkClusters(func(cluster api.ICluster) error {
+		if cluster.GetZookeeper() == nil {
+			return nil
+		}
+		for _, node := range cluster.GetZookeeper().Nodes {
+			if node.Port.HasValue() {
+				ports[node.Port.Value()] = true
+			}
+		}
+		return nil
+	})
+	return newNetworkPolicyPorts(ports)
+}
+
+// networkPolicyZookeeperPeers derives peers ZooKeeper/Keeper nodes referenced by the CR run at.
+// ClickHouseKeeperInstallation referenced is selected by its pods, nodes specified by IP are selected by IP
+// and nodes specified by in-cluster DNS name are selected by the namespace they run in.
+// In case any node can not be located this way, nil is returned, which means any destination.
+func (c *Creator) networkPolicyZookeeperPeers() []networking.NetworkPolicyPeer {
+	peers := map[string]networking.NetworkPolicyPeer{}
+	located := true
+	c.cr.WalkClusters(func(cluster api.ICluster) error {
+		zk := cluster.GetZookeeper()
+		switch {
+		case zk == nil:
+			return nil
+		case zk.HasKeeperRef():
+			namespace := zk.GetKeeperRef().GetNamespace()
+			if namespace == "" {
+				namespace = c.cr.GetNamespace()
+			}
+			peers["chk/"+namespace+"/"+zk.GetKeeperRef().GetName()] = newNetworkPolicyKeeperPeer(namespace, zk.GetKeeperRef().GetName())
+			return nil
+		}
+		for _, node := range zk.Nodes {
+			if ip := net.ParseIP(node.Host); ip != nil {
+				cidr := ip.String() + "/32"
+				if ip.To4() == nil {
+					cidr = ip.String() + "/128"
+				}
+				peers["ip/"+cidr] = networking.NetworkPolicyPeer{
+					IPBlock: &networking.IPBlock{
+						CIDR: cidr,
+					},
+				}
+			} else if namespace, ok := c.networkPolicyServiceNamespace(node.Host); ok {
+				peers["namespace/"+namespace] = newNetworkPolicyNamespacePeer(namespace)
+			} else {
+				located = false
+			}
+		}
+		return nil
+	})
+	if !located {
+		return nil
+	}
+
+	var keys []string
+	for key := range peers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var res []networking.NetworkPolicyPeer
+	for _, key := range keys {
+		res = append(res, peers[key])
+	}
+	return res
+}
+
+// newNetworkPolicyKeeperPeer creates peer selecting pods of the ClickHouseKeeperInstallation
+func newNetworkPolicyKeeperPeer(namespace, name string) networking.NetworkPolicyPeer {
+	chk := &apiChk.ClickHouseKeeperInstallation{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	peer := newNetworkPolicyNamespacePeer(namespace)
+	peer.PodSelector = &meta.LabelSelector{
+		MatchLabels: chkLabeler.New(chk).Selector(interfaces.SelectorCRScope),
+	}
+	return peer
+}
+
+// networkPolicyServiceNamespace gets namespace of the in-cluster service DNS name, such as
+// `zookeeper`, `zookeeper.zk`, `zookeeper.zk.svc` or `zookeeper-0.zookeepers.zk.svc.cluster.local`
+func (c *Creator) networkPolicyServiceNamespace(host string) (string, bool) {
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	switch {
+	case len(labels) == 1:
+		return c.cr.GetNamespace(), true
+	case len(labels) == 2:
+		return labels[1], true
+	}
+	for i := 1; i < len(labels)-1; i++ {
+		if labels[i+1] == "svc" {
+			return labels[i], true
+		}
+	}
+	return "", false
+}
+
+// newNetworkPolicyNamespacePeer creates peer selecting all pods of the namespace
+func newNetworkPolicyNamespacePeer(namespace string) networking.NetworkPolicyPeer {
+	return networking.NetworkPolicyPeer{
+		NamespaceSelector: &meta.LabelSelector{
+			MatchLabels: map[string]string{
+				core.LabelMetadataName: namespace,
+			},
+		},
+	}
+}
+
+// isClientPort checks whether named port is used by clients
+func isClientPort(name string) bool {
+	switch name {
+	case
+		api.ChDefaultTCPPortName,
+		api.ChDefaultTLSPortName,
+		api.ChDefaultHTTPPortName,
+		api.ChDefaultHTTPSPortName:
+		return true
+	}
+	return false
+}
+
+// newNetworkPolicyPorts creates sorted list of TCP ports
+func newNetworkPolicyPorts(ports map[int32]bool) []networking.NetworkPolicyPort {
+	var numbers []int
+	for port := range ports {
+		numbers = append(numbers, int(port))
+	}
+	sort.Ints(numbers)
+
+	var res []networking.NetworkPolicyPort
+	for _, port := range numbers {
+		res = append(res, newNetworkPolicyPort(core.ProtocolTCP, int32(port)))
+	}
+	return res
+}
+
+// newNetworkPolicyPort creates network policy port
+func newNetworkPolicyPort(protocol core.Protocol, port int32) networking.NetworkPolicyPort {
+	value := intstr.FromInt(int(port))
+	return networking.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &value,
+	}
+}
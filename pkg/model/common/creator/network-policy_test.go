@@ -0,0 +1,251 @@
+package creator_test
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/yaml"
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/macro"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/namer"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/tags/labeler"
+	"github.com/altinity/clickhouse-operator/pkg/model/common/creator"
+	commonMacro "github.com/altinity/clickhouse-operator/pkg/model/common/macro"
+	commonNormalizer "github.com/altinity/clickhouse-operator/pkg/model/common/normalizer"
+	"github.com/altinity/clickhouse-operator/pkg/model/managers"
+)
+
+// newNetworkPolicyTestCreator creates creator for CHI normalized from YAML spec
+func newNetworkPolicyTestCreator(t *testing.T, spec string) (*creator.Creator, *api.ClickHouseInstallation) {
+	chop.New(nil, nil, "../../../../config/config.yaml")
+
+	cr := &api.ClickHouseInstallation{}
+	require.NoError(t, yaml.Unmarshal([]byte("metadata: {name: test, namespace: ns}\nspec:\n"+spec), cr))
+	cr, err := normalizer.New(func(namespace, name string) (*core.Secret, error) {
+		return nil, nil
+	}, nil).CreateTemplated(cr, commonNormalizer.NewOptions())
+	require.NoError(t, err)
+
+	return creator.NewCreator(
+		cr,
+		nil,
+		nil,
+		managers.NewTagManager(managers.TagManagerTypeClickHouse, cr),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		managers.NewNameManager(managers.NameManagerTypeClickHouse),
+		managers.NewOwnerReferencesManager(managers.OwnerReferencesManagerTypeClickHouse),
+		namer.New(),
+		commonMacro.New(macro.List),
+		labeler.New(cr),
+	), cr
+}
+
+// networkPolicyTestPorts creates TCP ports
+func networkPolicyTestPorts(ports ...int) []networking.NetworkPolicyPort {
+	var res []networking.NetworkPolicyPort
+	for _, port := range ports {
+		protocol := core.ProtocolTCP
+		number := intstr.FromInt(port)
+		res = append(res, networking.NetworkPolicyPort{Protocol: &protocol, Port: &number})
+	}
+	return res
+}
+
+// networkPolicyTestNamespacePeer creates peer selecting all pods of the namespace
+func networkPolicyTestNamespacePeer(namespace string) networking.NetworkPolicyPeer {
+	return networking.NetworkPolicyPeer{
+		NamespaceSelector: &meta.LabelSelector{
+			MatchLabels: map[string]string{core.LabelMetadataName: namespace},
+		},
+	}
+}
+
+func TestCreateNetworkPolicy(t *testing.T) {
+	selector := map[string]string{
+		"clickhouse.altinity.com/app":       "chop",
+		"clickhouse.altinity.com/chi":       "test",
+		"clickhouse.altinity.com/namespace": "ns",
+	}
+	self := []networking.NetworkPolicyPeer{{PodSelector: &meta.LabelSelector{MatchLabels: selector}}}
+	operator := networking.NetworkPolicyPeer{
+		PodSelector: &meta.LabelSelector{MatchLabels: map[string]string{"app": "clickhouse-operator"}},
+	}
+	clients := networking.NetworkPolicyPeer{
+		PodSelector: &meta.LabelSelector{MatchLabels: map[string]string{"role": "client"}},
+	}
+	dns := networking.NetworkPolicyEgressRule{
+		Ports: []networking.NetworkPolicyPort{
+			{Protocol: &[]core.Protocol{core.ProtocolUDP}[0], Port: &[]intstr.IntOrString{intstr.FromInt(53)}[0]},
+			{Protocol: &[]core.Protocol{core.ProtocolTCP}[0], Port: &[]intstr.IntOrString{intstr.FromInt(53)}[0]},
+		},
+	}
+	allPorts := networkPolicyTestPorts(8123, 9000, 9009)
+	clientPorts := networkPolicyTestPorts(8123, 9000)
+
+	tests := []struct {
+		name        string
+		spec        string
+		wantIngress []networking.NetworkPolicyIngressRule
+		wantEgress  []networking.NetworkPolicyEgressRule
+	}{
+		{
+			name: "ingress only",
+			spec: `
+  networkPolicy:
+    enabled: "true"
+  configuration:
+    clusters:
+      - name: c
+        layout: {replicasCount: 2}
+`,
+			wantIngress: []networking.NetworkPolicyIngressRule{
+				{From: self, Ports: allPorts},
+				{From: []networking.NetworkPolicyPeer{operator}, Ports: clientPorts},
+			},
+		},
+		{
+			name: "clients and custom ports",
+			spec: `
+  networkPolicy:
+    enabled: "true"
+    clients:
+      - podSelector: {matchLabels: {role: client}}
+  defaults:
+    templates:
+      hostTemplate: ports
+  configuration:
+    clusters:
+      - name: c
+  templates:
+    hostTemplates:
+      - name: ports
+        spec:
+          tcpPort: 9100
+          httpPort: 8223
+          interserverHTTPPort: 9109
+`,
+			wantIngress: []networking.NetworkPolicyIngressRule{
+				{From: self, Ports: networkPolicyTestPorts(8223, 9100, 9109)},
+				{From: []networking.NetworkPolicyPeer{operator}, Ports: networkPolicyTestPorts(8223, 9100)},
+				{From: []networking.NetworkPolicyPeer{clients}, Ports: networkPolicyTestPorts(8223, 9100)},
+			},
+		},
+		{
+			name: "egress with zookeeper nodes located",
+			spec: `
+  networkPolicy:
+    enabled: "true"
+    operator:
+      podSelector: {matchLabels: {role: client}}
+    egress: {enabled: "true"}
+  configuration:
+    zookeeper:
+      nodes:
+        - host: zookeeper.zk
+        - host: 10.0.0.1
+          port: 2182
+    clusters:
+      - name: c
+`,
+			wantIngress: []networking.NetworkPolicyIngressRule{
+				{From: self, Ports: allPorts},
+				{From: []networking.NetworkPolicyPeer{clients}, Ports: clientPorts},
+			},
+			wantEgress: []networking.NetworkPolicyEgressRule{
+				{To: self, Ports: allPorts},
+				dns,
+				{
+					To: []networking.NetworkPolicyPeer{
+						{IPBlock: &networking.IPBlock{CIDR: "10.0.0.1/32"}},
+						networkPolicyTestNamespacePeer("zk"),
+					},
+					Ports: networkPolicyTestPorts(2181, 2182),
+				},
+			},
+		},
+		{
+			name: "egress with zookeeper node not located",
+			spec: `
+  networkPolicy:
+    enabled: "true"
+    egress: {enabled: "true"}
+  configuration:
+    zookeeper:
+      nodes:
+        - host: zookeeper.example.com
+    clusters:
+      - name: c
+`,
+			wantIngress: []networking.NetworkPolicyIngressRule{
+				{From: self, Ports: allPorts},
+				{From: []networking.NetworkPolicyPeer{operator}, Ports: clientPorts},
+			},
+			wantEgress: []networking.NetworkPolicyEgressRule{
+				{To: self, Ports: allPorts},
+				dns,
+				{Ports: networkPolicyTestPorts(2181)},
+			},
+		},
+		{
+			name: "egress with zookeeper peers and rules specified",
+			spec: `
+  networkPolicy:
+    enabled: "true"
+    egress:
+      enabled: "true"
+      zookeeper:
+        - namespaceSelector: {matchLabels: {kubernetes.io/metadata.name: keeper}}
+      rules:
+        - ports:
+            - port: 443
+  configuration:
+    zookeeper:
+      nodes:
+        - host: zookeeper
+    clusters:
+      - name: c
+`,
+			wantIngress: []networking.NetworkPolicyIngressRule{
+				{From: self, Ports: allPorts},
+				{From: []networking.NetworkPolicyPeer{operator}, Ports: clientPorts},
+			},
+			wantEgress: []networking.NetworkPolicyEgressRule{
+				{To: self, Ports: allPorts},
+				dns,
+				{To: []networking.NetworkPolicyPeer{networkPolicyTestNamespacePeer("keeper")}, Ports: networkPolicyTestPorts(2181)},
+				{Ports: []networking.NetworkPolicyPort{{Port: &[]intstr.IntOrString{intstr.FromInt(443)}[0]}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, cr := newNetworkPolicyTestCreator(t, tt.spec)
+			policy := c.CreateNetworkPolicy(cr.GetSpecT().GetNetworkPolicy())
+
+			require.Equal(t, "test-network-policy", policy.GetName())
+			require.Equal(t, "ns", policy.GetNamespace())
+			require.Equal(t, selector, policy.GetLabels())
+			require.Len(t, policy.GetOwnerReferences(), 1)
+			require.Equal(t, selector, policy.Spec.PodSelector.MatchLabels)
+
+			require.Equal(t, tt.wantIngress, policy.Spec.Ingress)
+			require.Equal(t, tt.wantEgress, policy.Spec.Egress)
+			if tt.wantEgress == nil {
+				require.Equal(t, []networking.PolicyType{networking.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+			} else {
+				require.Equal(t, []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+			}
+		})
+	}
+}
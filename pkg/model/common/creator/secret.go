@@ -34,3 +34,16 @@ func (c *Creator) CreateClusterSecret(name string) *core.Secret {
 		Type: core.SecretTypeOpaque,
 	}
 }
+
+// CreateZookeeperIdentitySecret creates secret with zookeeper identities ACLs are applied with, by cluster name
+func (c *Creator) CreateZookeeperIdentitySecret(name string, identities map[string]string) *core.Secret {
+	return &core.Secret{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace:       c.cr.GetNamespace(),
+			Name:            name,
+			OwnerReferences: c.or.CreateOwnerReferences(c.cr),
+		},
+		StringData: identities,
+		Type:       core.SecretTypeOpaque,
+	}
+}
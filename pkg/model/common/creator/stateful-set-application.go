@@ -126,26 +126,13 @@ func (c *Creator) stsAppContainerSetupTroubleshootingMode(statefulSet *apps.Stat
 
 	container, ok := c.stsGetAppContainer(statefulSet)
 	if !ok {
-		// Unable to locate ClickHouse container
+		// Unable to locate app container
 		return
 	}
 
-	// Let's setup troubleshooting in ClickHouse container
-
-	sleep := " || sleep 1800"
-	if len(container.Command) > 0 {
-		// In case we have user-specified command, let's
-		// append troubleshooting-capable tail and hope for the best
-		container.Command[len(container.Command)-1] += sleep
-	} else {
-		// Assume standard ClickHouse container is used
-		// Substitute entrypoint with troubleshooting-capable command
-		container.Command = []string{
-			"/bin/sh",
-			"-c",
-			"/entrypoint.sh" + sleep,
-		}
-	}
+	// Let's setup troubleshooting in app container
+	c.cm.SetupTroubleshootCommand(container)
+
 	// Appended `sleep` command makes Pod unable to respond to probes and probes would fail all the time,
 	// causing repeated restarts of the Pod by k8s. Restart is triggered by probes failures.
 	// Thus we need to disable all probes in troubleshooting mode.
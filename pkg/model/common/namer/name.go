@@ -66,3 +66,27 @@ func createClusterAutoSecretName(cluster api.ICluster) string {
 		cluster.GetName(),
 	)
 }
+
+// createTLSSecretName creates Secret name where TLS certificate of the hosts is kept
+func createTLSSecretName(cr api.ICustomResource) string {
+	return fmt.Sprintf(
+		"%s-auto-tls",
+		cr.GetName(),
+	)
+}
+
+// createZookeeperIdentitySecretName creates Secret name where zookeeper identities applied to ACLs are kept
+func createZookeeperIdentitySecretName(cr api.ICustomResource) string {
+	return fmt.Sprintf(
+		"%s-zookeeper-identity",
+		cr.GetName(),
+	)
+}
+
+// createNetworkPolicyName creates NetworkPolicy name for the CR
+func createNetworkPolicyName(cr api.ICustomResource) string {
+	return fmt.Sprintf(
+		"%s-network-policy",
+		cr.GetName(),
+	)
+}
@@ -61,6 +61,15 @@ func (n *Namer) Name(what interfaces.NameType, params ...any) string {
 	case interfaces.NameClusterAutoSecret:
 		cluster := params[0].(api.ICluster)
 		return createClusterAutoSecretName(cluster)
+	case interfaces.NameTLSSecret:
+		cr := params[0].(api.ICustomResource)
+		return createTLSSecretName(cr)
+	case interfaces.NameNetworkPolicy:
+		cr := params[0].(api.ICustomResource)
+		return createNetworkPolicyName(cr)
+	case interfaces.NameZookeeperIdentitySecret:
+		cr := params[0].(api.ICustomResource)
+		return createZookeeperIdentitySecretName(cr)
 	}
 
 	panic("unknown name type")
@@ -15,6 +15,8 @@
 package normalizer
 
 import (
+	"errors"
+
 	core "k8s.io/api/core/v1"
 
 	log "github.com/altinity/clickhouse-operator/pkg/announcer"
@@ -27,6 +29,8 @@ type Request struct {
 	target api.ICustomResource
 	// options specifies normalization options
 	options *Options
+	// errs specifies errors the target is found to have during normalization
+	errs []error
 }
 
 // NewRequest creates new Context
@@ -84,3 +88,20 @@ func (c *Request) AppendAdditionalVolumeMount(volumeMount core.VolumeMount) {
 	}
 	c.GetTarget().GetRuntime().GetAttributes().AppendAdditionalVolumeMountIfNotExists(volumeMount)
 }
+
+// AppendError appends error the target is found to have. Normalization is not aborted by the error,
+// however the whole normalization is reported as failed
+func (c *Request) AppendError(err error) {
+	if c == nil {
+		return
+	}
+	c.errs = append(c.errs, err)
+}
+
+// GetError gets all errors the target is found to have
+func (c *Request) GetError() error {
+	if c == nil {
+		return nil
+	}
+	return errors.Join(c.errs...)
+}
@@ -0,0 +1,45 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+)
+
+// NormalizeIngressTemplate normalizes .spec.templates.ingressTemplates
+func NormalizeIngressTemplate(template *api.IngressTemplate) {
+	// Check name
+	// Check GenerateName
+	// Check ObjectMeta
+	// Name and namespace are assigned by the operator, they are not allowed to be specified in template
+	template.ObjectMeta.Name = ""
+	template.ObjectMeta.Namespace = ""
+	// Check Spec
+	// PathType is mandatory for every path, otherwise Ingress is rejected by API server
+	for i := range template.Spec.Rules {
+		http := template.Spec.Rules[i].HTTP
+		if http == nil {
+			continue
+		}
+		for j := range http.Paths {
+			if http.Paths[j].PathType == nil {
+				pathType := networking.PathTypePrefix
+				http.Paths[j].PathType = &pathType
+			}
+		}
+	}
+}
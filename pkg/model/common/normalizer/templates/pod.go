@@ -40,6 +40,10 @@ func NormalizePodTemplate(macro interfaces.IMacro, labeler interfaces.ILabeler,
 		template.Spec.Affinity,
 		affinity.New(macro, labeler).Make(template),
 	)
+	template.Spec.TopologySpreadConstraints = affinity.MergeTopologySpreadConstraints(
+		template.Spec.TopologySpreadConstraints,
+		affinity.New(macro, labeler).MakeTopologySpreadConstraints(template),
+	)
 
 	// In case we have hostNetwork specified, we need to have ClusterFirstWithHostNet DNS policy, because of
 	// https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-dns-policy
@@ -115,6 +119,42 @@ func normalizePodDistribution(replicasCount int, podDistribution *api.PodDistrib
 		deployment.PodDistributionPreviousTailAffinity:
 		// PodDistribution is known
 		return nil
+	case
+		// TopologySpread section
+		deployment.PodDistributionTopologySpread,
+		deployment.PodDistributionPreferredTopologySpread:
+		// PodDistribution is known
+		if podDistribution.Scope == "" {
+			podDistribution.Scope = deployment.PodDistributionScopeShard
+		}
+		if podDistribution.Number < 1 {
+			// Number specifies max skew
+			podDistribution.Number = 1
+		}
+		return nil
+
+	case deployment.PodDistributionZoneBalanced:
+		// PodDistribution is known
+		// PodDistributionZoneBalanced is a shortcut to simplify complex set of other distributions
+		// All shortcuts have to be expanded
+
+		// Expand shortcut
+		return []api.PodDistribution{
+			// Replicas of each shard are spread across zones
+			{
+				Type:        deployment.PodDistributionTopologySpread,
+				Scope:       deployment.PodDistributionScopeShard,
+				Number:      1,
+				TopologyKey: core.LabelTopologyZone,
+			},
+			// Replicas of each shard are spread across nodes
+			{
+				Type:        deployment.PodDistributionPreferredTopologySpread,
+				Scope:       deployment.PodDistributionScopeShard,
+				Number:      1,
+				TopologyKey: core.LabelHostname,
+			},
+		}
 
 	case deployment.PodDistributionCircularReplication:
 		// PodDistribution is known
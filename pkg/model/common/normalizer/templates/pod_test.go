@@ -0,0 +1,76 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+	"github.com/altinity/clickhouse-operator/pkg/apis/deployment"
+	"github.com/altinity/clickhouse-operator/pkg/chop"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/macro"
+	"github.com/altinity/clickhouse-operator/pkg/model/chi/tags/labeler"
+	commonMacro "github.com/altinity/clickhouse-operator/pkg/model/common/macro"
+)
+
+func TestNormalizePodDistributionTopologySpread(t *testing.T) {
+	tests := []struct {
+		name       string
+		distribute api.PodDistribution
+		want       api.PodDistribution
+		expanded   []api.PodDistribution
+	}{
+		{
+			name:       "topology spread defaults",
+			distribute: api.PodDistribution{Type: deployment.PodDistributionTopologySpread},
+			want:       api.PodDistribution{Type: deployment.PodDistributionTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelHostname},
+		},
+		{
+			name:       "preferred topology spread explicit",
+			distribute: api.PodDistribution{Type: deployment.PodDistributionPreferredTopologySpread, Scope: deployment.PodDistributionScopeCluster, Number: 2, TopologyKey: core.LabelTopologyZone},
+			want:       api.PodDistribution{Type: deployment.PodDistributionPreferredTopologySpread, Scope: deployment.PodDistributionScopeCluster, Number: 2, TopologyKey: core.LabelTopologyZone},
+		},
+		{
+			name:       "zone balanced",
+			distribute: api.PodDistribution{Type: deployment.PodDistributionZoneBalanced},
+			want:       api.PodDistribution{Type: deployment.PodDistributionZoneBalanced, TopologyKey: core.LabelHostname},
+			expanded: []api.PodDistribution{
+				{Type: deployment.PodDistributionTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelTopologyZone},
+				{Type: deployment.PodDistributionPreferredTopologySpread, Scope: deployment.PodDistributionScopeShard, Number: 1, TopologyKey: core.LabelHostname},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := normalizePodDistribution(3, &tt.distribute)
+			require.Equal(t, tt.want, tt.distribute)
+			require.Equal(t, tt.expanded, expanded)
+		})
+	}
+}
+
+func TestNormalizePodTemplateZoneBalanced(t *testing.T) {
+	chop.New(nil, nil, "../../../../../config/config.yaml")
+	shardSelector := &meta.LabelSelector{
+		MatchLabels: map[string]string{
+			"clickhouse.altinity.com/app":       "chop",
+			"clickhouse.altinity.com/namespace": "{namespace}",
+			"clickhouse.altinity.com/chi":       "{chi}",
+			"clickhouse.altinity.com/cluster":   "{cluster}",
+			"clickhouse.altinity.com/shard":     "{shard}",
+		},
+	}
+
+	template := &api.PodTemplate{
+		PodDistribution: []api.PodDistribution{{Type: deployment.PodDistributionZoneBalanced}},
+	}
+	NormalizePodTemplate(commonMacro.New(macro.List), labeler.New(nil), 3, template)
+
+	// Both zone and node constraints are scoped to the shard, thus replicas of other shards do not skew each other
+	require.Equal(t, []core.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: core.LabelTopologyZone, WhenUnsatisfiable: core.DoNotSchedule, LabelSelector: shardSelector},
+		{MaxSkew: 1, TopologyKey: core.LabelHostname, WhenUnsatisfiable: core.ScheduleAnyway, LabelSelector: shardSelector},
+	}, template.Spec.TopologySpreadConstraints)
+}
@@ -0,0 +1,31 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+
+// NormalizeRouteTemplate normalizes .spec.templates.routeTemplates
+func NormalizeRouteTemplate(template *api.RouteTemplate) {
+	// Check Kind
+	switch template.Kind {
+	case api.RouteKindHTTPRoute, api.RouteKindTLSRoute:
+	default:
+		template.Kind = api.RouteKindHTTPRoute
+	}
+	// Check name
+	// Check GenerateName
+	// Check ObjectMeta
+	// Check Spec
+}
@@ -101,6 +101,9 @@ func (a *Annotator) Annotate(what interfaces.AnnotateType, params ...any) map[st
 			return util.MergeStringMapsOverwrite(annotations, a.GetHostScope(host))
 		}
 
+	case interfaces.AnnotateNetworkPolicy:
+		return a.GetCRScope()
+
 	case interfaces.AnnotatePDB:
 		var cluster api.ICluster
 		if len(params) > 0 {
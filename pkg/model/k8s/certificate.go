@@ -0,0 +1,29 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CertificateGroupVersionResource specifies GVR of cert-manager Certificate
+var CertificateGroupVersionResource = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// CertificateGroupVersionKind specifies GVK of cert-manager Certificate
+var CertificateGroupVersionKind = CertificateGroupVersionResource.GroupVersion().WithKind("Certificate")
@@ -0,0 +1,41 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com/v1"
+)
+
+// RouteGroup is an API group of Gateway API routes
+const RouteGroup = "gateway.networking.k8s.io"
+
+// RouteGroupVersionResource gets GVR of the Gateway API route of specified kind.
+// HTTPRoute is GA, while TLSRoute is available in experimental channel only.
+func RouteGroupVersionResource(kind string) schema.GroupVersionResource {
+	switch kind {
+	case api.RouteKindTLSRoute:
+		return schema.GroupVersionResource{Group: RouteGroup, Version: "v1alpha2", Resource: "tlsroutes"}
+	default:
+		return schema.GroupVersionResource{Group: RouteGroup, Version: "v1", Resource: "httproutes"}
+	}
+}
+
+// RouteGroupVersionKind gets GVK of the Gateway API route of specified kind
+func RouteGroupVersionKind(kind string) schema.GroupVersionKind {
+	gvr := RouteGroupVersionResource(kind)
+	return gvr.GroupVersion().WithKind(kind)
+}
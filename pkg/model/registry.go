@@ -43,6 +43,14 @@ const (
 	//PV EntityType = "PV"
 	// PDB describes PodDisruptionBudget entity type
 	PDB EntityType = "PDB"
+	// NetworkPolicy describes NetworkPolicy entity type
+	NetworkPolicy EntityType = "NetworkPolicy"
+	// Ingress describes Ingress entity type
+	Ingress EntityType = "Ingress"
+	// HTTPRoute describes Gateway API HTTPRoute entity type
+	HTTPRoute EntityType = "HTTPRoute"
+	// TLSRoute describes Gateway API TLSRoute entity type
+	TLSRoute EntityType = "TLSRoute"
 )
 
 // Registry specifies registry struct
@@ -318,6 +326,86 @@ func (r *Registry) WalkPDB(f func(meta meta.Object)) {
 	r.walkEntityType(PDB, f)
 }
 
+// RegisterNetworkPolicy register NetworkPolicy
+func (r *Registry) RegisterNetworkPolicy(meta meta.Object) {
+	r.registerEntity(NetworkPolicy, meta)
+}
+
+// HasNetworkPolicy checks whether registry has specified NetworkPolicy
+func (r *Registry) HasNetworkPolicy(meta meta.Object) bool {
+	return r.hasEntity(NetworkPolicy, meta)
+}
+
+// NumNetworkPolicy gets number of NetworkPolicy
+func (r *Registry) NumNetworkPolicy() int {
+	return r.Len(NetworkPolicy)
+}
+
+// WalkNetworkPolicy walk over specified entity types
+func (r *Registry) WalkNetworkPolicy(f func(meta meta.Object)) {
+	r.walkEntityType(NetworkPolicy, f)
+}
+
+// RegisterIngress register Ingress
+func (r *Registry) RegisterIngress(meta meta.Object) {
+	r.registerEntity(Ingress, meta)
+}
+
+// HasIngress checks whether registry has specified Ingress
+func (r *Registry) HasIngress(meta meta.Object) bool {
+	return r.hasEntity(Ingress, meta)
+}
+
+// NumIngress gets number of Ingress
+func (r *Registry) NumIngress() int {
+	return r.Len(Ingress)
+}
+
+// WalkIngress walk over specified entity types
+func (r *Registry) WalkIngress(f func(meta meta.Object)) {
+	r.walkEntityType(Ingress, f)
+}
+
+// RegisterHTTPRoute register HTTPRoute
+func (r *Registry) RegisterHTTPRoute(meta meta.Object) {
+	r.registerEntity(HTTPRoute, meta)
+}
+
+// HasHTTPRoute checks whether registry has specified HTTPRoute
+func (r *Registry) HasHTTPRoute(meta meta.Object) bool {
+	return r.hasEntity(HTTPRoute, meta)
+}
+
+// NumHTTPRoute gets number of HTTPRoute
+func (r *Registry) NumHTTPRoute() int {
+	return r.Len(HTTPRoute)
+}
+
+// WalkHTTPRoute walk over specified entity types
+func (r *Registry) WalkHTTPRoute(f func(meta meta.Object)) {
+	r.walkEntityType(HTTPRoute, f)
+}
+
+// RegisterTLSRoute register TLSRoute
+func (r *Registry) RegisterTLSRoute(meta meta.Object) {
+	r.registerEntity(TLSRoute, meta)
+}
+
+// HasTLSRoute checks whether registry has specified TLSRoute
+func (r *Registry) HasTLSRoute(meta meta.Object) bool {
+	return r.hasEntity(TLSRoute, meta)
+}
+
+// NumTLSRoute gets number of TLSRoute
+func (r *Registry) NumTLSRoute() int {
+	return r.Len(TLSRoute)
+}
+
+// WalkTLSRoute walk over specified entity types
+func (r *Registry) WalkTLSRoute(f func(meta meta.Object)) {
+	r.walkEntityType(TLSRoute, f)
+}
+
 // Subtract subtracts specified registry from main
 func (r *Registry) Subtract(sub *Registry) *Registry {
 	if sub.Len() == 0 {
@@ -336,6 +424,17 @@ func (r *Registry) Subtract(sub *Registry) *Registry {
 	return r
 }
 
+// Filter provides registry of the entities matching the filter
+func (r *Registry) Filter(f func(entityType EntityType, meta meta.Object) bool) *Registry {
+	res := NewRegistry()
+	r.Walk(func(entityType EntityType, entity meta.Object) {
+		if f(entityType, entity) {
+			res.registerEntity(entityType, entity)
+		}
+	})
+	return res
+}
+
 // hasEntity
 func (r *Registry) hasEntity(entityType EntityType, meta meta.Object) bool {
 	// Try to minimize coarse grained locking at the registry level. Immediately getOrCreate for the entity type
@@ -0,0 +1,350 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeper
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+)
+
+// DigestACL creates ACL entry granting all permissions to the identity in `user:password` format
+func DigestACL(identity string) zk.ACL {
+	user, password, _ := strings.Cut(identity, ":")
+	return zk.DigestACL(zk.PermAll, user, password)[0]
+}
+
+// ACLManager manages ACLs of znodes
+type ACLManager struct {
+	*Connection
+}
+
+// NewACLManager creates new ACL manager
+func NewACLManager(connection *Connection) *ACLManager {
+	return &ACLManager{
+		Connection: connection,
+	}
+}
+
+// Protect replaces world ACL of each znode of the tree with digest ACL of the identity.
+// Digest ACL entries of other identities are kept, thus identity being rotated does not lose access.
+// Root is protected the last, thus protected root means the whole tree is protected already.
+// Returns number of znodes updated.
+func (m *ACLManager) Protect(ctx context.Context, root string, identity string) (int, error) {
+	root = "/" + strings.Trim(strings.TrimSpace(root), "/")
+	digest := DigestACL(identity)
+	protect := func(acl []zk.ACL) []zk.ACL {
+		return ProtectACL(acl, digest)
+	}
+
+	acl, stat, err := m.GetACL(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+	protected := protect(acl)
+	if isEqualACLs(acl, protected) {
+		return 0, nil
+	}
+
+	children, _, err := m.Children(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for _, child := range children {
+		n, err := m.Walk(ctx, path.Join(root, child), protect)
+		updated += n
+		if err != nil {
+			return updated, err
+		}
+	}
+
+	if _, err := m.SetACL(ctx, root, protected, stat.Aversion); err != nil {
+		return updated, err
+	}
+	log.Info("zk tree protected by digest ACL: %s znodes updated: %d", root, updated+1)
+	return updated + 1, nil
+}
+
+// Grant adds digest ACL entry of the identity `to` to each znode of the tree, which has entry of the identity `from`.
+// Returns number of znodes updated.
+func (m *ACLManager) Grant(ctx context.Context, root string, from, to string) (int, error) {
+	fromDigest := DigestACL(from)
+	toDigest := DigestACL(to)
+	return m.Walk(ctx, root, func(acl []zk.ACL) []zk.ACL {
+		entry, found := findACL(acl, fromDigest)
+		if !found {
+			return acl
+		}
+		if _, found := findACL(acl, toDigest); found {
+			return acl
+		}
+		toDigest.Perms = entry.Perms
+		return append(append([]zk.ACL{}, acl...), toDigest)
+	})
+}
+
+// Revoke replaces digest ACL entry of the identity `from` with entry of the identity `to` in each znode of the tree.
+// Returns number of znodes updated.
+func (m *ACLManager) Revoke(ctx context.Context, root string, from, to string) (int, error) {
+	fromDigest := DigestACL(from)
+	toDigest := DigestACL(to)
+	return m.Walk(ctx, root, func(acl []zk.ACL) []zk.ACL {
+		entry, found := findACL(acl, fromDigest)
+		if !found {
+			return acl
+		}
+		var updated []zk.ACL
+		for _, e := range acl {
+			if isSameACL(e, fromDigest) || isSameACL(e, toDigest) {
+				continue
+			}
+			updated = append(updated, e)
+		}
+		toDigest.Perms = entry.Perms
+		return append(updated, toDigest)
+	})
+}
+
+// Freeze makes the tree read-only by masking out all permissions but read and admin from ACL of each znode.
+// Frozen ACLs have to be registered by CollectFrozenACLs beforehand, thus the tree can be thawed later.
+// Returns number of znodes updated.
+func (m *ACLManager) Freeze(ctx context.Context, root string, frozen map[string]string) (int, error) {
+	return m.WalkE(ctx, root, func(acl []zk.ACL) ([]zk.ACL, error) {
+		if _, found := frozen[ACLString(acl)]; found {
+			// Frozen already
+			return acl, nil
+		}
+		frozenACL := FreezeACL(acl)
+		if isEqualACLs(acl, frozenACL) {
+			// Read-only already
+			return acl, nil
+		}
+		if original, found := frozen[ACLString(frozenACL)]; !found || (original != ACLString(acl)) {
+			return nil, fmt.Errorf("unregistered ACL: %s", ACLString(acl))
+		}
+		return frozenACL, nil
+	})
+}
+
+// Thaw restores original ACL of each znode of the tree frozen by Freeze.
+// Returns number of znodes updated.
+func (m *ACLManager) Thaw(ctx context.Context, root string, frozen map[string]string) (int, error) {
+	return m.WalkE(ctx, root, func(acl []zk.ACL) ([]zk.ACL, error) {
+		return ThawACL(acl, frozen)
+	})
+}
+
+// CollectFrozenACLs walks over the tree and registers frozen form of each ACL met in the map of frozen to original ACLs.
+// Fails in case frozen form can not be told apart from another ACL of the tree, since thaw would be ambiguous then.
+func (m *ACLManager) CollectFrozenACLs(ctx context.Context, root string, frozen map[string]string) (map[string]string, error) {
+	collected := make(map[string]string, len(frozen))
+	for frozenACL, original := range frozen {
+		collected[frozenACL] = original
+	}
+	unchanged := make(map[string]bool)
+
+	_, err := m.WalkE(ctx, root, func(acl []zk.ACL) ([]zk.ACL, error) {
+		str := ACLString(acl)
+		if _, found := frozen[str]; found {
+			// Frozen already
+			return acl, nil
+		}
+		if original, found := collected[str]; found {
+			// Read-only ACL looks the same as the frozen one
+			return nil, fmt.Errorf("ambiguous ACLs: %s and %s", str, original)
+		}
+		frozenStr := ACLString(FreezeACL(acl))
+		switch {
+		case frozenStr == str:
+			// Read-only already, is not changed by freeze
+			unchanged[str] = true
+		case unchanged[frozenStr]:
+			return nil, fmt.Errorf("ambiguous ACLs: %s and %s", str, frozenStr)
+		default:
+			if original, found := collected[frozenStr]; found && (original != str) {
+				return nil, fmt.Errorf("ambiguous ACLs: %s and %s", str, original)
+			}
+			collected[frozenStr] = str
+		}
+		return acl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collected, nil
+}
+
+// Walk walks over the tree rooted at the specified path and sets ACL of each znode to the one provided by the function.
+// Returns number of znodes updated.
+func (m *ACLManager) Walk(ctx context.Context, root string, fn func(acl []zk.ACL) []zk.ACL) (int, error) {
+	return m.WalkE(ctx, root, func(acl []zk.ACL) ([]zk.ACL, error) {
+		return fn(acl), nil
+	})
+}
+
+// WalkE is the same as Walk, but stops the walk on the first error returned by the function.
+// Returns number of znodes updated.
+func (m *ACLManager) WalkE(ctx context.Context, root string, fn func(acl []zk.ACL) ([]zk.ACL, error)) (int, error) {
+	root = "/" + strings.Trim(strings.TrimSpace(root), "/")
+
+	updated := 0
+	queue := []string{root}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+		if isSystemPath(current) {
+			continue
+		}
+
+		acl, stat, err := m.GetACL(ctx, current)
+		if err == zk.ErrNoNode {
+			// Deleted in the meantime
+			continue
+		}
+		if err != nil {
+			return updated, err
+		}
+
+		newACL, err := fn(acl)
+		if err != nil {
+			return updated, fmt.Errorf("znode: %s err: %v", current, err)
+		}
+		if !isEqualACLs(acl, newACL) {
+			if _, err := m.SetACL(ctx, current, newACL, stat.Aversion); err != nil && err != zk.ErrNoNode {
+				return updated, err
+			}
+			updated++
+		}
+
+		children, _, err := m.Children(ctx, current)
+		if err != nil && err != zk.ErrNoNode {
+			return updated, err
+		}
+		for _, child := range children {
+			queue = append(queue, path.Join(current, child))
+		}
+	}
+
+	log.Info("zk tree ACL walked: %s znodes updated: %d", root, updated)
+	return updated, nil
+}
+
+// ProtectACL replaces world entry of the ACL with the digest entry. Entries of other identities are kept
+func ProtectACL(acl []zk.ACL, digest zk.ACL) []zk.ACL {
+	protected := []zk.ACL{digest}
+	for _, entry := range acl {
+		if (entry.Scheme == "world") || isSameACL(entry, digest) {
+			continue
+		}
+		protected = append(protected, entry)
+	}
+	return protected
+}
+
+// FreezeACL creates read-only copy of the ACL, which keeps read and admin permissions only
+func FreezeACL(acl []zk.ACL) []zk.ACL {
+	frozen := make([]zk.ACL, 0, len(acl))
+	for _, entry := range acl {
+		entry.Perms &= zk.PermRead | zk.PermAdmin
+		frozen = append(frozen, entry)
+	}
+	return frozen
+}
+
+// ThawACL restores original ACL of the frozen one. ACLs which are not frozen are returned as is
+func ThawACL(acl []zk.ACL, frozen map[string]string) ([]zk.ACL, error) {
+	original, found := frozen[ACLString(acl)]
+	if !found {
+		return acl, nil
+	}
+	return ParseACL(original)
+}
+
+// ACLString serializes ACL into string of comma-separated sorted `scheme:id:perms` entries
+func ACLString(acl []zk.ACL) string {
+	entries := make([]string, 0, len(acl))
+	for _, entry := range acl {
+		entries = append(entries, fmt.Sprintf("%s:%s:%d", entry.Scheme, entry.ID, entry.Perms))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// ParseACL parses ACL serialized by ACLString
+func ParseACL(str string) ([]zk.ACL, error) {
+	var acl []zk.ACL
+	for _, entry := range strings.Split(str, ",") {
+		if entry == "" {
+			continue
+		}
+		// ID may contain colons, as digest `user:hash` does
+		scheme, rest, found := strings.Cut(entry, ":")
+		sep := strings.LastIndex(rest, ":")
+		if !found || (sep < 0) {
+			return nil, fmt.Errorf("malformed ACL entry: %s", entry)
+		}
+		perms, err := strconv.ParseInt(rest[sep+1:], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed ACL entry: %s err: %v", entry, err)
+		}
+		acl = append(acl, zk.ACL{
+			Scheme: scheme,
+			ID:     rest[:sep],
+			Perms:  int32(perms),
+		})
+	}
+	return acl, nil
+}
+
+// isSameACL checks whether ACL entries refer to the same identity
+func isSameACL(a, b zk.ACL) bool {
+	return (a.Scheme == b.Scheme) && (a.ID == b.ID)
+}
+
+// findACL finds ACL entry of the same identity
+func findACL(acl []zk.ACL, entry zk.ACL) (zk.ACL, bool) {
+	for _, e := range acl {
+		if isSameACL(e, entry) {
+			return e, true
+		}
+	}
+	return zk.ACL{}, false
+}
+
+// isEqualACLs checks whether ACLs have the same entries
+func isEqualACLs(a, b []zk.ACL) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, entry := range a {
+		e, found := findACL(b, entry)
+		if !found || (e.Perms != entry.Perms) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package zookeeper
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLStringRoundTrip(t *testing.T) {
+	acl := []zk.ACL{
+		{Scheme: "world", ID: "anyone", Perms: zk.PermRead},
+		{Scheme: "digest", ID: "user:hash+/=", Perms: zk.PermAll},
+	}
+	str := ACLString(acl)
+	require.Equal(t, "digest:user:hash+/=:31,world:anyone:1", str)
+
+	parsed, err := ParseACL(str)
+	require.NoError(t, err)
+	require.True(t, isEqualACLs(acl, parsed))
+
+	_, err = ParseACL("world:anyone")
+	require.Error(t, err)
+}
+
+func TestFreezeThawACL(t *testing.T) {
+	world := zk.WorldACL(zk.PermAll)
+	digest := []zk.ACL{DigestACL("user:password"), {Scheme: "world", ID: "anyone", Perms: zk.PermRead}}
+	readOnly := zk.WorldACL(zk.PermRead)
+
+	tests := []struct {
+		name   string
+		acl    []zk.ACL
+		frozen []zk.ACL
+	}{
+		{name: "world", acl: world, frozen: zk.WorldACL(zk.PermRead | zk.PermAdmin)},
+		{name: "digest", acl: digest, frozen: []zk.ACL{
+			{Scheme: "digest", ID: digest[0].ID, Perms: zk.PermRead | zk.PermAdmin},
+			{Scheme: "world", ID: "anyone", Perms: zk.PermRead},
+		}},
+		{name: "read-only", acl: readOnly, frozen: readOnly},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frozen := FreezeACL(tt.acl)
+			require.True(t, isEqualACLs(tt.frozen, frozen))
+
+			mapping := map[string]string{ACLString(frozen): ACLString(tt.acl)}
+			thawed, err := ThawACL(frozen, mapping)
+			require.NoError(t, err)
+			require.True(t, isEqualACLs(tt.acl, thawed))
+		})
+	}
+
+	// ACL not frozen is not changed by thaw
+	thawed, err := ThawACL(world, map[string]string{ACLString(readOnly): ACLString(world)})
+	require.NoError(t, err)
+	require.Equal(t, world, thawed)
+}
+
+func TestProtectACL(t *testing.T) {
+	digest := DigestACL("user:password")
+	previous := DigestACL("user:previous")
+
+	tests := []struct {
+		name string
+		acl  []zk.ACL
+		want []zk.ACL
+	}{
+		{
+			name: "world",
+			acl:  zk.WorldACL(zk.PermAll),
+			want: []zk.ACL{digest},
+		},
+		{
+			name: "protected already",
+			acl:  []zk.ACL{digest},
+			want: []zk.ACL{digest},
+		},
+		{
+			name: "identity being rotated is kept",
+			acl:  []zk.ACL{previous, {Scheme: "world", ID: "anyone", Perms: zk.PermRead}},
+			want: []zk.ACL{digest, previous},
+		},
+		{
+			name: "digest perms are restored",
+			acl:  []zk.ACL{{Scheme: digest.Scheme, ID: digest.ID, Perms: zk.PermRead}},
+			want: []zk.ACL{digest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, isEqualACLs(tt.want, ProtectACL(tt.acl, digest)))
+		})
+	}
+}
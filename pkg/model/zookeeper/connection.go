@@ -62,6 +62,14 @@ func (c *Connection) Get(ctx context.Context, path string) (data []byte, stat *z
 	return
 }
 
+func (c *Connection) Children(ctx context.Context, path string) (children []string, stat *zk.Stat, err error) {
+	err = c.retry(ctx, func(connection *zk.Conn) error {
+		children, stat, err = connection.Children(path)
+		return err
+	})
+	return
+}
+
 func (c *Connection) Exists(ctx context.Context, path string) bool {
 	exists, _, _ := c.Details(ctx, path)
 	return exists
@@ -91,12 +99,46 @@ func (c *Connection) Set(ctx context.Context, path string, value []byte, version
 	return
 }
 
+func (c *Connection) GetACL(ctx context.Context, path string) (acl []zk.ACL, stat *zk.Stat, err error) {
+	err = c.retry(ctx, func(connection *zk.Conn) error {
+		acl, stat, err = connection.GetACL(path)
+		return err
+	})
+	return
+}
+
+func (c *Connection) SetACL(ctx context.Context, path string, acl []zk.ACL, version int32) (stat *zk.Stat, err error) {
+	err = c.retry(ctx, func(connection *zk.Conn) error {
+		stat, err = connection.SetACL(path, acl, version)
+		return err
+	})
+	return
+}
+
 func (c *Connection) Delete(ctx context.Context, path string, version int32) error {
 	return c.retry(ctx, func(connection *zk.Conn) error {
 		return connection.Delete(path, version)
 	})
 }
 
+// Multi runs operations as a single transaction
+func (c *Connection) Multi(ctx context.Context, ops ...interface{}) (responses []zk.MultiResponse, err error) {
+	err = c.retry(ctx, func(connection *zk.Conn) error {
+		responses, err = connection.Multi(ops...)
+		return err
+	})
+	return
+}
+
+// IncrementalReconfig adds joining and removes leaving members of the ensemble
+func (c *Connection) IncrementalReconfig(ctx context.Context, joining, leaving []string) (stat *zk.Stat, err error) {
+	err = c.retry(ctx, func(connection *zk.Conn) error {
+		stat, err = connection.IncrementalReconfig(joining, leaving, -1)
+		return err
+	})
+	return
+}
+
 func (c *Connection) Close() error {
 	if c == nil {
 		return nil
@@ -159,6 +201,12 @@ func (c *Connection) ensureConnection(ctx context.Context) (*zk.Conn, error) {
 }
 
 func (c *Connection) connectionAddAuth(ctx context.Context) {
+	for _, identity := range c.Identities {
+		if err := c.connection.AddAuth("digest", []byte(identity)); err != nil {
+			log.Error("failed to add digest auth to zk connection: %v", err)
+		}
+	}
+
 	if c.AuthFile == "" {
 		return
 	}
@@ -34,6 +34,9 @@ type ConnectionParams struct {
 	KeyFile  string
 	CaFile   string
 	AuthFile string
+
+	// Identities specifies digest identities in `user:password` format the connection is authenticated with
+	Identities []string
 }
 
 func (p *ConnectionParams) Normalize() *ConnectionParams {
@@ -0,0 +1,242 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeper
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// KeeperConfigPath specifies path ClickHouse Keeper exposes its current cluster configuration at
+	KeeperConfigPath = "/keeper/config"
+
+	timeoutFourLetterWord = 10 * time.Second
+)
+
+// KeeperServer defines member of ClickHouse Keeper cluster, as listed in cluster configuration
+type KeeperServer struct {
+	ID       int
+	Hostname string
+	Port     int
+	// Raw specifies the whole server line, ex.: server.1=host:9234;participant;1
+	Raw string
+}
+
+// NewKeeperServer creates new Keeper server
+func NewKeeperServer(id int, hostname string, port int) *KeeperServer {
+	return &KeeperServer{
+		ID:       id,
+		Hostname: hostname,
+		Port:     port,
+		Raw:      fmt.Sprintf("server.%d=%s:%d;participant;1", id, hostname, port),
+	}
+}
+
+// GetKeeperServers gets members of ClickHouse Keeper cluster, mapped by server id
+func (c *Connection) GetKeeperServers(ctx context.Context) (map[int]*KeeperServer, error) {
+	data, _, err := c.Get(ctx, KeeperConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeeperServers(string(data)), nil
+}
+
+// ParseKeeperServers parses cluster configuration of ClickHouse Keeper
+func ParseKeeperServers(config string) map[int]*KeeperServer {
+	servers := make(map[int]*KeeperServer)
+	for _, line := range strings.Split(config, "\n") {
+		// server.1=host:9234;participant;1
+		line = strings.TrimSpace(line)
+		key, value, found := strings.Cut(line, "=")
+		if !found || !strings.HasPrefix(key, "server.") {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(key, "server."))
+		if err != nil {
+			continue
+		}
+		address, _, _ := strings.Cut(value, ";")
+		hostname, port, err := net.SplitHostPort(address)
+		if err != nil {
+			continue
+		}
+		portNum, _ := strconv.Atoi(port)
+		servers[id] = &KeeperServer{
+			ID:       id,
+			Hostname: hostname,
+			Port:     portNum,
+			Raw:      line,
+		}
+	}
+	return servers
+}
+
+// FourLetterWord sends four letter word command to the server and returns the response
+func FourLetterWord(ctx context.Context, address, word string) (string, error) {
+	dialer := net.Dialer{
+		Timeout: timeoutFourLetterWord,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(timeoutFourLetterWord))
+	if _, err := conn.Write([]byte(word)); err != nil {
+		return "", err
+	}
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return "", err
+	}
+	return string(response), nil
+}
+
+// Mntr runs 'mntr' four letter word command and returns the response as key-value map
+func Mntr(ctx context.Context, address string) (map[string]string, error) {
+	response, err := FourLetterWord(ctx, address, "mntr")
+	if err != nil {
+		return nil, err
+	}
+	// zk_server_state	leader
+	return parseFourLetterWordResponse(response, "\t"), nil
+}
+
+// Srvr runs 'srvr' four letter word command and returns the response as key-value map
+func Srvr(ctx context.Context, address string) (map[string]string, error) {
+	response, err := FourLetterWord(ctx, address, "srvr")
+	if err != nil {
+		return nil, err
+	}
+	// Mode: leader
+	return parseFourLetterWordResponse(response, ":"), nil
+}
+
+// YieldLeadership runs 'ydld' four letter word command, which requests the leader to become a follower
+func YieldLeadership(ctx context.Context, address string) error {
+	_, err := FourLetterWord(ctx, address, "ydld")
+	return err
+}
+
+// GetServerState gets state of the server, as reported by 'mntr' and falling back to 'srvr'
+func GetServerState(ctx context.Context, address string) string {
+	if mntr, err := Mntr(ctx, address); err == nil {
+		if state, found := mntr["zk_server_state"]; found {
+			return state
+		}
+	}
+	if srvr, err := Srvr(ctx, address); err == nil {
+		return srvr["Mode"]
+	}
+	return ""
+}
+
+func parseFourLetterWordResponse(response, separator string) map[string]string {
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(response))
+	for scanner.Scan() {
+		if key, value, found := strings.Cut(scanner.Text(), separator); found {
+			result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return result
+}
+
+// Possible values of zk_server_state reported by 'mntr'
+const (
+	ServerStateLeader     = "leader"
+	ServerStateFollower   = "follower"
+	ServerStateObserver   = "observer"
+	ServerStateStandalone = "standalone"
+)
+
+// ServerStats defines state of the server, as reported by four letter word commands
+type ServerStats struct {
+	// OK specifies whether server responded to 'ruok' command as running in a non-error state
+	OK                  bool
+	State               string
+	Zxid                string
+	OutstandingRequests int64
+	ZnodeCount          int64
+	Followers           int64
+	SyncedFollowers     int64
+}
+
+// GetServerStats gets state of the server with 'ruok', 'mntr' and 'srvr' commands
+func GetServerStats(ctx context.Context, address string) (*ServerStats, error) {
+	ruok, err := FourLetterWord(ctx, address, "ruok")
+	if err != nil {
+		return nil, err
+	}
+	stats := &ServerStats{
+		OK: strings.TrimSpace(ruok) == "imok",
+	}
+	if !stats.OK {
+		return stats, nil
+	}
+
+	mntr, err := Mntr(ctx, address)
+	if err != nil {
+		return stats, err
+	}
+	stats.State = mntr["zk_server_state"]
+	stats.OutstandingRequests, _ = strconv.ParseInt(mntr["zk_outstanding_requests"], 10, 64)
+	stats.ZnodeCount, _ = strconv.ParseInt(mntr["zk_znode_count"], 10, 64)
+	stats.Followers, _ = strconv.ParseInt(mntr["zk_followers"], 10, 64)
+	stats.SyncedFollowers, _ = strconv.ParseInt(mntr["zk_synced_followers"], 10, 64)
+
+	// Zxid is reported by 'srvr' only
+	if srvr, err := Srvr(ctx, address); err == nil {
+		stats.Zxid = srvr["Zxid"]
+	}
+
+	return stats, nil
+}
+
+// IsMember checks whether server is a voting member of the cluster
+func (s *ServerStats) IsMember() bool {
+	if s == nil || !s.OK {
+		return false
+	}
+	switch s.State {
+	case ServerStateLeader, ServerStateFollower, ServerStateStandalone:
+		return true
+	}
+	return false
+}
+
+// IsQuorumHealthy checks whether majority of the cluster members is alive and the cluster has a leader.
+// Stats are expected to be provided for all members of the cluster, nil for unreachable ones.
+func IsQuorumHealthy(stats []*ServerStats) bool {
+	members := 0
+	leaders := 0
+	for _, s := range stats {
+		if s.IsMember() {
+			members++
+		}
+		if s.IsMember() && (s.State != ServerStateFollower) {
+			leaders++
+		}
+	}
+	return (leaders == 1) && (members > len(stats)/2)
+}
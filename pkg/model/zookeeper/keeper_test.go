@@ -0,0 +1,102 @@
+package zookeeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeeperServers(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   map[int]*KeeperServer
+	}{
+		{
+			name:   "empty",
+			config: "",
+			want:   map[int]*KeeperServer{},
+		},
+		{
+			name: "members",
+			config: "server.1=chk-keeper-0-0:9234;participant;1\n" +
+				"server.2=chk-keeper-0-1:9234;participant;1\n" +
+				"server.3=chk-keeper-0-2:9234;learner;1\n",
+			want: map[int]*KeeperServer{
+				1: {ID: 1, Hostname: "chk-keeper-0-0", Port: 9234, Raw: "server.1=chk-keeper-0-0:9234;participant;1"},
+				2: {ID: 2, Hostname: "chk-keeper-0-1", Port: 9234, Raw: "server.2=chk-keeper-0-1:9234;participant;1"},
+				3: {ID: 3, Hostname: "chk-keeper-0-2", Port: 9234, Raw: "server.3=chk-keeper-0-2:9234;learner;1"},
+			},
+		},
+		{
+			name:   "surrounding spaces and no role",
+			config: "  server.5=keeper:9444  \n",
+			want: map[int]*KeeperServer{
+				5: {ID: 5, Hostname: "keeper", Port: 9444, Raw: "server.5=keeper:9444"},
+			},
+		},
+		{
+			name:   "ipv6",
+			config: "server.1=[::1]:9234;participant;1",
+			want: map[int]*KeeperServer{
+				1: {ID: 1, Hostname: "::1", Port: 9234, Raw: "server.1=[::1]:9234;participant;1"},
+			},
+		},
+		{
+			name: "malformed lines are skipped",
+			config: "version=100000000\n" +
+				"server.x=keeper:9234;participant;1\n" +
+				"server.2=keeper;participant;1\n" +
+				"server.3\n" +
+				"server.4=keeper-4:9234;participant;1\n",
+			want: map[int]*KeeperServer{
+				4: {ID: 4, Hostname: "keeper-4", Port: 9234, Raw: "server.4=keeper-4:9234;participant;1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ParseKeeperServers(tt.config))
+		})
+	}
+}
+
+func TestNewKeeperServerRoundTrip(t *testing.T) {
+	server := NewKeeperServer(3, "chk-keeper-0-2", 9234)
+	require.Equal(t, map[int]*KeeperServer{3: server}, ParseKeeperServers(server.Raw))
+}
+
+func TestIsQuorumHealthy(t *testing.T) {
+	leader := &ServerStats{OK: true, State: ServerStateLeader}
+	follower := &ServerStats{OK: true, State: ServerStateFollower}
+	standalone := &ServerStats{OK: true, State: ServerStateStandalone}
+	observer := &ServerStats{OK: true, State: ServerStateObserver}
+	notOK := &ServerStats{OK: false, State: ServerStateFollower}
+	unknown := &ServerStats{OK: true}
+
+	tests := []struct {
+		name  string
+		stats []*ServerStats
+		want  bool
+	}{
+		{name: "no members", stats: nil, want: false},
+		{name: "standalone", stats: []*ServerStats{standalone}, want: true},
+		{name: "single unreachable", stats: []*ServerStats{nil}, want: false},
+		{name: "all alive", stats: []*ServerStats{leader, follower, follower}, want: true},
+		{name: "majority of three", stats: []*ServerStats{leader, follower, nil}, want: true},
+		{name: "minority of three", stats: []*ServerStats{leader, nil, nil}, want: false},
+		{name: "half of four", stats: []*ServerStats{leader, follower, nil, nil}, want: false},
+		{name: "majority of four", stats: []*ServerStats{leader, follower, follower, nil}, want: true},
+		{name: "majority of five", stats: []*ServerStats{leader, follower, follower, nil, notOK}, want: true},
+		{name: "minority of five", stats: []*ServerStats{leader, follower, nil, notOK, unknown}, want: false},
+		{name: "no leader", stats: []*ServerStats{follower, follower, follower}, want: false},
+		{name: "two leaders", stats: []*ServerStats{leader, leader, follower}, want: false},
+		{name: "observer is not a member", stats: []*ServerStats{leader, observer, observer}, want: false},
+		{name: "not ok leader", stats: []*ServerStats{{OK: false, State: ServerStateLeader}, follower, follower}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsQuorumHealthy(tt.stats))
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeper
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+
+	log "github.com/altinity/clickhouse-operator/pkg/announcer"
+	"github.com/altinity/clickhouse-operator/pkg/util"
+)
+
+// systemPaths specifies paths maintained by zookeeper or keeper itself, which are never copied
+var systemPaths = []string{
+	"/zookeeper",
+	"/keeper",
+}
+
+const (
+	// sequenceLen specifies length of the sequence number suffix of sequential znodes
+	sequenceLen = 10
+	// sequenceBatchSize specifies how many sequential znodes are created by one request while sequence is restored
+	sequenceBatchSize = 1000
+	// sequenceBumpPrefix specifies name prefix of the temporary znodes created while sequence is restored
+	sequenceBumpPrefix = "zk-copy-sequence-"
+)
+
+// sequentialParents specifies names of the znodes ClickHouse creates sequential children in,
+// which need sequence to be restored even in case they have no children at the moment
+var sequentialParents = []string{
+	"log",
+	"mutations",
+	"queue",
+	"ddl",
+}
+
+// TreeCopier copies tree of znodes from one ensemble to another
+type TreeCopier struct {
+	from *Connection
+	to   *Connection
+	// frozen specifies map of frozen to original ACLs, see ACLManager.Freeze
+	frozen map[string]string
+}
+
+// NewTreeCopier creates new tree copier.
+// Source tree is expected to be frozen with the specified map of frozen to original ACLs, so it does not change while copied.
+func NewTreeCopier(from, to *Connection, frozen map[string]string) *TreeCopier {
+	return &TreeCopier{
+		from:   from,
+		to:     to,
+		frozen: frozen,
+	}
+}
+
+// Copy copies tree rooted at the specified path and returns number of znodes copied.
+// Target tree is made equal to the source: existing znodes are overwritten with the data of the source
+// and znodes absent in the source are deleted. Ephemeral znodes are skipped, since they belong to the sessions of the source ensemble.
+// Original ACLs of the frozen source are set on the target and sequential counters of the parents are restored,
+// thus sequential znodes created on the target later do not reuse numbers of the source.
+func (c *TreeCopier) Copy(ctx context.Context, root string) (int, error) {
+	root = "/" + strings.Trim(strings.TrimSpace(root), "/")
+
+	// Parents of the root are ensured to exist
+	NewPathManager(c.to).Ensure(path.Dir(root))
+
+	var copied []string
+	queue := []string{root}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return len(copied), err
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+		if isSystemPath(current) {
+			continue
+		}
+
+		data, stat, err := c.from.Get(ctx, current)
+		if err == zk.ErrNoNode {
+			// Deleted in the meantime
+			continue
+		}
+		if err != nil {
+			return len(copied), err
+		}
+		if stat.EphemeralOwner != 0 {
+			continue
+		}
+
+		if err := c.copyNode(ctx, current, data); err != nil {
+			return len(copied), err
+		}
+		copied = append(copied, current)
+
+		children, _, err := c.from.Children(ctx, current)
+		if err != nil && err != zk.ErrNoNode {
+			return len(copied), err
+		}
+		if err := c.deleteExtraChildren(ctx, current, children); err != nil {
+			return len(copied), err
+		}
+		if isSequentialParent(current, children) {
+			if err := c.restoreSequence(ctx, current, getNextSequence(stat, children)); err != nil {
+				return len(copied), err
+			}
+		}
+		for _, child := range children {
+			queue = append(queue, path.Join(current, child))
+		}
+	}
+
+	// ACLs are copied after all znodes are created, since original ACLs may not allow to create children
+	for _, _path := range copied {
+		if err := c.copyACL(ctx, _path); err != nil {
+			return len(copied), err
+		}
+	}
+
+	log.Info("zk tree copied: %s znodes: %d", root, len(copied))
+	return len(copied), nil
+}
+
+// copyNode creates or updates znode on the target
+func (c *TreeCopier) copyNode(ctx context.Context, _path string, data []byte) error {
+	if _path == "/" {
+		// Root always exists and has no data of interest
+		return nil
+	}
+
+	existing, _, err := c.to.Get(ctx, _path)
+	switch err {
+	case nil:
+		if bytes.Equal(existing, data) {
+			return nil
+		}
+		_, err = c.to.Set(ctx, _path, data, -1)
+		return err
+	case zk.ErrNoNode:
+		// Original ACL is set by copyACL later on
+		_, err = c.to.Create(ctx, _path, data, 0, zk.WorldACL(zk.PermAll))
+		if err == zk.ErrNodeExists {
+			// Created in the meantime
+			return nil
+		}
+		return err
+	default:
+		return err
+	}
+}
+
+// copyACL sets original ACL of the source znode on the target
+func (c *TreeCopier) copyACL(ctx context.Context, _path string) error {
+	if _path == "/" {
+		// Root ACL is managed by the ensemble owner
+		return nil
+	}
+
+	acl, _, err := c.from.GetACL(ctx, _path)
+	if err != nil {
+		return err
+	}
+	if acl, err = ThawACL(acl, c.frozen); err != nil {
+		return err
+	}
+
+	existing, stat, err := c.to.GetACL(ctx, _path)
+	if err != nil {
+		return err
+	}
+	if isEqualACLs(existing, acl) {
+		return nil
+	}
+	_, err = c.to.SetACL(ctx, _path, acl, stat.Aversion)
+	return err
+}
+
+// deleteExtraChildren deletes children of the target znode, which are absent in the source
+func (c *TreeCopier) deleteExtraChildren(ctx context.Context, parent string, children []string) error {
+	existing, _, err := c.to.Children(ctx, parent)
+	if err != nil {
+		return err
+	}
+	for _, child := range existing {
+		_path := path.Join(parent, child)
+		if util.InArray(child, children) || isSystemPath(_path) {
+			continue
+		}
+		if err := c.deleteTree(ctx, _path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTree deletes tree rooted at the specified path on the target
+func (c *TreeCopier) deleteTree(ctx context.Context, _path string) error {
+	children, _, err := c.to.Children(ctx, _path)
+	if err == zk.ErrNoNode {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := c.deleteTree(ctx, path.Join(_path, child)); err != nil {
+			return err
+		}
+	}
+	if err := c.to.Delete(ctx, _path, -1); err != nil && err != zk.ErrNoNode {
+		return err
+	}
+	return nil
+}
+
+// restoreSequence advances sequential counter of the target znode up to the specified next sequence number.
+// Counter is advanced by sequential children created and deleted right away in batches.
+func (c *TreeCopier) restoreSequence(ctx context.Context, parent string, next int64) error {
+	prefix := path.Join(parent, sequenceBumpPrefix)
+	batch := int64(1)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		creates := make([]interface{}, 0, batch)
+		for i := int64(0); i < batch; i++ {
+			creates = append(creates, &zk.CreateRequest{
+				Path:  prefix,
+				Acl:   zk.WorldACL(zk.PermAll),
+				Flags: zk.FlagSequence,
+			})
+		}
+		responses, err := c.to.Multi(ctx, creates...)
+		if err != nil {
+			return err
+		}
+
+		last := int64(-1)
+		deletes := make([]interface{}, 0, len(responses))
+		for _, response := range responses {
+			seq, ok := getSequence(response.String)
+			if !ok {
+				return fmt.Errorf("unexpected sequential znode created: %s", response.String)
+			}
+			if seq > last {
+				last = seq
+			}
+			deletes = append(deletes, &zk.DeleteRequest{
+				Path:    response.String,
+				Version: -1,
+			})
+		}
+		if _, err := c.to.Multi(ctx, deletes...); err != nil {
+			return err
+		}
+
+		if last+1 >= next {
+			log.V(1).Info("zk sequence restored: %s next: %d", parent, last+1)
+			return nil
+		}
+		batch = next - last - 1
+		if batch > sequenceBatchSize {
+			batch = sequenceBatchSize
+		}
+	}
+}
+
+// isSequentialParent checks whether znode has sequential children, so its sequential counter matters
+func isSequentialParent(_path string, children []string) bool {
+	if util.InArray(path.Base(_path), sequentialParents) || (path.Base(path.Dir(_path)) == "block_numbers") {
+		// Block numbers are allocated by ephemeral sequential znodes, which are not copied
+		return true
+	}
+	for _, child := range children {
+		if _, ok := getSequence(child); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getNextSequence gets sequence number the next sequential child of the znode would get
+func getNextSequence(stat *zk.Stat, children []string) int64 {
+	// Parent's cversion is the sequence number of the next sequential child
+	next := int64(stat.Cversion)
+	for _, child := range children {
+		if seq, ok := getSequence(child); ok && (seq+1 > next) {
+			next = seq + 1
+		}
+	}
+	return next
+}
+
+// getSequence gets sequence number of the sequential znode from the name suffix
+func getSequence(name string) (int64, bool) {
+	if len(name) < sequenceLen {
+		return 0, false
+	}
+	suffix := name[len(name)-sequenceLen:]
+	for _, r := range suffix {
+		if (r < '0') || (r > '9') {
+			return 0, false
+		}
+	}
+	seq, err := strconv.ParseInt(suffix, 10, 64)
+	return seq, err == nil
+}
+
+// isSystemPath checks whether path belongs to the system subtree
+func isSystemPath(_path string) bool {
+	for _, systemPath := range systemPaths {
+		if _path == systemPath || strings.HasPrefix(_path, systemPath+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package zookeeper
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  int64
+		ok   bool
+	}{
+		{name: "log-0000000123", seq: 123, ok: true},
+		{name: "0000000005", seq: 5, ok: true},
+		{name: "query-0000012345", seq: 12345, ok: true},
+		{name: "all_0_0_0", ok: false},
+		{name: "000000001", ok: false},
+		{name: "log-00000001x3", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seq, ok := getSequence(tt.name)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.seq, seq)
+		})
+	}
+}
+
+func TestGetNextSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		cversion int32
+		children []string
+		want     int64
+	}{
+		{name: "no children", cversion: 10, want: 10},
+		{name: "cversion ahead of children", cversion: 10, children: []string{"log-0000000003"}, want: 10},
+		{name: "children ahead of cversion", cversion: 1, children: []string{"log-0000000003", "log-0000000007"}, want: 8},
+		{name: "not sequential children", cversion: 2, children: []string{"all_0_0_0"}, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getNextSequence(&zk.Stat{Cversion: tt.cversion}, tt.children))
+		})
+	}
+}
+
+func TestIsSequentialParent(t *testing.T) {
+	require.True(t, isSequentialParent("/clickhouse/tables/0/t/log", nil))
+	require.True(t, isSequentialParent("/clickhouse/tables/0/t/block_numbers/all", nil))
+	require.True(t, isSequentialParent("/clickhouse/tables/0/t/replicas/r/queue", nil))
+	require.True(t, isSequentialParent("/clickhouse/tables/0/t/other", []string{"query-0000000001"}))
+	require.False(t, isSequentialParent("/clickhouse/tables/0/t/block_numbers", []string{"all"}))
+	require.False(t, isSequentialParent("/clickhouse/tables/0/t/replicas/r/parts", []string{"all_0_0_0"}))
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// IssueCertificate issues server and client certificate for specified DNS names signed by the provided CA.
+// CA certificate and key, as well as resulting certificate and key are PEM-encoded.
+func IssueCertificate(
+	caCertPEM []byte,
+	caKeyPEM []byte,
+	commonName string,
+	dnsNames []string,
+	duration time.Duration,
+) (certPEM []byte, keyPEM []byte, err error) {
+	ca, err := tls.X509KeyPair(caCertPEM, caKeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load CA key pair: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse CA certificate: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate key: %v", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate serial number: %v", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+		DNSNames:              dnsNames,
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.Add(duration),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), ca.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal key: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// ParseCertificatePEM parses the first certificate of the PEM-encoded data
+func ParseCertificatePEM(certPEM []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, certPEM = pem.Decode(certPEM)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// CertificateFingerprint gets SHA-256 fingerprint of the certificate
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// CertificateCoversDNSNames checks whether certificate is issued for all specified DNS names
+func CertificateCoversDNSNames(cert *x509.Certificate, dnsNames []string) bool {
+	have := make(map[string]bool, len(cert.DNSNames))
+	for _, name := range cert.DNSNames {
+		have[name] = true
+	}
+	for _, name := range dnsNames {
+		if !have[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCA creates self-signed CA certificate and key, both PEM-encoded
+func newTestCA(t *testing.T) (certPEM []byte, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestIssueCertificate(t *testing.T) {
+	caCertPEM, caKeyPEM := newTestCA(t)
+	dnsNames := []string{"localhost", "clickhouse-test", "clickhouse-test.default.svc"}
+
+	certPEM, keyPEM, err := IssueCertificate(caCertPEM, caKeyPEM, "clickhouse-test", dnsNames, time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, keyPEM)
+
+	cert, err := ParseCertificatePEM(certPEM)
+	require.NoError(t, err)
+	require.Equal(t, "clickhouse-test", cert.Subject.CommonName)
+	require.ElementsMatch(t, dnsNames, cert.DNSNames)
+	require.WithinDuration(t, time.Now().Add(time.Hour), cert.NotAfter, time.Minute)
+	require.ElementsMatch(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+
+	// Certificate is verified by the CA it is issued by
+	caCert, err := ParseCertificatePEM(caCertPEM)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	for _, name := range dnsNames {
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName:   name,
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		require.NoError(t, err, name)
+	}
+
+	// And is not verified by another CA
+	otherCertPEM, _ := newTestCA(t)
+	otherCert, err := ParseCertificatePEM(otherCertPEM)
+	require.NoError(t, err)
+	others := x509.NewCertPool()
+	others.AddCert(otherCert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: others})
+	require.Error(t, err)
+}
+
+func TestIssueCertificateBadCA(t *testing.T) {
+	caCertPEM, _ := newTestCA(t)
+	_, otherKeyPEM := newTestCA(t)
+
+	_, _, err := IssueCertificate(caCertPEM, otherKeyPEM, "test", []string{"test"}, time.Hour)
+	require.Error(t, err, "mismatched CA key")
+
+	_, _, err = IssueCertificate([]byte("garbage"), []byte("garbage"), "test", []string{"test"}, time.Hour)
+	require.Error(t, err, "malformed CA")
+}
+
+func TestParseCertificatePEM(t *testing.T) {
+	caCertPEM, caKeyPEM := newTestCA(t)
+
+	// Leading non-certificate blocks are skipped
+	cert, err := ParseCertificatePEM(append(append([]byte{}, caKeyPEM...), caCertPEM...))
+	require.NoError(t, err)
+	require.Equal(t, "test-ca", cert.Subject.CommonName)
+
+	_, err = ParseCertificatePEM(caKeyPEM)
+	require.Error(t, err)
+	_, err = ParseCertificatePEM(nil)
+	require.Error(t, err)
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	caCertPEM, caKeyPEM := newTestCA(t)
+	certPEM1, _, err := IssueCertificate(caCertPEM, caKeyPEM, "test", []string{"test"}, time.Hour)
+	require.NoError(t, err)
+	certPEM2, _, err := IssueCertificate(caCertPEM, caKeyPEM, "test", []string{"test"}, time.Hour)
+	require.NoError(t, err)
+
+	cert1, err := ParseCertificatePEM(certPEM1)
+	require.NoError(t, err)
+	cert1Again, err := ParseCertificatePEM(certPEM1)
+	require.NoError(t, err)
+	cert2, err := ParseCertificatePEM(certPEM2)
+	require.NoError(t, err)
+
+	require.Len(t, CertificateFingerprint(cert1), 64)
+	require.Equal(t, CertificateFingerprint(cert1), CertificateFingerprint(cert1Again))
+	// Re-issued certificate has to be told apart, as hosts are restarted on fingerprint change
+	require.NotEqual(t, CertificateFingerprint(cert1), CertificateFingerprint(cert2))
+}
+
+func TestCertificateCoversDNSNames(t *testing.T) {
+	cert := &x509.Certificate{DNSNames: []string{"a", "b", "c"}}
+
+	require.True(t, CertificateCoversDNSNames(cert, nil))
+	require.True(t, CertificateCoversDNSNames(cert, []string{"a", "c"}))
+	require.True(t, CertificateCoversDNSNames(cert, []string{"c", "b", "a"}))
+	require.False(t, CertificateCoversDNSNames(cert, []string{"a", "d"}))
+	require.False(t, CertificateCoversDNSNames(&x509.Certificate{}, []string{"a"}))
+}
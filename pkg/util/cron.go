@@ -0,0 +1,225 @@
+// Copyright 2019 Altinity Ltd and/or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit specifies how far in time to search for the next or previous activation of a cron schedule
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// CronSchedule is a parsed standard 5-field cron expression: minute hour day-of-month month day-of-week
+type CronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// domStar and dowStar are required in order to implement day-of-month vs day-of-week cron semantics
+	domStar bool
+	dowStar bool
+}
+
+// cronField describes boundaries of a cron field
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	cronFieldMinute = cronField{name: "minute", min: 0, max: 59}
+	cronFieldHour   = cronField{name: "hour", min: 0, max: 23}
+	cronFieldDOM    = cronField{name: "day of month", min: 1, max: 31}
+	cronFieldMonth  = cronField{name: "month", min: 1, max: 12}
+	// Day of week allows 7 as an alias of 0 - Sunday
+	cronFieldDOW = cronField{name: "day of week", min: 0, max: 7}
+)
+
+// ParseCron parses standard 5-field cron expression.
+// Each field supports `*`, single values, ranges `a-b`, steps `*/n` and `a-b/n` and comma-separated lists of these.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression '%s' has %d fields, expected 5", expr, len(fields))
+	}
+
+	var err error
+	schedule := &CronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	if schedule.minute, err = parseCronField(fields[0], cronFieldMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], cronFieldHour); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], cronFieldDOM); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], cronFieldMonth); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], cronFieldDOW); err != nil {
+		return nil, err
+	}
+	// Sunday may be specified as 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+
+	return schedule, nil
+}
+
+// parseCronField parses one field of a cron expression into a bitset
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("cron %s field '%s' has bad step", f.name, field)
+			}
+		}
+
+		from, to := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			from, err1 = strconv.Atoi(bounds[0])
+			to, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("cron %s field '%s' has bad range", f.name, field)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("cron %s field '%s' has bad value", f.name, field)
+			}
+			from, to = value, value
+			if step > 1 {
+				// 'a/n' means from 'a' till the end with step 'n'
+				to = f.max
+			}
+		}
+
+		if from < f.min || to > f.max || from > to {
+			return 0, fmt.Errorf("cron %s field '%s' is out of range %d-%d", f.name, field, f.min, f.max)
+		}
+		for i := from; i <= to; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// matchDay checks whether the day of t matches the schedule
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		// In case any of day fields is unrestricted, both have to match
+		return domMatch && dowMatch
+	}
+	// Both fields are restricted, either has to match
+	return domMatch || dowMatch
+}
+
+// Next finds the first activation of the schedule strictly after t.
+// Activation falling into the wall clock hour, skipped due to DST transition, is skipped,
+// activation falling into the wall clock hour, repeated due to DST transition, happens once.
+// Returns zero time in case no activation found.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	limit := t.Add(cronSearchLimit)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = cronForward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location()))
+		case !s.matchDay(t):
+			t = cronForward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()))
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = cronForward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location()))
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		case isRepeatedWallClock(t):
+			// The same wall clock time has already been passed an hour ago
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Prev finds the latest activation of the schedule not after t.
+// DST transitions are handled the same way as by Next.
+// Returns zero time in case no activation found.
+func (s *CronSchedule) Prev(t time.Time) time.Time {
+	limit := t.Add(-cronSearchLimit)
+	t = t.Truncate(time.Minute)
+	for t.After(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = cronBackward(t, time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute))
+		case !s.matchDay(t):
+			t = cronBackward(t, time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute))
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = cronBackward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute))
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(-time.Minute)
+		case isRepeatedWallClock(t):
+			// The same wall clock time has been passed an hour earlier, it is the activation
+			t = t.Add(-time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// cronForward moves forward to the candidate time. Wall clock time may not exist or be ambiguous
+// due to DST transition, thus candidate may be not after t, in this case moves one minute forward
+func cronForward(t, candidate time.Time) time.Time {
+	if candidate.After(t) {
+		return candidate
+	}
+	return t.Add(time.Minute)
+}
+
+// cronBackward moves backward to the candidate time. Wall clock time may not exist or be ambiguous
+// due to DST transition, thus candidate may be not before t, in this case moves one minute backward
+func cronBackward(t, candidate time.Time) time.Time {
+	if candidate.Before(t) {
+		return candidate
+	}
+	return t.Add(-time.Minute)
+}
+
+// isRepeatedWallClock checks whether wall clock time of t has already been passed an hour ago,
+// which happens when clocks go back due to DST transition
+func isRepeatedWallClock(t time.Time) bool {
+	hourAgo := t.Add(-time.Hour)
+	return (hourAgo.Day() == t.Day()) && (hourAgo.Hour() == t.Hour()) && (hourAgo.Minute() == t.Minute())
+}
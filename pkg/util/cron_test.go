@@ -0,0 +1,193 @@
+package util
+
+import (
+	"testing"
+	"time"
+	// Test environment may have no time zone database
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+		minute  []int
+		hour    []int
+		dow     []int
+	}{
+		{name: "every minute", expr: "* * * * *", minute: []int{0, 30, 59}, hour: []int{0, 23}},
+		{name: "single values", expr: "5 4 * * *", minute: []int{5}, hour: []int{4}},
+		{name: "range", expr: "10-12 * * * *", minute: []int{10, 11, 12}},
+		{name: "step", expr: "*/20 * * * *", minute: []int{0, 20, 40}},
+		{name: "range with step", expr: "10-30/10 * * * *", minute: []int{10, 20, 30}},
+		{name: "value with step", expr: "50/5 * * * *", minute: []int{50, 55}},
+		{name: "list", expr: "1,2,40-41 * * * *", minute: []int{1, 2, 40, 41}},
+		{name: "sunday as 7", expr: "0 0 * * 7", minute: []int{0}, dow: []int{0, 7}},
+		{name: "weekdays", expr: "0 0 * * 1-5", minute: []int{0}, dow: []int{1, 2, 3, 4, 5}},
+		{name: "too few fields", expr: "* * * *", wantErr: true},
+		{name: "too many fields", expr: "* * * * * *", wantErr: true},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: true},
+		{name: "day of month out of range", expr: "* * 0 * *", wantErr: true},
+		{name: "month out of range", expr: "* * * 13 *", wantErr: true},
+		{name: "reversed range", expr: "20-10 * * * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "bad value", expr: "a * * * *", wantErr: true},
+		{name: "bad range", expr: "1-a * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			bits := func(values []int) (res uint64) {
+				for _, v := range values {
+					res |= 1 << uint(v)
+				}
+				return res
+			}
+			if tt.minute != nil {
+				// Check exact bitset for minutes, except '*' which is checked by sampling
+				if tt.expr[0] == '*' && tt.expr[1] == ' ' {
+					require.Equal(t, bits(tt.minute), s.minute&bits(tt.minute))
+				} else {
+					require.Equal(t, bits(tt.minute), s.minute)
+				}
+			}
+			if tt.hour != nil {
+				require.Equal(t, bits(tt.hour), s.hour&bits(tt.hour))
+			}
+			if tt.dow != nil {
+				require.Equal(t, bits(tt.dow), s.dow)
+			}
+		})
+	}
+}
+
+func TestCronNextPrev(t *testing.T) {
+	utc := time.UTC
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		next time.Time
+		prev time.Time
+	}{
+		{
+			name: "daily",
+			expr: "0 20 * * *",
+			from: time.Date(2024, 5, 15, 12, 0, 0, 0, utc),
+			next: time.Date(2024, 5, 15, 20, 0, 0, 0, utc),
+			prev: time.Date(2024, 5, 14, 20, 0, 0, 0, utc),
+		},
+		{
+			name: "next is strictly after, prev is not after",
+			expr: "0 20 * * *",
+			from: time.Date(2024, 5, 15, 20, 0, 30, 0, utc),
+			next: time.Date(2024, 5, 16, 20, 0, 0, 0, utc),
+			prev: time.Date(2024, 5, 15, 20, 0, 0, 0, utc),
+		},
+		{
+			name: "weekdays over weekend",
+			expr: "0 8 * * 1-5",
+			// Saturday
+			from: time.Date(2024, 5, 18, 12, 0, 0, 0, utc),
+			next: time.Date(2024, 5, 20, 8, 0, 0, 0, utc),
+			prev: time.Date(2024, 5, 17, 8, 0, 0, 0, utc),
+		},
+		{
+			name: "day of month only",
+			expr: "0 0 1 * *",
+			from: time.Date(2024, 5, 15, 0, 0, 0, 0, utc),
+			next: time.Date(2024, 6, 1, 0, 0, 0, 0, utc),
+			prev: time.Date(2024, 5, 1, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "day of month or day of week when both are restricted",
+			expr: "0 0 1 * 1",
+			// Wednesday
+			from: time.Date(2024, 5, 15, 0, 0, 0, 0, utc),
+			// Monday
+			next: time.Date(2024, 5, 20, 0, 0, 0, 0, utc),
+			// Monday
+			prev: time.Date(2024, 5, 13, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "leap day",
+			expr: "0 0 29 2 *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, utc),
+			next: time.Date(2028, 2, 29, 0, 0, 0, 0, utc),
+			prev: time.Date(2024, 2, 29, 0, 0, 0, 0, utc),
+		},
+		{
+			name: "month wrap",
+			expr: "30 23 31 12 *",
+			from: time.Date(2024, 6, 1, 0, 0, 0, 0, utc),
+			next: time.Date(2024, 12, 31, 23, 30, 0, 0, utc),
+			prev: time.Date(2023, 12, 31, 23, 30, 0, 0, utc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			require.True(t, tt.next.Equal(s.Next(tt.from)), "next: %s", s.Next(tt.from))
+			require.True(t, tt.prev.Equal(s.Prev(tt.from)), "prev: %s", s.Prev(tt.from))
+		})
+	}
+}
+
+func TestCronNoActivation(t *testing.T) {
+	s, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, s.Next(from).IsZero())
+	require.True(t, s.Prev(from).IsZero())
+}
+
+func TestCronDST(t *testing.T) {
+	loc := mustLoadLocation(t, "America/New_York")
+
+	t.Run("activation in the skipped hour is skipped", func(t *testing.T) {
+		// Clocks jump from 02:00 to 03:00 on 2024-03-10
+		s, err := ParseCron("30 2 * * *")
+		require.NoError(t, err)
+		from := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+		next := s.Next(from)
+		require.Equal(t, "2024-03-11 02:30", next.Format("2006-01-02 15:04"))
+	})
+
+	t.Run("hour after the skipped one", func(t *testing.T) {
+		s, err := ParseCron("30 3 * * *")
+		require.NoError(t, err)
+		from := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+		next := s.Next(from)
+		require.True(t, time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC).Equal(next), "next: %s", next)
+	})
+
+	t.Run("activation in the repeated hour happens once", func(t *testing.T) {
+		// Clocks go back from 02:00 EDT to 01:00 EST on 2024-11-03
+		s, err := ParseCron("30 1 * * *")
+		require.NoError(t, err)
+		from := time.Date(2024, 11, 3, 0, 0, 0, 0, loc)
+		first := s.Next(from)
+		// 01:30 EDT
+		require.True(t, time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).Equal(first), "first: %s", first)
+		second := s.Next(first)
+		require.Equal(t, "2024-11-04 01:30", second.Format("2006-01-02 15:04"))
+		// Prev from within the repeated hour finds the only activation
+		prev := s.Prev(time.Date(2024, 11, 3, 6, 45, 0, 0, time.UTC).In(loc))
+		require.True(t, first.Equal(prev), "prev: %s", prev)
+	})
+}
@@ -18,6 +18,8 @@ import (
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	clickhouse_altinity_com "github.com/altinity/clickhouse-operator/pkg/apis/clickhouse.altinity.com"
 )
 
 // NamespaceName returns namespace and anme from the meta
@@ -41,6 +43,8 @@ func NamespacedName(obj meta.Object) types.NamespacedName {
 // AnnotationsToBeSkipped kubectl service annotation that we'd like to skip
 var AnnotationsToBeSkipped = []string{
 	"kubectl.kubernetes.io/last-applied-configuration",
+	// Schedule override is meaningful for the CHI itself only
+	clickhouse_altinity_com.AnnotationScheduleKeepRunningUntil,
 }
 
 // IsAnnotationToBeSkipped checks whether an annotation should be skipped